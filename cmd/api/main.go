@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,15 +17,40 @@ import (
 	"github.com/SergeyBogomolovv/fitflow/config"
 	_ "github.com/SergeyBogomolovv/fitflow/docs"
 	authHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/auth"
+	broadcastHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/broadcast"
 	contentHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/content"
+	planHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/plan"
+	promptHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/prompt"
+	queueHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/queue"
+	quizHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/quiz"
+	scheduleHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/schedule"
+	usageHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/usage"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	adminRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/admin"
+	autopilotRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/autopilot"
+	broadcastRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/broadcast"
+	planRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/plan"
 	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	promptRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/prompt"
+	quizRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/quiz"
+	scheduleRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/schedule"
+	usageRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/usage"
 	authSvc "github.com/SergeyBogomolovv/fitflow/internal/service/auth"
+	autopilotSvc "github.com/SergeyBogomolovv/fitflow/internal/service/autopilot"
+	broadcastSvc "github.com/SergeyBogomolovv/fitflow/internal/service/broadcast"
 	contentSvc "github.com/SergeyBogomolovv/fitflow/internal/service/content"
+	planSvc "github.com/SergeyBogomolovv/fitflow/internal/service/plan"
+	promptSvc "github.com/SergeyBogomolovv/fitflow/internal/service/prompt"
+	queueSvc "github.com/SergeyBogomolovv/fitflow/internal/service/queue"
+	quizSvc "github.com/SergeyBogomolovv/fitflow/internal/service/quiz"
+	scheduleSvc "github.com/SergeyBogomolovv/fitflow/internal/service/schedule"
+	usageSvc "github.com/SergeyBogomolovv/fitflow/internal/service/usage"
 	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
 	"github.com/SergeyBogomolovv/fitflow/pkg/db"
 	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/SergeyBogomolovv/fitflow/pkg/leader"
 	"github.com/SergeyBogomolovv/fitflow/pkg/logger"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
 	"github.com/SergeyBogomolovv/fitflow/pkg/uploader"
 	"github.com/joho/godotenv"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
@@ -46,9 +72,22 @@ func main() {
 	db := db.MustNew(conf.PG.URL)
 	logger.Info("database connected")
 
-	aiClient := ai.MustNewClient(ctx, conf.AI.Key)
-	aiGen := ai.NewContentGenerator(aiClient, conf.AI.Model, conf.AI.DefaultPrompt)
-	logger.Info("ai connected")
+	aiGen := ai.MustNew(ctx, ai.Options{
+		Provider:      ai.Provider(conf.AI.Provider),
+		Key:           conf.AI.Key,
+		BaseURL:       conf.AI.BaseURL,
+		Model:         conf.AI.Model,
+		DefaultPrompt: conf.AI.DefaultPrompt,
+	})
+	aiGen = ai.NewResilientGenerator(logger, aiGen, ai.ResilienceOptions{
+		Timeout:          conf.AI.Timeout,
+		Retries:          conf.AI.Retries,
+		BaseDelay:        conf.AI.RetryDelay,
+		MaxDelay:         conf.AI.MaxRetryDelay,
+		FailureThreshold: conf.AI.BreakerThreshold,
+		OpenTimeout:      conf.AI.BreakerTimeout,
+	})
+	logger.Info("ai connected", slog.String("provider", conf.AI.Provider))
 
 	s3 := uploader.MustNew(conf.S3.AccessKey, conf.S3.SecretKey, conf.S3.Region, conf.S3.Endpoint, conf.S3.Bucket)
 	logger.Info("s3 connected")
@@ -59,18 +98,80 @@ func main() {
 
 	adminRepo := adminRepo.New(db)
 	postRepo := postRepo.New(db)
+	planRepo := planRepo.New(db)
+	promptRepo := promptRepo.New(db)
+	usageRepo := usageRepo.New(db)
+	quizRepo := quizRepo.New(db)
+	scheduleRepo := scheduleRepo.New(db)
+	broadcastRepo := broadcastRepo.New(db)
+	autopilotRepo := autopilotRepo.New(db)
 	logger.Info("init repositories")
 
 	authSvc := authSvc.New(logger, adminRepo, conf.JWT.Secret, conf.JWT.TTL)
-	contentSvc := contentSvc.New(logger, postRepo, aiGen, s3)
+	usageSvc := usageSvc.New(logger, usageRepo, domain.Quota{Tokens: conf.AI.DailyTokens, Requests: conf.AI.DailyRequests})
+	contentSvc := contentSvc.New(logger, postRepo, promptRepo, usageSvc, aiGen, s3, contentSvc.Options{
+		CacheTTL: conf.AI.CacheTTL,
+		Review: domain.ReviewPolicy{
+			Enabled:   conf.Review.Enabled,
+			Rubric:    conf.Review.Rubric,
+			PassScore: conf.Review.PassScore,
+			Block:     conf.Review.Block,
+		},
+	})
+	planSvc := planSvc.New(logger, planRepo, postRepo, contentSvc, conf.Review.Block)
+	promptSvc := promptSvc.New(logger, promptRepo)
+	quizSvc := quizSvc.New(logger, quizRepo, conf.Quiz.Cooldown)
+	scheduleSvc := scheduleSvc.New(logger, scheduleRepo)
+	broadcastSvc := broadcastSvc.New(logger, broadcastRepo)
+	alerts := notify.New(notify.Options{
+		Token:      conf.TG.Token,
+		ChatID:     conf.Alerts.ChatID,
+		WebhookURL: conf.Alerts.WebhookURL,
+		Timeout:    conf.Alerts.Timeout,
+	})
+	// queues are monitored by the bot, api only reports their depth
+	queueSvc := queueSvc.New(logger, postRepo, scheduleSvc, alerts, domain.QueueThresholds{
+		Low:      conf.Queue.LowDays,
+		Critical: conf.Queue.CriticalDays,
+	})
+	autopilotAudiences := make([]domain.UserLvl, 0, len(conf.Autopilot.Audiences))
+	for _, audience := range conf.Autopilot.Audiences {
+		if !domain.UserLvl(audience).Valid() {
+			log.Fatalf("unknown autopilot audience: %s", audience)
+		}
+		autopilotAudiences = append(autopilotAudiences, domain.UserLvl(audience))
+	}
+	autopilotSvc := autopilotSvc.New(logger, postRepo, autopilotRepo, contentSvc, alerts, autopilotSvc.Options{
+		MinQueued: conf.Autopilot.MinQueued,
+		Batch:     conf.Autopilot.Batch,
+		Audiences: autopilotAudiences,
+		Themes:    conf.Autopilot.Themes,
+		Tone:      domain.Tone(conf.Autopilot.Tone),
+		Length:    domain.Length(conf.Autopilot.Length),
+		Language:  conf.Autopilot.Language,
+	})
 	logger.Info("init services")
 
 	authMiddleware := httpx.NewAuthMiddleware(authSvc.AuthFunc)
 
 	contentHandler := contentHandler.New(logger, contentSvc)
 	authHandler := authHandler.New(logger, authSvc)
+	planHandler := planHandler.New(logger, planSvc)
+	promptHandler := promptHandler.New(logger, promptSvc)
+	usageHandler := usageHandler.New(logger, usageSvc)
+	quizHandler := quizHandler.New(logger, quizSvc)
+	scheduleHandler := scheduleHandler.New(logger, scheduleSvc)
+	broadcastHandler := broadcastHandler.New(logger, broadcastSvc)
+	queueHandler := queueHandler.New(logger, queueSvc)
 	authHandler.Init(router)
 	contentHandler.Init(router, authMiddleware)
+	planHandler.Init(router, authMiddleware)
+	promptHandler.Init(router, authMiddleware)
+	usageHandler.Init(router, authMiddleware)
+	quizHandler.Init(router, authMiddleware)
+	scheduleHandler.Init(router, authMiddleware)
+	broadcastHandler.Init(router, authMiddleware)
+	queueHandler.Init(router, authMiddleware)
 	logger.Info("init handlers")
 
 	loggerMiddleware := httpx.NewLoggerMiddleware(logger)
@@ -81,7 +182,24 @@ func main() {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
+	// only one replica processes plan jobs, on start worker returns running jobs to the queue
+	planElector := leader.New(logger, db, conf.Plan.LockKey, conf.Leader.Interval)
+	go func() {
+		defer wg.Done()
+		planElector.Run(ctx, planSvc.Run)
+	}()
+	if conf.Autopilot.Enabled {
+		// only one replica refills queues, otherwise every replica would generate its own drafts
+		elector := leader.New(logger, db, conf.Autopilot.LockKey, conf.Leader.Interval)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			elector.Run(ctx, func(ctx context.Context) {
+				autopilotSvc.Run(ctx, conf.Autopilot.Interval)
+			})
+		}()
+	}
 	go func() {
 		defer wg.Done()
 		<-ctx.Done()
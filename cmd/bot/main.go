@@ -2,22 +2,44 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 
 	"github.com/SergeyBogomolovv/fitflow/config"
+	healthHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/health"
 	"github.com/SergeyBogomolovv/fitflow/internal/delivery/telegram"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	assistantRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/assistant"
+	broadcastRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/broadcast"
+	deliveryRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/delivery"
 	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	quizRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/quiz"
+	scheduleRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/schedule"
 	userRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/user"
+	workoutRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/workout"
+	assistantSvc "github.com/SergeyBogomolovv/fitflow/internal/service/assistant"
+	broadcastSvc "github.com/SergeyBogomolovv/fitflow/internal/service/broadcast"
+	deliverySvc "github.com/SergeyBogomolovv/fitflow/internal/service/delivery"
 	postSvc "github.com/SergeyBogomolovv/fitflow/internal/service/post"
+	queueSvc "github.com/SergeyBogomolovv/fitflow/internal/service/queue"
+	quizSvc "github.com/SergeyBogomolovv/fitflow/internal/service/quiz"
+	scheduleSvc "github.com/SergeyBogomolovv/fitflow/internal/service/schedule"
 	userSvc "github.com/SergeyBogomolovv/fitflow/internal/service/user"
+	workoutSvc "github.com/SergeyBogomolovv/fitflow/internal/service/workout"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
 	"github.com/SergeyBogomolovv/fitflow/pkg/bot"
 	"github.com/SergeyBogomolovv/fitflow/pkg/db"
+	"github.com/SergeyBogomolovv/fitflow/pkg/leader"
 	"github.com/SergeyBogomolovv/fitflow/pkg/logger"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
 	"github.com/joho/godotenv"
 )
 
@@ -34,33 +56,149 @@ func main() {
 	bot := bot.MustNew(conf.TG.Token)
 	logger.Info("telegram connected")
 
+	aiGen := ai.MustNew(context.Background(), ai.Options{
+		Provider:      ai.Provider(conf.AI.Provider),
+		Key:           conf.AI.Key,
+		BaseURL:       conf.AI.BaseURL,
+		Model:         conf.AI.Model,
+		DefaultPrompt: conf.AI.DefaultPrompt,
+	})
+	aiGen = ai.NewResilientGenerator(logger, aiGen, ai.ResilienceOptions{
+		Timeout:          conf.AI.Timeout,
+		Retries:          conf.AI.Retries,
+		BaseDelay:        conf.AI.RetryDelay,
+		MaxDelay:         conf.AI.MaxRetryDelay,
+		FailureThreshold: conf.AI.BreakerThreshold,
+		OpenTimeout:      conf.AI.BreakerTimeout,
+	})
+	logger.Info("ai connected", slog.String("provider", conf.AI.Provider))
+
 	userRepo := userRepo.New(db)
 	postsRepo := postRepo.New(db)
+	assistantRepo := assistantRepo.New(db)
+	workoutRepo := workoutRepo.New(db)
+	quizRepo := quizRepo.New(db)
+	deliveryRepo := deliveryRepo.New(db)
+	scheduleRepo := scheduleRepo.New(db)
+	broadcastRepo := broadcastRepo.New(db)
 	logger.Info("init repositories")
 
 	userSvc := userSvc.New(logger, userRepo)
-	postSvc := postSvc.New(logger, postsRepo)
+	postSvc := postSvc.New(logger, postsRepo, conf.Evergreen.MinInterval)
+	assistantSvc := assistantSvc.New(logger, assistantRepo, userRepo, aiGen, assistantSvc.Options{
+		DailyLimit:  conf.Assistant.DailyLimit,
+		HistorySize: conf.Assistant.HistorySize,
+		MemoryTTL:   conf.Assistant.MemoryTTL,
+		Prompt:      conf.Assistant.Prompt,
+	})
+	workoutSvc := workoutSvc.New(logger, workoutRepo, userRepo, aiGen, workoutSvc.Options{
+		DailyLimit: conf.Workout.DailyLimit,
+		Prompt:     conf.Workout.Prompt,
+	})
+	quizSvc := quizSvc.New(logger, quizRepo, conf.Quiz.Cooldown)
+	deliverySvc := deliverySvc.New(logger, deliveryRepo, deliverySvc.Options{
+		QuietHours: domain.DeliveryWindow{Start: conf.Delivery.QuietStart, End: conf.Delivery.QuietEnd},
+		MaxDelay:   conf.Delivery.MaxDelay,
+		BatchSize:  conf.Delivery.BatchSize,
+		// users who got evergreen post within its interval do not get it again
+		ResendInterval: conf.Evergreen.MinInterval,
+	})
+	scheduleSvc := scheduleSvc.New(logger, scheduleRepo)
+	broadcastSvc := broadcastSvc.New(logger, broadcastRepo)
+	alerts := notify.New(notify.Options{
+		Token:      conf.TG.Token,
+		ChatID:     conf.Alerts.ChatID,
+		WebhookURL: conf.Alerts.WebhookURL,
+		Timeout:    conf.Alerts.Timeout,
+	})
+	queueSvc := queueSvc.New(logger, postsRepo, scheduleSvc, alerts, domain.QueueThresholds{
+		Low:      conf.Queue.LowDays,
+		Critical: conf.Queue.CriticalDays,
+	})
 	logger.Info("init services")
 
-	telegram := telegram.New(logger, bot, postSvc, userSvc)
+	// specs from config are used only for audiences without schedule in database
+	if err := scheduleSvc.EnsureSchedules(context.Background(), map[domain.UserLvl]string{
+		domain.UserLvlDefault:      conf.TG.BroadcastSpec,
+		domain.UserLvlBeginner:     conf.TG.LevelSpec,
+		domain.UserLvlIntermediate: conf.TG.LevelSpec,
+		domain.UserLvlAdvanced:     conf.TG.LevelSpec,
+	}); err != nil {
+		log.Fatalf("failed to init schedules: %s", err)
+	}
+
+	var store state.State
+	switch conf.State.Driver {
+	case "memory":
+		store = state.NewMemory(conf.State.TTL)
+	case "postgres":
+		store = state.NewPostgres(db, conf.State.TTL)
+	default:
+		log.Fatalf("unknown state driver: %s", conf.State.Driver)
+	}
+	logger.Info("state initialized", slog.String("driver", conf.State.Driver))
+
+	telegram := telegram.New(logger, bot, store, postSvc, userSvc, assistantSvc, workoutSvc, quizSvc, deliverySvc, scheduleSvc, broadcastSvc)
 	telegram.Init()
 	logger.Info("init handlers")
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// only one replica schedules and sends posts, others wait to take over
+	elector := leader.New(logger, db, conf.Leader.LockKey, conf.Leader.Interval)
+
+	router := http.NewServeMux()
+	healthHandler.New(logger, elector, db).Init(router)
+	srv := &http.Server{Addr: conf.TG.HealthAddr, Handler: router}
+
+	var jobs sync.WaitGroup
+	jobs.Add(2)
+	go func() {
+		defer jobs.Done()
+		state.RunCleanup(ctx, logger, store, conf.State.CleanupInterval)
+	}()
+	go func() {
+		defer jobs.Done()
+		elector.Run(ctx, func(ctx context.Context) {
+			var wg sync.WaitGroup
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				telegram.RunScheduler(ctx, conf.TG.ScheduleReload)
+			}()
+			go func() {
+				defer wg.Done()
+				telegram.RunDispatcher(ctx, conf.Delivery.Interval)
+			}()
+			go func() {
+				defer wg.Done()
+				queueSvc.Run(ctx, conf.Queue.Interval)
+			}()
+			wg.Wait()
+		})
+	}()
+
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		logger.Info("starting health server", slog.String("addr", conf.TG.HealthAddr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("failed to start health server: %s", err)
+		}
+	}()
 	go func() {
 		defer wg.Done()
 		<-ctx.Done()
 		bot.Stop()
+		srv.Shutdown(context.Background())
+		jobs.Wait()
 		db.Close()
 		logger.Info("bot stopped")
 	}()
 
 	logger.Info("starting bot", slog.String("name", bot.Me.FirstName))
-	telegram.RunScheduler(ctx, conf.TG.BroadcastSpec, conf.TG.LevelSpec)
 	bot.Start()
 	wg.Wait()
 }
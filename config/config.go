@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -9,13 +10,25 @@ import (
 
 type (
 	Config struct {
-		HTTP HTTP `yaml:"http"`
-		JWT  JWT  `yaml:"jwt"`
-		Log  Log  `yaml:"logger"`
-		TG   TG   `yaml:"telegram"`
-		AI   AI   `yaml:"ai"`
-		S3   S3   `yaml:"s3"`
-		PG   PG
+		HTTP      HTTP      `yaml:"http"`
+		JWT       JWT       `yaml:"jwt"`
+		Log       Log       `yaml:"logger"`
+		TG        TG        `yaml:"telegram"`
+		State     State     `yaml:"state"`
+		Delivery  Delivery  `yaml:"delivery"`
+		Leader    Leader    `yaml:"leader"`
+		Queue     Queue     `yaml:"queue"`
+		Evergreen Evergreen `yaml:"evergreen"`
+		Alerts    Alerts    `yaml:"alerts"`
+		AI        AI        `yaml:"ai"`
+		Review    Review    `yaml:"review"`
+		Plan      Plan      `yaml:"plan"`
+		Autopilot Autopilot `yaml:"autopilot"`
+		Assistant Assistant `yaml:"assistant"`
+		Workout   Workout   `yaml:"workout"`
+		Quiz      Quiz      `yaml:"quiz"`
+		S3        S3        `yaml:"s3"`
+		PG        PG
 	}
 
 	HTTP struct {
@@ -33,9 +46,57 @@ type (
 	}
 
 	TG struct {
-		Token         string `env-required:"true" env:"BOT_TOKEN"`
+		Token string `env-required:"true" env:"BOT_TOKEN"`
+		// BroadcastSpec and LevelSpec are initial schedules, afterwards schedules are managed in database
 		BroadcastSpec string `env-required:"true" yaml:"broadcast_spec" env:"BOT_BROADCAST_SPEC"`
 		LevelSpec     string `env-required:"true" yaml:"level_spec" env:"BOT_LEVEL_SPEC"`
+		// ScheduleReload is period of reloading schedules from database
+		ScheduleReload time.Duration `env-default:"30s" yaml:"schedule_reload" env:"BOT_SCHEDULE_RELOAD"`
+		HealthAddr     string        `env-default:":8081" yaml:"health_addr" env:"BOT_HEALTH_ADDR"`
+	}
+
+	Delivery struct {
+		// QuietStart and QuietEnd are local hours of users when posts are not sent, equal hours disable quiet hours
+		QuietStart int           `env-default:"22" yaml:"quiet_start" env:"DELIVERY_QUIET_START"`
+		QuietEnd   int           `env-default:"8" yaml:"quiet_end" env:"DELIVERY_QUIET_END"`
+		MaxDelay   time.Duration `env-default:"24h" yaml:"max_delay" env:"DELIVERY_MAX_DELAY"`
+		Interval   time.Duration `env-default:"1m" yaml:"interval" env:"DELIVERY_INTERVAL"`
+		// BatchSize limits deliveries sent by dispatcher per interval
+		BatchSize int `env-default:"500" yaml:"batch_size" env:"DELIVERY_BATCH_SIZE"`
+	}
+
+	Leader struct {
+		// LockKey is postgres advisory lock key, replicas of one bot must share it
+		LockKey int64 `env-default:"7414" yaml:"lock_key" env:"LEADER_LOCK_KEY"`
+		// Interval is period of lock attempts and leader health checks, failover takes up to two intervals
+		Interval time.Duration `env-default:"5s" yaml:"interval" env:"LEADER_INTERVAL"`
+	}
+
+	Queue struct {
+		// LowDays and CriticalDays are days of content left at current schedule when admins are alerted
+		LowDays      float64       `env-default:"7" yaml:"low_days" env:"QUEUE_LOW_DAYS"`
+		CriticalDays float64       `env-default:"2" yaml:"critical_days" env:"QUEUE_CRITICAL_DAYS"`
+		Interval     time.Duration `env-default:"1h" yaml:"interval" env:"QUEUE_INTERVAL"`
+	}
+
+	Evergreen struct {
+		// MinInterval is minimal time before evergreen post is sent again, to audience and to every user
+		MinInterval time.Duration `env-default:"720h" yaml:"min_interval" env:"EVERGREEN_MIN_INTERVAL"`
+	}
+
+	Alerts struct {
+		// ChatID is telegram chat of admins, alerts are sent by the bot, zero disables chat alerts
+		ChatID int64 `yaml:"chat_id" env:"ALERTS_CHAT_ID"`
+		// WebhookURL receives alerts as JSON, empty url disables webhook
+		WebhookURL string        `yaml:"webhook_url" env:"ALERTS_WEBHOOK_URL"`
+		Timeout    time.Duration `env-default:"10s" yaml:"timeout" env:"ALERTS_TIMEOUT"`
+	}
+
+	State struct {
+		// Driver is memory or postgres, memory state is lost on restart and is not shared by replicas
+		Driver          string        `env-default:"postgres" yaml:"driver" env:"STATE_DRIVER"`
+		TTL             time.Duration `env-default:"24h" yaml:"ttl" env:"STATE_TTL"`
+		CleanupInterval time.Duration `env-default:"10m" yaml:"cleanup_interval" env:"STATE_CLEANUP_INTERVAL"`
 	}
 
 	JWT struct {
@@ -44,9 +105,70 @@ type (
 	}
 
 	AI struct {
-		Key           string `env-required:"true" env:"AI_KEY"`
+		Provider      string `env-default:"gemini" yaml:"provider" env:"AI_PROVIDER"`
+		Key           string `env:"AI_KEY"`
+		BaseURL       string `yaml:"base_url" env:"AI_BASE_URL"`
 		Model         string `env-required:"true" yaml:"model" env:"AI_MODEL"`
 		DefaultPrompt string `env-required:"true" yaml:"default_prompt" env:"AI_DEFAULT_PROMPT"`
+		// Daily quotas are per admin, zero means no limit
+		DailyTokens   int64         `yaml:"daily_tokens" env:"AI_DAILY_TOKENS"`
+		DailyRequests int           `yaml:"daily_requests" env:"AI_DAILY_REQUESTS"`
+		CacheTTL      time.Duration `env-default:"1h" yaml:"cache_ttl" env:"AI_CACHE_TTL"`
+		// Timeout limits single model call, failed calls are retried with exponential backoff
+		Timeout       time.Duration `env-default:"60s" yaml:"timeout" env:"AI_TIMEOUT"`
+		Retries       int           `env-default:"2" yaml:"retries" env:"AI_RETRIES"`
+		RetryDelay    time.Duration `env-default:"1s" yaml:"retry_delay" env:"AI_RETRY_DELAY"`
+		MaxRetryDelay time.Duration `env-default:"10s" yaml:"max_retry_delay" env:"AI_MAX_RETRY_DELAY"`
+		// BreakerThreshold consecutive provider failures stop calls for BreakerTimeout
+		BreakerThreshold int           `env-default:"5" yaml:"breaker_threshold" env:"AI_BREAKER_THRESHOLD"`
+		BreakerTimeout   time.Duration `env-default:"30s" yaml:"breaker_timeout" env:"AI_BREAKER_TIMEOUT"`
+	}
+
+	Review struct {
+		Enabled bool   `yaml:"enabled" env:"REVIEW_ENABLED"`
+		Rubric  string `yaml:"rubric" env:"REVIEW_RUBRIC"`
+		// PassScore is minimal score in every category, from 1 to 10
+		PassScore int  `env-default:"6" yaml:"pass_score" env:"REVIEW_PASS_SCORE"`
+		Block     bool `yaml:"block" env:"REVIEW_BLOCK"`
+	}
+
+	Plan struct {
+		// LockKey elects one api replica running plan worker, it must differ from other lock keys
+		LockKey int64 `env-default:"7416" yaml:"lock_key" env:"PLAN_LOCK_KEY"`
+	}
+
+	Autopilot struct {
+		Enabled bool `yaml:"enabled" env:"AUTOPILOT_ENABLED"`
+		// MinQueued is number of approved posts in queue, audiences with fewer posts get AI drafts for review
+		MinQueued int           `env-default:"5" yaml:"min_queued" env:"AUTOPILOT_MIN_QUEUED"`
+		Batch     int           `env-default:"3" yaml:"batch" env:"AUTOPILOT_BATCH"`
+		Interval  time.Duration `env-default:"1h" yaml:"interval" env:"AUTOPILOT_INTERVAL"`
+		Audiences []string      `env-default:"default,beginner,intermediate,advanced" yaml:"audiences" env:"AUTOPILOT_AUDIENCES"`
+		Themes    []string      `yaml:"themes" env:"AUTOPILOT_THEMES"`
+		Tone      string        `env-default:"friendly" yaml:"tone" env:"AUTOPILOT_TONE"`
+		Length    string        `env-default:"medium" yaml:"length" env:"AUTOPILOT_LENGTH"`
+		Language  string        `env-default:"ru" yaml:"language" env:"AUTOPILOT_LANGUAGE"`
+		// LockKey elects one api replica running autopilot, it must differ from bot leader key
+		LockKey int64 `env-default:"7415" yaml:"lock_key" env:"AUTOPILOT_LOCK_KEY"`
+	}
+
+	Assistant struct {
+		// DailyLimit is number of questions per user a day, zero means no limit
+		DailyLimit  int           `env-default:"20" yaml:"daily_limit" env:"ASSISTANT_DAILY_LIMIT"`
+		HistorySize int           `env-default:"5" yaml:"history_size" env:"ASSISTANT_HISTORY_SIZE"`
+		MemoryTTL   time.Duration `env-default:"24h" yaml:"memory_ttl" env:"ASSISTANT_MEMORY_TTL"`
+		Prompt      string        `env-required:"true" yaml:"prompt" env:"ASSISTANT_PROMPT"`
+	}
+
+	Workout struct {
+		// DailyLimit is number of generated plans per user a day, zero means no limit
+		DailyLimit int    `env-default:"5" yaml:"daily_limit" env:"WORKOUT_DAILY_LIMIT"`
+		Prompt     string `env-required:"true" yaml:"prompt" env:"WORKOUT_PROMPT"`
+	}
+
+	Quiz struct {
+		// Cooldown is minimal time between level test attempts of one user, zero means no cooldown
+		Cooldown time.Duration `env-default:"24h" yaml:"cooldown" env:"QUIZ_COOLDOWN"`
 	}
 
 	S3 struct {
@@ -69,5 +191,30 @@ func MustNewConfig(path string) *Config {
 		log.Fatalf("config env error: %s", err)
 	}
 
+	if err := cfg.validate(); err != nil {
+		log.Fatalf("config error: %s", err)
+	}
+
 	return cfg
 }
+
+// validate checks periods of background loops, tickers panic on zero or negative period
+func (c *Config) validate() error {
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"telegram.schedule_reload", c.TG.ScheduleReload},
+		{"state.cleanup_interval", c.State.CleanupInterval},
+		{"delivery.interval", c.Delivery.Interval},
+		{"leader.interval", c.Leader.Interval},
+		{"queue.interval", c.Queue.Interval},
+		{"autopilot.interval", c.Autopilot.Interval},
+	}
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", interval.name, interval.value)
+		}
+	}
+	return nil
+}
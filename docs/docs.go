@@ -67,9 +67,143 @@ const docTemplate = `{
                 }
             }
         },
+        "/broadcasts": {
+            "get": {
+                "description": "Каждый запуск планировщика сохраняется с исходом: sent, no_posts (очередь пуста), no_subscribers, failed или running (не завершен).\nКоличество отправленных и неудачных доставок растет по мере отправки поста подписчикам в их окна доставки.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "broadcasts"
+                ],
+                "summary": "История запусков рассылки",
+                "parameters": [
+                    {
+                        "enum": [
+                            "default",
+                            "beginner",
+                            "intermediate",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Аудитория",
+                        "name": "audience",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "running",
+                            "sent",
+                            "no_posts",
+                            "no_subscribers",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Исход запуска",
+                        "name": "outcome",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Количество запусков (1-200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.BroadcastRun"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/broadcasts/last": {
+            "get": {
+                "description": "Время последней успешной рассылки и количество секунд с ее завершения для каждой аудитории.\nЕсли аудитория еще не получала постов, время не указывается.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "broadcasts"
+                ],
+                "summary": "Последние успешные рассылки",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LastBroadcast"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/drafts": {
+            "get": {
+                "description": "Черновики не попадают в рассылку, пока администратор их не одобрит",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Получение черновиков",
+                "responses": {
+                    "200": {
+                        "description": "Список черновиков",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Post"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/content/generate": {
             "get": {
-                "description": "Генерирует контент для телеграм поста на заданную тему с помощью AI",
+                "description": "Генерирует структурированный пост на заданную тему с помощью AI с учетом аудитории, тона, длины и языка.\nОтвет модели проверяется на соответствие ограничениям поста, при ошибке модель переспрашивается.\nОдинаковые запросы отдаются из кэша, обращения к модели учитываются в дневной квоте администратора.",
                 "consumes": [
                     "application/json"
                 ],
@@ -87,6 +221,46 @@ const docTemplate = `{
                         "name": "theme",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Уровень аудитории (beginner, intermediate, advanced)",
+                        "name": "audience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "friendly",
+                        "description": "Тон (friendly, motivational, expert, humorous)",
+                        "name": "tone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "long",
+                        "description": "Длина (short, medium, long)",
+                        "name": "length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык поста",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона промпта из библиотеки",
+                        "name": "prompt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия шаблона, по умолчанию активная",
+                        "name": "prompt_version",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -102,11 +276,140 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpx.Response"
                         }
                     },
+                    "404": {
+                        "description": "Шаблон промпта не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана дневная квота администратора или лимит запросов провайдера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Модель вернула некорректный ответ",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI провайдер недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/generate/stream": {
+            "get": {
+                "description": "Генерирует пост с теми же параметрами, что и /content/generate, и отдает текст по мере генерации через Server-Sent Events.\nСобытия: chunk - очередной фрагмент текста, done - итоговый пост после проверки ограничений, error - ошибка после начала потока.\nОшибки до первого фрагмента возвращаются обычным JSON ответом. Генерация прерывается при отключении клиента.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Потоковая генерация контента для поста",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тема контента",
+                        "name": "theme",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Уровень аудитории (beginner, intermediate, advanced)",
+                        "name": "audience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "friendly",
+                        "description": "Тон (friendly, motivational, expert, humorous)",
+                        "name": "tone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "long",
+                        "description": "Длина (short, medium, long)",
+                        "name": "length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык поста",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона промпта из библиотеки",
+                        "name": "prompt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия шаблона, по умолчанию активная",
+                        "name": "prompt_version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий chunk, завершается событием done с GenerateContentResponse",
+                        "schema": {
+                            "$ref": "#/definitions/content.StreamChunk"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон промпта не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана дневная квота администратора или лимит запросов провайдера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/httpx.Response"
                         }
+                    },
+                    "502": {
+                        "description": "Модель вернула некорректный ответ",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI провайдер недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
                     }
                 }
             }
@@ -160,11 +463,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpx.Response"
                         }
                     },
+                    "422": {
+                        "description": "Пост не прошёл AI-проверку",
+                        "schema": {
+                            "$ref": "#/definitions/content.ReviewFailedResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Лимит запросов провайдера при AI-проверке",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/httpx.Response"
                         }
+                    },
+                    "503": {
+                        "description": "AI-проверка недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
                     }
                 }
             }
@@ -213,88 +534,2762 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/content/posts": {
-            "get": {
+            },
+            "patch": {
+                "description": "Изменяет переданные поля поста и сохраняет новую ревизию с логином администратора",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "content"
                 ],
-                "summary": "Получение постов",
+                "summary": "Изменение поста",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Новый список изображений (заменяет текущий)",
+                        "name": "images",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "default": "default",
-                        "description": "Уровень пользователя (beginner, intermediate, advanced)",
-                        "name": "audience",
-                        "in": "query"
+                        "description": "Текст поста",
+                        "name": "content",
+                        "in": "formData"
                     },
                     {
-                        "type": "boolean",
+                        "type": "string",
+                        "description": "Аудитория (beginner, intermediate, advanced)",
+                        "name": "audience",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Пост не прошёл AI-проверку",
+                        "schema": {
+                            "$ref": "#/definitions/content.ReviewFailedResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Лимит запросов провайдера при AI-проверке",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI-проверка недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/approve": {
+            "post": {
+                "description": "Переводит черновик в очередь на отправку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Одобрение черновика",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Пост не прошёл AI-проверку",
+                        "schema": {
+                            "$ref": "#/definitions/content.ReviewFailedResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Лимит запросов провайдера при AI-проверке",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI-проверка недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/diff": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Сравнение ревизий поста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Исходная версия",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Конечная версия",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PostDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Ревизия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/evergreen": {
+            "put": {
+                "description": "Вечнозелёные посты отправляются повторно, когда в очереди аудитории нет новых постов.\nОдин и тот же пост не отправляется повторно ни аудитории, ни пользователю чаще минимального интервала.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Вечнозелёный пост",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Флаг",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content.EvergreenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/revisions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "История изменений поста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ревизии, начиная с последней",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PostRevision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/revisions/{version}/rollback": {
+            "post": {
+                "description": "Восстанавливает контент, аудиторию и изображения выбранной ревизии, откат сохраняется как новая ревизия",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Откат поста к ревизии",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия для отката",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Post"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные параметры",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Ревизия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Пост не прошёл AI-проверку",
+                        "schema": {
+                            "$ref": "#/definitions/content.ReviewFailedResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Лимит запросов провайдера при AI-проверке",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI-проверка недоступна",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/post/{id}/rewrite": {
+            "post": {
+                "description": "Переписывает существующий пост с помощью AI: adapt_to_level - под другой уровень аудитории (level),\nshorten_to - сокращение до length символов, translate_to - перевод на язык language, change_tone - смена тона (tone).\nПри save=true результат сохраняется черновиком со ссылкой на исходный пост, изображения не копируются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "AI-переработка поста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исходного поста",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Преобразование",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/content.RewriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/content.RewriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Пост не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Исчерпана дневная квота администратора или лимит запросов провайдера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Модель вернула некорректный ответ",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "AI провайдер недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/content/posts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Получение постов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "default",
+                        "description": "Уровень пользователя (beginner, intermediate, advanced)",
+                        "name": "audience",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
                         "description": "Фильтр по публикации (true - не опубликованные, false - все)",
                         "name": "incoming",
                         "in": "query"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Список постов",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Post"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
-                        "schema": {
-                            "$ref": "#/definitions/httpx.Response"
-                        }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список постов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Post"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans": {
+            "post": {
+                "description": "Запускает фоновую генерацию черновиков на период: по одному посту на каждый слот и аудиторию.\nCadence задает количество постов в неделю для каждой аудитории, темы используются по кругу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plans"
+                ],
+                "summary": "Генерация контент-плана",
+                "parameters": [
+                    {
+                        "description": "Параметры плана",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/plan.CreatePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PlanJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plans"
+                ],
+                "summary": "Статус генерации контент-плана",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PlanJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}/approve": {
+            "post": {
+                "description": "Переводит все оставшиеся черновики плана в очередь на отправку",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plans"
+                ],
+                "summary": "Одобрение контент-плана",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plan.ApproveJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}/drafts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plans"
+                ],
+                "summary": "Черновики контент-плана",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Неодобренные черновики",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Post"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Список шаблонов промптов",
+                "responses": {
+                    "200": {
+                        "description": "Шаблоны с активной версией",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Prompt"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Шаблон задается в формате go text/template, доступны переменные .Theme, .Level, .Language, .Tone, .Length.\nШаблон сохраняется как первая версия и используется как системный промпт при генерации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Создание шаблона промпта",
+                "parameters": [
+                    {
+                        "description": "Шаблон промпта",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/prompt.CreatePromptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Prompt"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Шаблон с таким именем уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Получение шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Prompt"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет шаблон со всеми версиями, у созданных по нему постов ссылка на шаблон очищается",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Удаление шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблон успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Новый текст шаблона сохраняется следующей версией и становится активным, описание меняется на месте",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Изменение шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменяемые поля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/prompt.UpdatePromptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Prompt"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts/{id}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "История версий шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версии от новых к старым",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PromptVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts/{id}/versions/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Получение версии шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PromptVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID или версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Версия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/prompts/{id}/versions/{version}/activate": {
+            "post": {
+                "description": "Делает выбранную версию активной, используется для отката изменений шаблона",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prompts"
+                ],
+                "summary": "Активация версии шаблона промпта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Prompt"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID или версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон или версия не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/queues": {
+            "get": {
+                "description": "Количество одобренных неотправленных постов каждой аудитории, доступных для рассылки сейчас, и на сколько дней их хватит при текущем расписании.\nПосты, запланированные на будущее, не учитываются.\nСтатус low и critical выставляется по настраиваемым порогам в днях, empty означает, что новых постов нет и рассылаются повторно вечнозелёные посты, а без них рассылки пропускаются.\nДля отключенного расписания количество дней не указывается.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "queues"
+                ],
+                "summary": "Глубина очереди постов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.QueueDepth"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Список версий теста уровня",
+                "responses": {
+                    "200": {
+                        "description": "Версии от новых к старым",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Quiz"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Версии теста неизменяемы, изменение вопросов, весов или порогов сохраняется новой версией.\nУровень определяется по сумме весов выбранных ответов: выбирается наибольший достигнутый порог.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Создание версии теста уровня",
+                "parameters": [
+                    {
+                        "description": "Вопросы, ответы с весами и пороги уровней",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/quiz.CreateQuizRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/active": {
+            "get": {
+                "description": "Версия, которую бот выдает пользователям",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Активная версия теста уровня",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Quiz"
+                        }
+                    },
+                    "404": {
+                        "description": "Активная версия не выбрана",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/levels": {
+            "get": {
+                "description": "Количество прохождений теста по итоговому уровню за каждый день, дни без прохождений пропускаются.\nДни считаются по UTC.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Распределение уровней по дням",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Количество дней истории (1-365)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер версии, по умолчанию все версии",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.LevelStats"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{version}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Получение версии теста уровня",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Версия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Нельзя удалить активную версию и версию, которую уже проходили пользователи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Удаление версии теста уровня",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версия успешно удалена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Версия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Версия активна или уже пройдена пользователями",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{version}/activate": {
+            "post": {
+                "description": "Новые прохождения теста в боте используют выбранную версию, начатые прохождения завершаются по своей версии",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Активация версии теста уровня",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Quiz"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Версия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{version}/stats": {
+            "get": {
+                "description": "Количество и доля прохождений, в которых был выбран каждый вариант ответа",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Статистика ответов версии теста",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер версии",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.QuizStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная версия",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Версия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "description": "Расписание задается для каждой аудитории, аудитория default рассылается всем подписчикам.\nВремя следующих запусков указано в часовом поясе сервера.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Расписания рассылки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Количество следующих запусков (0-50)",
+                        "name": "runs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{audience}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Расписание рассылки аудитории",
+                "parameters": [
+                    {
+                        "enum": [
+                            "default",
+                            "beginner",
+                            "intermediate",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Аудитория",
+                        "name": "audience",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Количество следующих запусков (0-50)",
+                        "name": "runs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Расписание не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Cron выражение содержит поле секунд, поддерживаются дескрипторы вида @daily.\nБот применяет новое расписание без перезапуска в течение интервала перезагрузки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Изменение расписания рассылки аудитории",
+                "parameters": [
+                    {
+                        "enum": [
+                            "default",
+                            "beginner",
+                            "intermediate",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Аудитория",
+                        "name": "audience",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Количество следующих запусков (0-50)",
+                        "name": "runs",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Расписание",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schedule.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные в запросе или некорректное cron выражение",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/usage": {
+            "get": {
+                "description": "Возвращает расход токенов и запросов к модели текущим администратором за сегодня, остаток дневной квоты и историю по дням.\nДни считаются по UTC, null в остатке квоты означает отсутствие ограничения.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "usage"
+                ],
+                "summary": "Использование AI",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Количество дней истории (1-90)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UsageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/httpx.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "content.EvergreenRequest": {
+            "type": "object",
+            "required": [
+                "evergreen"
+            ],
+            "properties": {
+                "evergreen": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "content.GenerateContentResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Белок помогает мышцам восстановиться..."
+                },
+                "content": {
+                    "description": "Content is title, body and hashtags assembled into ready to post text",
+                    "type": "string",
+                    "example": "*Протеин после тренировки*\n\nБелок помогает мышцам восстановиться..."
+                },
+                "hashtags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "#питание",
+                        "#протеин"
+                    ]
+                },
+                "image_prompt": {
+                    "type": "string",
+                    "example": "protein shake on a gym bench"
+                },
+                "prompt_id": {
+                    "description": "PromptID and PromptVersion are set when post was generated with template from library",
+                    "type": "integer",
+                    "example": 1
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "review": {
+                    "description": "Review is set when review is enabled and reviewer responded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Review"
+                        }
+                    ]
+                },
+                "status": {
+                    "$ref": "#/definitions/httpx.Status"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Протеин после тренировки"
+                }
+            }
+        },
+        "content.ReviewFailedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Запрос выполнен успешно"
+                },
+                "review": {
+                    "$ref": "#/definitions/domain.Review"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/httpx.Status"
+                        }
+                    ],
+                    "example": "success"
+                }
+            }
+        },
+        "content.RewriteRequest": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "description": "Language is target language for translate_to",
+                    "type": "string",
+                    "example": "en"
+                },
+                "length": {
+                    "description": "Length is max post length for shorten_to",
+                    "type": "integer",
+                    "example": 150
+                },
+                "level": {
+                    "description": "Level is target audience for adapt_to_level",
+                    "type": "string",
+                    "example": "advanced"
+                },
+                "save": {
+                    "description": "Save stores result as draft linked to the source post",
+                    "type": "boolean",
+                    "example": false
+                },
+                "tone": {
+                    "description": "Tone is target tone for change_tone",
+                    "type": "string",
+                    "example": "expert"
+                },
+                "transform": {
+                    "type": "string",
+                    "example": "adapt_to_level"
+                }
+            }
+        },
+        "content.RewriteResponse": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "description": "Draft is set when result was saved",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Post"
+                        }
+                    ]
+                },
+                "post": {
+                    "$ref": "#/definitions/domain.GeneratedPost"
+                },
+                "status": {
+                    "$ref": "#/definitions/httpx.Status"
+                }
+            }
+        },
+        "content.StreamChunk": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "example": "Белок помогает "
+                }
+            }
+        },
+        "domain.BroadcastOutcome": {
+            "type": "string",
+            "enum": [
+                "running",
+                "sent",
+                "no_posts",
+                "no_subscribers",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "BroadcastOutcomeRunning",
+                "BroadcastOutcomeSent",
+                "BroadcastOutcomeNoPosts",
+                "BroadcastOutcomeNoSubscribers",
+                "BroadcastOutcomeFailed"
+            ]
+        },
+        "domain.BroadcastRun": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "evergreen": {
+                    "description": "Evergreen is set when queue was empty and evergreen post was sent again",
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outcome": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.BroadcastOutcome"
+                        }
+                    ],
+                    "example": "sent"
+                },
+                "post_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "recipients": {
+                    "description": "Recipients is number of queued deliveries, Sent and Failed are counted as dispatcher sends them",
+                    "type": "integer",
+                    "example": 120
+                },
+                "sent": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DiffLine": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DiffOp"
+                        }
+                    ],
+                    "example": "insert"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Пейте больше воды"
+                }
+            }
+        },
+        "domain.DiffOp": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "DiffOpEqual",
+                "DiffOpInsert",
+                "DiffOpDelete"
+            ]
+        },
+        "domain.FlaggedSentence": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ReviewCategory"
+                        }
+                    ],
+                    "example": "level"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Небезопасная нагрузка для начинающих"
+                },
+                "sentence": {
+                    "type": "string",
+                    "example": "Новичкам стоит начинать с приседаний со штангой 100 кг."
+                }
+            }
+        },
+        "domain.GeneratedPost": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "Белок помогает мышцам восстановиться..."
+                },
+                "content": {
+                    "description": "Content is title, body and hashtags assembled into ready to post text",
+                    "type": "string",
+                    "example": "*Протеин после тренировки*\n\nБелок помогает мышцам восстановиться..."
+                },
+                "hashtags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "#питание",
+                        "#протеин"
+                    ]
+                },
+                "image_prompt": {
+                    "type": "string",
+                    "example": "protein shake on a gym bench"
+                },
+                "prompt_id": {
+                    "description": "PromptID and PromptVersion are set when post was generated with template from library",
+                    "type": "integer",
+                    "example": 1
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "review": {
+                    "description": "Review is set when review is enabled and reviewer responded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Review"
+                        }
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Протеин после тренировки"
+                }
+            }
+        },
+        "domain.JobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "done",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "JobStatusPending",
+                "JobStatusRunning",
+                "JobStatusDone",
+                "JobStatusFailed"
+            ]
+        },
+        "domain.LastBroadcast": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "since_seconds": {
+                    "description": "SinceSeconds is time since the last successful broadcast",
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "domain.Length": {
+            "type": "string",
+            "enum": [
+                "short",
+                "medium",
+                "long"
+            ],
+            "x-enum-varnames": [
+                "LengthShort",
+                "LengthMedium",
+                "LengthLong"
+            ]
+        },
+        "domain.LevelStats": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "levels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.PlanJob": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-03-01T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-03-01T12:05:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0195b4a4-0a5e-7c4e-9d5f-1b2c3d4e5f60"
+                },
+                "params": {
+                    "$ref": "#/definitions/domain.PlanParams"
+                },
+                "progress": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.JobStatus"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "domain.PlanParams": {
+            "type": "object",
+            "properties": {
+                "cadence": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-03-03T00:00:00Z"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "length": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Length"
+                        }
+                    ],
+                    "example": "long"
+                },
+                "prompt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PlanSlot"
+                    }
+                },
+                "themes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Польза протеина",
+                        "Растяжка после тренировки"
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-03-09T00:00:00Z"
+                },
+                "tone": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Tone"
+                        }
+                    ],
+                    "example": "friendly"
+                }
+            }
+        },
+        "domain.PlanSlot": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-03-03T00:00:00Z"
+                },
+                "theme": {
+                    "type": "string",
+                    "example": "Польза протеина"
+                }
+            }
+        },
+        "domain.Post": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Польза протеина в диете"
+                },
+                "evergreen": {
+                    "description": "Evergreen posts are sent again when queue of audience is empty",
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "image1.jpg",
+                        "image2.jpg"
+                    ]
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "0195b4a4-0a5e-7c4e-9d5f-1b2c3d4e5f60"
+                },
+                "last_sent_at": {
+                    "type": "string",
+                    "example": "2025-03-03T10:00:00Z"
+                },
+                "prompt_id": {
+                    "description": "PromptID and PromptVersion record which prompt template produced generated draft",
+                    "type": "integer",
+                    "example": 1
+                },
+                "prompt_version": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "review": {
+                    "description": "Review is result of the last AI review of post content",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Review"
+                        }
+                    ]
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-03-03T00:00:00Z"
+                },
+                "source_id": {
+                    "description": "SourceID is set for drafts rewritten from another post",
+                    "type": "integer",
+                    "example": 42
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.PostStatus"
+                        }
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "domain.PostDiff": {
+            "type": "object",
+            "properties": {
+                "audience_from": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "default"
+                },
+                "audience_to": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DiffLine"
+                    }
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "images_added": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "image3.jpg"
+                    ]
+                },
+                "images_removed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "image1.jpg"
+                    ]
+                },
+                "post_id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.PostRevision": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "author": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Польза протеина в диете"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-20T12:00:00Z"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "image1.jpg",
+                        "image2.jpg"
+                    ]
+                },
+                "post_id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.PostStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "approved"
+            ],
+            "x-enum-varnames": [
+                "PostStatusDraft",
+                "PostStatusApproved"
+            ]
+        },
+        "domain.Prompt": {
+            "type": "object",
+            "properties": {
+                "active_version": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-20T12:00:00Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Экспертный стиль с отсылками к исследованиям"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "expert"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-21T12:00:00Z"
+                }
+            }
+        },
+        "domain.PromptVersion": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-20T12:00:00Z"
+                },
+                "prompt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "template": {
+                    "type": "string",
+                    "example": "Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.QueueDepth": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "beginner"
+                },
+                "days_left": {
+                    "description": "DaysLeft is empty when schedule is disabled, queue is not consumed then",
+                    "type": "number",
+                    "example": 6
+                },
+                "posts": {
+                    "description": "Posts are approved posts which can be sent now, posts scheduled for later are not counted",
+                    "type": "integer",
+                    "example": 12
+                },
+                "runs_per_day": {
+                    "description": "RunsPerDay is average number of broadcasts a day, zero when schedule is disabled",
+                    "type": "number",
+                    "example": 2
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.QueueStatus"
+                        }
+                    ],
+                    "example": "ok"
+                }
+            }
+        },
+        "domain.QueueStatus": {
+            "type": "string",
+            "enum": [
+                "ok",
+                "low",
+                "critical",
+                "empty"
+            ],
+            "x-enum-varnames": [
+                "QueueStatusOK",
+                "QueueStatusLow",
+                "QueueStatusCritical",
+                "QueueStatusEmpty"
+            ]
+        },
+        "domain.Quiz": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "author": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-20T12:00:00Z"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizQuestion"
+                    }
+                },
+                "thresholds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizThreshold"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.QuizOption": {
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "3 и более раз в неделю"
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 3
+                }
+            }
+        },
+        "domain.QuizOptionStats": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "share": {
+                    "description": "Share is part of attempts with this answer, from 0 to 1",
+                    "type": "number",
+                    "example": 0.35
+                },
+                "text": {
+                    "type": "string",
+                    "example": "3 и более раз в неделю"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "domain.QuizQuestion": {
+            "type": "object",
+            "required": [
+                "options",
+                "text"
+            ],
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "maxItems": 8,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizOption"
+                    }
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 300,
+                    "example": "Как часто вы тренируетесь в зале?"
+                }
+            }
+        },
+        "domain.QuizQuestionStats": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizOptionStats"
                     }
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Как часто вы тренируетесь в зале?"
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginRequest": {
+        },
+        "domain.QuizStats": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizQuestionStats"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "domain.QuizThreshold": {
             "type": "object",
             "required": [
-                "login",
-                "password"
+                "level"
             ],
             "properties": {
-                "login": {
-                    "type": "string"
+                "level": {
+                    "enum": [
+                        "beginner",
+                        "intermediate",
+                        "advanced"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserLvl"
+                        }
+                    ],
+                    "example": "intermediate"
                 },
-                "password": {
-                    "type": "string"
+                "min_score": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
                 }
             }
         },
-        "auth.LoginResponse": {
+        "domain.Quota": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string"
+                "requests": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "tokens": {
+                    "type": "integer",
+                    "example": 100000
                 }
             }
         },
-        "content.GenerateContentResponse": {
+        "domain.Remaining": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "requests": {
+                    "type": "integer",
+                    "example": 88
                 },
-                "status": {
-                    "$ref": "#/definitions/httpx.Status"
+                "tokens": {
+                    "type": "integer",
+                    "example": 98500
                 }
             }
         },
-        "domain.Post": {
+        "domain.Review": {
+            "type": "object",
+            "properties": {
+                "flagged": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FlaggedSentence"
+                    }
+                },
+                "scores": {
+                    "$ref": "#/definitions/domain.ReviewScores"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "Пост в целом корректен, но рекомендуемая нагрузка завышена"
+                },
+                "verdict": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ReviewVerdict"
+                        }
+                    ],
+                    "example": "warn"
+                }
+            }
+        },
+        "domain.ReviewCategory": {
+            "type": "string",
+            "enum": [
+                "safety",
+                "level",
+                "fact"
+            ],
+            "x-enum-varnames": [
+                "ReviewCategorySafety",
+                "ReviewCategoryLevel",
+                "ReviewCategoryFact"
+            ]
+        },
+        "domain.ReviewScores": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "level_fit": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "safety": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "domain.ReviewVerdict": {
+            "type": "string",
+            "enum": [
+                "pass",
+                "warn",
+                "fail"
+            ],
+            "x-enum-varnames": [
+                "ReviewVerdictPass",
+                "ReviewVerdictWarn",
+                "ReviewVerdictFail"
+            ]
+        },
+        "domain.Schedule": {
             "type": "object",
             "properties": {
                 "audience": {
@@ -305,23 +3300,93 @@ const docTemplate = `{
                     ],
                     "example": "beginner"
                 },
-                "content": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_runs": {
+                    "description": "NextRuns are upcoming run times in server time zone, empty for disabled schedule",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "spec": {
+                    "description": "Spec is cron expression with seconds field",
                     "type": "string",
-                    "example": "Польза протеина в диете"
+                    "example": "0 0 10 * * *"
                 },
-                "id": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "domain.Tone": {
+            "type": "string",
+            "enum": [
+                "friendly",
+                "motivational",
+                "expert",
+                "humorous"
+            ],
+            "x-enum-varnames": [
+                "ToneFriendly",
+                "ToneMotivational",
+                "ToneExpert",
+                "ToneHumorous"
+            ]
+        },
+        "domain.Usage": {
+            "type": "object",
+            "properties": {
+                "completion_tokens": {
                     "type": "integer",
-                    "example": 123
+                    "example": 300
                 },
-                "images": {
+                "day": {
+                    "type": "string",
+                    "example": "2025-03-01T00:00:00Z"
+                },
+                "prompt_tokens": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "requests": {
+                    "description": "Requests is number of generations, repairs of invalid output within one generation are not counted",
+                    "type": "integer",
+                    "example": 12
+                },
+                "total_tokens": {
+                    "type": "integer",
+                    "example": 1500
+                }
+            }
+        },
+        "domain.UsageReport": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "history": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "image1.jpg",
-                        "image2.jpg"
-                    ]
+                        "$ref": "#/definitions/domain.Usage"
+                    }
+                },
+                "quota": {
+                    "$ref": "#/definitions/domain.Quota"
+                },
+                "remaining": {
+                    "$ref": "#/definitions/domain.Remaining"
+                },
+                "today": {
+                    "$ref": "#/definitions/domain.Usage"
                 }
             }
         },
@@ -371,6 +3436,162 @@ const docTemplate = `{
                 "StatusSuccess",
                 "StatusError"
             ]
+        },
+        "plan.ApproveJobResponse": {
+            "type": "object",
+            "properties": {
+                "approved": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "status": {
+                    "$ref": "#/definitions/httpx.Status"
+                }
+            }
+        },
+        "plan.CreatePlanRequest": {
+            "type": "object",
+            "required": [
+                "cadence",
+                "from",
+                "themes",
+                "to"
+            ],
+            "properties": {
+                "cadence": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-03-03"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "length": {
+                    "type": "string",
+                    "example": "long"
+                },
+                "prompt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "themes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Польза протеина",
+                        "Растяжка после тренировки"
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-03-09"
+                },
+                "tone": {
+                    "type": "string",
+                    "example": "friendly"
+                }
+            }
+        },
+        "prompt.CreatePromptRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "template"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Экспертный стиль с отсылками к исследованиям"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "expert"
+                },
+                "template": {
+                    "description": "Template is go text/template, available variables: .Theme, .Level, .Language, .Tone, .Length",
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."
+                }
+            }
+        },
+        "prompt.UpdatePromptRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Экспертный стиль"
+                },
+                "template": {
+                    "description": "Template is saved as new active version",
+                    "type": "string",
+                    "maxLength": 5000,
+                    "minLength": 1,
+                    "example": "Ты фитнес тренер. Тема: {{.Theme}}."
+                }
+            }
+        },
+        "quiz.CreateQuizRequest": {
+            "type": "object",
+            "required": [
+                "questions",
+                "thresholds"
+            ],
+            "properties": {
+                "activate": {
+                    "description": "Activate makes new version active right away",
+                    "type": "boolean",
+                    "example": true
+                },
+                "questions": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizQuestion"
+                    }
+                },
+                "thresholds": {
+                    "description": "Thresholds assign level by sum of answer weights, the highest reached threshold wins",
+                    "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/domain.QuizThreshold"
+                    }
+                }
+            }
+        },
+        "schedule.UpdateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "spec"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "spec": {
+                    "description": "Spec is cron expression with seconds field, descriptors like @daily are supported",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "0 0 10 * * *"
+                }
+            }
         }
     }
 }`
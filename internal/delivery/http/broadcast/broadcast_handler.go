@@ -0,0 +1,110 @@
+package broadcast
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+)
+
+type BroadcastService interface {
+	Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)
+	LastBroadcasts(ctx context.Context) ([]domain.LastBroadcast, error)
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+)
+
+type handler struct {
+	logger       *slog.Logger
+	broadcastSvc BroadcastService
+}
+
+func New(logger *slog.Logger, broadcastSvc BroadcastService) *handler {
+	return &handler{logger, broadcastSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /broadcasts", h.HandleGetRuns)
+	router.HandleFunc("GET /broadcasts/last", h.HandleGetLast)
+	r.Handle("/broadcasts", auth(router))
+	r.Handle("/broadcasts/", auth(router))
+}
+
+// @Summary      История запусков рассылки
+// @Description  Каждый запуск планировщика сохраняется с исходом: sent, no_posts (очередь пуста), no_subscribers, failed или running (не завершен).
+// @Description  Количество отправленных и неудачных доставок растет по мере отправки поста подписчикам в их окна доставки.
+// @Tags         broadcasts
+// @Produce      json
+// @Param        audience  query     string  false  "Аудитория"  Enums(default, beginner, intermediate, advanced)
+// @Param        outcome   query     string  false  "Исход запуска"  Enums(running, sent, no_posts, no_subscribers, failed)
+// @Param        limit     query     int     false  "Количество запусков (1-200)" default(50)
+// @Param        offset    query     int     false  "Смещение" default(0)
+// @Success      200       {array}   domain.BroadcastRun
+// @Failure      400       {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      500       {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /broadcasts [get]
+func (h *handler) HandleGetRuns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.BroadcastRunsFilter{
+		Audience: domain.UserLvl(query.Get("audience")),
+		Outcome:  domain.BroadcastOutcome(query.Get("outcome")),
+		Limit:    defaultLimit,
+	}
+	if filter.Audience != "" && !filter.Audience.Valid() {
+		httpx.WriteError(w, "invalid audience", http.StatusBadRequest)
+		return
+	}
+	if filter.Outcome != "" && !filter.Outcome.Valid() {
+		httpx.WriteError(w, "invalid outcome", http.StatusBadRequest)
+		return
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			httpx.WriteError(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			httpx.WriteError(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		filter.Offset = offset
+	}
+
+	runs, err := h.broadcastSvc.Runs(r.Context(), filter)
+	if err != nil {
+		httpx.WriteError(w, "failed to get broadcast runs", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, runs, http.StatusOK)
+}
+
+// @Summary      Последние успешные рассылки
+// @Description  Время последней успешной рассылки и количество секунд с ее завершения для каждой аудитории.
+// @Description  Если аудитория еще не получала постов, время не указывается.
+// @Tags         broadcasts
+// @Produce      json
+// @Success      200  {array}   domain.LastBroadcast
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /broadcasts/last [get]
+func (h *handler) HandleGetLast(w http.ResponseWriter, r *http.Request) {
+	last, err := h.broadcastSvc.LastBroadcasts(r.Context())
+	if err != nil {
+		httpx.WriteError(w, "failed to get last broadcasts", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, last, http.StatusOK)
+}
@@ -0,0 +1,161 @@
+package broadcast_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	broadcastHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/broadcast"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/broadcast/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBroadcastHandler_GetRuns(t *testing.T) {
+	type MockBehavior func(svc *mocks.BroadcastService)
+
+	postID := int64(10)
+	startedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(time.Second)
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:  "success",
+			query: "?audience=beginner&outcome=sent&limit=10&offset=20",
+			mockBehavior: func(svc *mocks.BroadcastService) {
+				svc.EXPECT().Runs(mock.Anything, domain.BroadcastRunsFilter{
+					Audience: domain.UserLvlBeginner,
+					Outcome:  domain.BroadcastOutcomeSent,
+					Limit:    10,
+					Offset:   20,
+				}).Return([]domain.BroadcastRun{{
+					ID:         1,
+					Audience:   domain.UserLvlBeginner,
+					PostID:     &postID,
+					Outcome:    domain.BroadcastOutcomeSent,
+					Recipients: 3,
+					Sent:       2,
+					Failed:     1,
+					StartedAt:  startedAt,
+					FinishedAt: &finishedAt,
+				}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `[{"id":1,"audience":"beginner","post_id":10,"outcome":"sent","recipients":3,"sent":2,"failed":1,` +
+				`"started_at":"2025-03-01T10:00:00Z","finished_at":"2025-03-01T10:00:01Z"}]` + "\n",
+		},
+		{
+			name: "default filter",
+			mockBehavior: func(svc *mocks.BroadcastService) {
+				svc.EXPECT().Runs(mock.Anything, domain.BroadcastRunsFilter{Limit: 50}).Return([]domain.BroadcastRun{}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       "[]\n",
+		},
+		{
+			name:           "invalid audience",
+			query:          "?audience=expert",
+			mockBehavior:   func(svc *mocks.BroadcastService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid audience"}` + "\n",
+		},
+		{
+			name:           "invalid outcome",
+			query:          "?outcome=done",
+			mockBehavior:   func(svc *mocks.BroadcastService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid outcome"}` + "\n",
+		},
+		{
+			name:           "invalid limit",
+			query:          "?limit=500",
+			mockBehavior:   func(svc *mocks.BroadcastService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid limit"}` + "\n",
+		},
+		{
+			name: "error",
+			mockBehavior: func(svc *mocks.BroadcastService) {
+				svc.EXPECT().Runs(mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to get broadcast runs"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broadcastSvc := mocks.NewBroadcastService(t)
+			tc.mockBehavior(broadcastSvc)
+
+			handler := broadcastHandler.New(testutils.NewTestLogger(), broadcastSvc)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/broadcasts"+tc.query, nil)
+			handler.HandleGetRuns(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestBroadcastHandler_GetLast(t *testing.T) {
+	type MockBehavior func(svc *mocks.BroadcastService)
+
+	sentAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	since := int64(3600)
+
+	testCases := []struct {
+		name           string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			mockBehavior: func(svc *mocks.BroadcastService) {
+				svc.EXPECT().LastBroadcasts(mock.Anything).Return([]domain.LastBroadcast{
+					{Audience: domain.UserLvlDefault},
+					{Audience: domain.UserLvlBeginner, SentAt: &sentAt, SinceSeconds: &since},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `[{"audience":"default"},` +
+				`{"audience":"beginner","sent_at":"2025-03-01T10:00:00Z","since_seconds":3600}]` + "\n",
+		},
+		{
+			name: "error",
+			mockBehavior: func(svc *mocks.BroadcastService) {
+				svc.EXPECT().LastBroadcasts(mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to get last broadcasts"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broadcastSvc := mocks.NewBroadcastService(t)
+			tc.mockBehavior(broadcastSvc)
+
+			handler := broadcastHandler.New(testutils.NewTestLogger(), broadcastSvc)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/broadcasts/last", nil)
+			handler.HandleGetLast(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
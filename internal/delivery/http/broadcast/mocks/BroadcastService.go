@@ -0,0 +1,154 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BroadcastService is an autogenerated mock type for the BroadcastService type
+type BroadcastService struct {
+	mock.Mock
+}
+
+type BroadcastService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BroadcastService) EXPECT() *BroadcastService_Expecter {
+	return &BroadcastService_Expecter{mock: &_m.Mock}
+}
+
+// LastBroadcasts provides a mock function with given fields: ctx
+func (_m *BroadcastService) LastBroadcasts(ctx context.Context) ([]domain.LastBroadcast, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastBroadcasts")
+	}
+
+	var r0 []domain.LastBroadcast
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.LastBroadcast, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.LastBroadcast); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LastBroadcast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastService_LastBroadcasts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastBroadcasts'
+type BroadcastService_LastBroadcasts_Call struct {
+	*mock.Call
+}
+
+// LastBroadcasts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BroadcastService_Expecter) LastBroadcasts(ctx interface{}) *BroadcastService_LastBroadcasts_Call {
+	return &BroadcastService_LastBroadcasts_Call{Call: _e.mock.On("LastBroadcasts", ctx)}
+}
+
+func (_c *BroadcastService_LastBroadcasts_Call) Run(run func(ctx context.Context)) *BroadcastService_LastBroadcasts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BroadcastService_LastBroadcasts_Call) Return(_a0 []domain.LastBroadcast, _a1 error) *BroadcastService_LastBroadcasts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastService_LastBroadcasts_Call) RunAndReturn(run func(context.Context) ([]domain.LastBroadcast, error)) *BroadcastService_LastBroadcasts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Runs provides a mock function with given fields: ctx, filter
+func (_m *BroadcastService) Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Runs")
+	}
+
+	var r0 []domain.BroadcastRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastRunsFilter) []domain.BroadcastRun); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BroadcastRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BroadcastRunsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastService_Runs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Runs'
+type BroadcastService_Runs_Call struct {
+	*mock.Call
+}
+
+// Runs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BroadcastRunsFilter
+func (_e *BroadcastService_Expecter) Runs(ctx interface{}, filter interface{}) *BroadcastService_Runs_Call {
+	return &BroadcastService_Runs_Call{Call: _e.mock.On("Runs", ctx, filter)}
+}
+
+func (_c *BroadcastService_Runs_Call) Run(run func(ctx context.Context, filter domain.BroadcastRunsFilter)) *BroadcastService_Runs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BroadcastRunsFilter))
+	})
+	return _c
+}
+
+func (_c *BroadcastService_Runs_Call) Return(_a0 []domain.BroadcastRun, _a1 error) *BroadcastService_Runs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastService_Runs_Call) RunAndReturn(run func(context.Context, domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)) *BroadcastService_Runs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBroadcastService creates a new instance of BroadcastService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBroadcastService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BroadcastService {
+	mock := &BroadcastService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -9,15 +9,25 @@ import (
 	"strings"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
 	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
 	"github.com/go-playground/validator/v10"
 )
 
 type ContentService interface {
-	GenerateContent(ctx context.Context, theme string) (string, error)
+	GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error)
+	StreamContent(ctx context.Context, params domain.GenerateParams, onChunk func(chunk string) error) (domain.GeneratedPost, error)
 	CreatePost(ctx context.Context, in domain.CreatePostDTO) (domain.Post, error)
 	RemovePost(ctx context.Context, id int64) error
 	Posts(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error)
+	UpdatePost(ctx context.Context, id int64, in domain.UpdatePostDTO) (domain.Post, error)
+	PostRevisions(ctx context.Context, id int64) ([]domain.PostRevision, error)
+	DiffRevisions(ctx context.Context, id int64, from, to int) (domain.PostDiff, error)
+	RollbackPost(ctx context.Context, id int64, version int) (domain.Post, error)
+	Drafts(ctx context.Context) ([]domain.Post, error)
+	ApprovePost(ctx context.Context, id int64) (domain.Post, error)
+	SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error)
+	RewritePost(ctx context.Context, id int64, in domain.RewriteDTO) (domain.RewriteResult, error)
 }
 
 type handler struct {
@@ -34,37 +44,184 @@ func New(logger *slog.Logger, contentSvc ContentService) *handler {
 func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
 	router := http.NewServeMux()
 	router.HandleFunc("GET /generate", h.HandleGenerateContent)
+	router.HandleFunc("GET /generate/stream", h.HandleStreamContent)
 	router.HandleFunc("GET /posts", h.HandleGetPosts)
 	router.HandleFunc("POST /post", h.HandleCreatePost)
 	router.HandleFunc("DELETE /post/{id}", h.HandleRemovePost)
+	router.HandleFunc("PATCH /post/{id}", h.HandleUpdatePost)
+	router.HandleFunc("GET /post/{id}/revisions", h.HandleGetRevisions)
+	router.HandleFunc("GET /post/{id}/diff", h.HandleDiffRevisions)
+	router.HandleFunc("POST /post/{id}/revisions/{version}/rollback", h.HandleRollbackPost)
+	router.HandleFunc("GET /drafts", h.HandleGetDrafts)
+	router.HandleFunc("POST /post/{id}/approve", h.HandleApprovePost)
+	router.HandleFunc("POST /post/{id}/rewrite", h.HandleRewritePost)
+	router.HandleFunc("PUT /post/{id}/evergreen", h.HandleSetEvergreen)
 	r.Handle("/content/", http.StripPrefix("/content", auth(router)))
 }
 
 // @Summary      Генерация контента для поста
-// @Description  Генерирует контент для телеграм поста на заданную тему с помощью AI
+// @Description  Генерирует структурированный пост на заданную тему с помощью AI с учетом аудитории, тона, длины и языка.
+// @Description  Ответ модели проверяется на соответствие ограничениям поста, при ошибке модель переспрашивается.
+// @Description  Одинаковые запросы отдаются из кэша, обращения к модели учитываются в дневной квоте администратора.
 // @Tags         content
 // @Accept       json
 // @Produce      json
-// @Param 			 theme  query     string true "Тема контента"
+// @Param 			 theme           query     string true  "Тема контента"
+// @Param 			 audience        query     string false "Уровень аудитории (beginner, intermediate, advanced)" default(default)
+// @Param 			 tone            query     string false "Тон (friendly, motivational, expert, humorous)" default(friendly)
+// @Param 			 length          query     string false "Длина (short, medium, long)" default(long)
+// @Param 			 language        query     string false "Язык поста" default(ru)
+// @Param 			 prompt          query     int    false "ID шаблона промпта из библиотеки"
+// @Param 			 prompt_version  query     int    false "Версия шаблона, по умолчанию активная"
 // @Success      200    {object}  GenerateContentResponse
 // @Failure      400    {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      404    {object}  httpx.Response  "Шаблон промпта не найден"
+// @Failure      429    {object}  httpx.Response  "Исчерпана дневная квота администратора или лимит запросов провайдера"
+// @Failure      502    {object}  httpx.Response  "Модель вернула некорректный ответ"
+// @Failure      503    {object}  httpx.Response  "AI провайдер недоступен"
 // @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
 // @Router       /content/generate [get]
 func (h *handler) HandleGenerateContent(w http.ResponseWriter, r *http.Request) {
-	theme := r.URL.Query().Get("theme")
-	if theme == "" {
-		httpx.WriteError(w, "theme is required", http.StatusBadRequest)
+	params, err := h.parseGenerateParams(r)
+	if err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	content, err := h.contentSvc.GenerateContent(r.Context(), theme)
+	post, err := h.contentSvc.GenerateContent(r.Context(), params)
 	if err != nil {
-		h.logger.Error("failed to generate content", "error", err, "theme", theme)
-		httpx.WriteError(w, "failed to generate content", http.StatusInternalServerError)
+		h.logger.Error("failed to generate content", "error", err, "theme", params.Theme)
+		msg, code := generateError(err)
+		httpx.WriteError(w, msg, code)
+		return
+	}
+
+	httpx.WriteJSON(w, GenerateContentResponse{GeneratedPost: post, Status: httpx.StatusSuccess}, http.StatusOK)
+}
+
+// @Summary      Потоковая генерация контента для поста
+// @Description  Генерирует пост с теми же параметрами, что и /content/generate, и отдает текст по мере генерации через Server-Sent Events.
+// @Description  События: chunk - очередной фрагмент текста, done - итоговый пост после проверки ограничений, error - ошибка после начала потока.
+// @Description  Ошибки до первого фрагмента возвращаются обычным JSON ответом. Генерация прерывается при отключении клиента.
+// @Tags         content
+// @Produce      text/event-stream
+// @Param 			 theme           query     string true  "Тема контента"
+// @Param 			 audience        query     string false "Уровень аудитории (beginner, intermediate, advanced)" default(default)
+// @Param 			 tone            query     string false "Тон (friendly, motivational, expert, humorous)" default(friendly)
+// @Param 			 length          query     string false "Длина (short, medium, long)" default(long)
+// @Param 			 language        query     string false "Язык поста" default(ru)
+// @Param 			 prompt          query     int    false "ID шаблона промпта из библиотеки"
+// @Param 			 prompt_version  query     int    false "Версия шаблона, по умолчанию активная"
+// @Success      200    {object}  StreamChunk  "Поток событий chunk, завершается событием done с GenerateContentResponse"
+// @Failure      400    {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      404    {object}  httpx.Response  "Шаблон промпта не найден"
+// @Failure      429    {object}  httpx.Response  "Исчерпана дневная квота администратора или лимит запросов провайдера"
+// @Failure      502    {object}  httpx.Response  "Модель вернула некорректный ответ"
+// @Failure      503    {object}  httpx.Response  "AI провайдер недоступен"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/generate/stream [get]
+func (h *handler) HandleStreamContent(w http.ResponseWriter, r *http.Request) {
+	params, err := h.parseGenerateParams(r)
+	if err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stream := httpx.NewEventStream(w)
+	post, err := h.contentSvc.StreamContent(r.Context(), params, func(chunk string) error {
+		return stream.Send("chunk", StreamChunk{Text: chunk})
+	})
+	if err != nil {
+		if r.Context().Err() != nil {
+			return
+		}
+		h.logger.Error("failed to stream content", "error", err, "theme", params.Theme)
+		msg, code := generateError(err)
+		if !stream.Started() {
+			httpx.WriteError(w, msg, code)
+			return
+		}
+		stream.Send("error", httpx.Response{Status: httpx.StatusError, Code: code, Message: msg})
 		return
 	}
 
-	httpx.WriteJSON(w, GenerateContentResponse{Content: content, Status: httpx.StatusSuccess}, http.StatusOK)
+	stream.Send("done", GenerateContentResponse{GeneratedPost: post, Status: httpx.StatusSuccess})
+}
+
+// writeReviewError reports post blocked by review with reviewer verdict, or blocked because reviewer is not available
+func writeReviewError(w http.ResponseWriter, err error) bool {
+	if errors.Is(err, ai.ErrRateLimited) || errors.Is(err, ai.ErrUnavailable) {
+		msg, code := generateError(err)
+		httpx.WriteError(w, "post review failed: "+msg, code)
+		return true
+	}
+
+	var failed *domain.ReviewFailedError
+	if !errors.As(err, &failed) {
+		return false
+	}
+	code := http.StatusUnprocessableEntity
+	httpx.WriteJSON(w, ReviewFailedResponse{
+		Response: httpx.Response{Status: httpx.StatusError, Code: code, Message: "post failed review"},
+		Review:   failed.Review,
+	}, code)
+	return true
+}
+
+func generateError(err error) (string, int) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGeneration):
+		return "model returned invalid content", http.StatusBadGateway
+	case errors.Is(err, domain.ErrPromptVersionNotFound):
+		return "prompt not found", http.StatusNotFound
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		return "daily quota exceeded", http.StatusTooManyRequests
+	case errors.Is(err, ai.ErrRateLimited):
+		return "ai provider rate limited", http.StatusTooManyRequests
+	case errors.Is(err, ai.ErrUnavailable):
+		return "ai provider unavailable", http.StatusServiceUnavailable
+	default:
+		return "failed to generate content", http.StatusInternalServerError
+	}
+}
+
+func (h *handler) parseGenerateParams(r *http.Request) (domain.GenerateParams, error) {
+	query := r.URL.Query()
+	params := domain.GenerateParams{
+		Theme:    query.Get("theme"),
+		Audience: domain.UserLvl(valueOr(query.Get("audience"), string(domain.UserLvlDefault))),
+		Tone:     domain.Tone(valueOr(query.Get("tone"), string(domain.ToneFriendly))),
+		Length:   domain.Length(valueOr(query.Get("length"), string(domain.LengthLong))),
+		Language: valueOr(query.Get("language"), "ru"),
+	}
+	if params.Theme == "" {
+		return params, errors.New("theme is required")
+	}
+	if prompt := query.Get("prompt"); prompt != "" {
+		id, err := strconv.ParseInt(prompt, 10, 64)
+		if err != nil {
+			return params, errors.New("invalid params")
+		}
+		params.PromptID = id
+	}
+	if version := query.Get("prompt_version"); version != "" {
+		v, err := strconv.Atoi(version)
+		if err != nil || params.PromptID == 0 {
+			return params, errors.New("invalid params")
+		}
+		params.PromptVersion = v
+	}
+	if err := h.validate.Struct(params); err != nil {
+		return params, errors.New("invalid params")
+	}
+	return params, nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
 }
 
 // @Summary      Создание нового поста
@@ -77,6 +234,9 @@ func (h *handler) HandleGenerateContent(w http.ResponseWriter, r *http.Request)
 // @Param audience formData string true "Аудитория (beginner, intermediate, advanced)"
 // @Success      200    {object}  domain.Post
 // @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      422    {object}  ReviewFailedResponse  "Пост не прошёл AI-проверку"
+// @Failure      429    {object}  httpx.Response  "Лимит запросов провайдера при AI-проверке"
+// @Failure      503    {object}  httpx.Response  "AI-проверка недоступна"
 // @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
 // @Router       /content/post [post]
 func (h *handler) HandleCreatePost(w http.ResponseWriter, r *http.Request) {
@@ -97,6 +257,9 @@ func (h *handler) HandleCreatePost(w http.ResponseWriter, r *http.Request) {
 
 	post, err := h.contentSvc.CreatePost(r.Context(), dto)
 	if err != nil {
+		if writeReviewError(w, err) {
+			return
+		}
 		h.logger.Error("error creating post", "error", err)
 		httpx.WriteError(w, "failed to create post", http.StatusInternalServerError)
 		return
@@ -159,3 +322,327 @@ func (h *handler) HandleGetPosts(w http.ResponseWriter, r *http.Request) {
 
 	httpx.WriteJSON(w, posts, http.StatusOK)
 }
+
+// @Summary      Изменение поста
+// @Description  Изменяет переданные поля поста и сохраняет новую ревизию с логином администратора
+// @Tags         content
+// @Accept 			 multipart/form-data
+// @Produce      json
+// @Param        id   path      int  true  "ID поста"
+// @Param images formData file false "Новый список изображений (заменяет текущий)"
+// @Param content formData string false "Текст поста"
+// @Param audience formData string false "Аудитория (beginner, intermediate, advanced)"
+// @Success      200    {object}  domain.Post
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      404    {object}  httpx.Response  "Пост не найден"
+// @Failure      422    {object}  ReviewFailedResponse  "Пост не прошёл AI-проверку"
+// @Failure      429    {object}  httpx.Response  "Лимит запросов провайдера при AI-проверке"
+// @Failure      503    {object}  httpx.Response  "AI-проверка недоступна"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id} [patch]
+func (h *handler) HandleUpdatePost(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	dto := domain.UpdatePostDTO{
+		Content:  r.FormValue("content"),
+		Images:   r.MultipartForm.File["images"],
+		Audience: domain.UserLvl(r.FormValue("audience")),
+	}
+	if err := h.validate.Struct(dto); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if dto.Content == "" && dto.Audience == "" && len(dto.Images) == 0 {
+		httpx.WriteError(w, "nothing to update", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.contentSvc.UpdatePost(r.Context(), id, dto)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "post not found", http.StatusNotFound)
+			return
+		}
+		if writeReviewError(w, err) {
+			return
+		}
+		httpx.WriteError(w, "failed to update post", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, post, http.StatusOK)
+}
+
+// @Summary      История изменений поста
+// @Tags         content
+// @Produce      json
+// @Param        id   path      int  true  "ID поста"
+// @Success      200  {array}   domain.PostRevision  "Ревизии, начиная с последней"
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Пост не найден"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/revisions [get]
+func (h *handler) HandleGetRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := h.contentSvc.PostRevisions(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "post not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get revisions", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, revisions, http.StatusOK)
+}
+
+// @Summary      Сравнение ревизий поста
+// @Tags         content
+// @Produce      json
+// @Param        id    path      int  true  "ID поста"
+// @Param        from  query     int  true  "Исходная версия"
+// @Param        to    query     int  true  "Конечная версия"
+// @Success      200  {object}  domain.PostDiff
+// @Failure      400  {object}  httpx.Response  "Некорректные параметры"
+// @Failure      404  {object}  httpx.Response  "Ревизия не найдена"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/diff [get]
+func (h *handler) HandleDiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		httpx.WriteError(w, "invalid from version", http.StatusBadRequest)
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		httpx.WriteError(w, "invalid to version", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := h.contentSvc.DiffRevisions(r.Context(), id, from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			httpx.WriteError(w, "revision not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to diff revisions", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, diff, http.StatusOK)
+}
+
+// @Summary      Откат поста к ревизии
+// @Description  Восстанавливает контент, аудиторию и изображения выбранной ревизии, откат сохраняется как новая ревизия
+// @Tags         content
+// @Produce      json
+// @Param        id       path      int  true  "ID поста"
+// @Param        version  path      int  true  "Версия для отката"
+// @Success      200  {object}  domain.Post
+// @Failure      400  {object}  httpx.Response  "Некорректные параметры"
+// @Failure      404  {object}  httpx.Response  "Ревизия не найдена"
+// @Failure      422  {object}  ReviewFailedResponse  "Пост не прошёл AI-проверку"
+// @Failure      429  {object}  httpx.Response  "Лимит запросов провайдера при AI-проверке"
+// @Failure      503  {object}  httpx.Response  "AI-проверка недоступна"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/revisions/{version}/rollback [post]
+func (h *handler) HandleRollbackPost(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil {
+		httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.contentSvc.RollbackPost(r.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) || errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "revision not found", http.StatusNotFound)
+			return
+		}
+		if writeReviewError(w, err) {
+			return
+		}
+		httpx.WriteError(w, "failed to rollback post", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, post, http.StatusOK)
+}
+
+// @Summary      Получение черновиков
+// @Description  Черновики не попадают в рассылку, пока администратор их не одобрит
+// @Tags         content
+// @Produce      json
+// @Success      200  {array}   domain.Post   "Список черновиков"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/drafts [get]
+func (h *handler) HandleGetDrafts(w http.ResponseWriter, r *http.Request) {
+	drafts, err := h.contentSvc.Drafts(r.Context())
+	if err != nil {
+		httpx.WriteError(w, "failed to get drafts", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, drafts, http.StatusOK)
+}
+
+// @Summary      Одобрение черновика
+// @Description  Переводит черновик в очередь на отправку
+// @Tags         content
+// @Produce      json
+// @Param        id   path      int  true  "ID поста"
+// @Success      200  {object}  domain.Post
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Пост не найден"
+// @Failure      422  {object}  ReviewFailedResponse  "Пост не прошёл AI-проверку"
+// @Failure      429  {object}  httpx.Response  "Лимит запросов провайдера при AI-проверке"
+// @Failure      503  {object}  httpx.Response  "AI-проверка недоступна"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/approve [post]
+func (h *handler) HandleApprovePost(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.contentSvc.ApprovePost(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "post not found", http.StatusNotFound)
+			return
+		}
+		if writeReviewError(w, err) {
+			return
+		}
+		httpx.WriteError(w, "failed to approve post", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, post, http.StatusOK)
+}
+
+// @Summary      AI-переработка поста
+// @Description  Переписывает существующий пост с помощью AI: adapt_to_level - под другой уровень аудитории (level),
+// @Description  shorten_to - сокращение до length символов, translate_to - перевод на язык language, change_tone - смена тона (tone).
+// @Description  При save=true результат сохраняется черновиком со ссылкой на исходный пост, изображения не копируются.
+// @Tags         content
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int             true  "ID исходного поста"
+// @Param        input  body      RewriteRequest  true  "Преобразование"
+// @Success      200    {object}  RewriteResponse
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      404    {object}  httpx.Response  "Пост не найден"
+// @Failure      429    {object}  httpx.Response  "Исчерпана дневная квота администратора или лимит запросов провайдера"
+// @Failure      502    {object}  httpx.Response  "Модель вернула некорректный ответ"
+// @Failure      503    {object}  httpx.Response  "AI провайдер недоступен"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/rewrite [post]
+func (h *handler) HandleRewritePost(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req RewriteRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	dto := domain.RewriteDTO{
+		Transform: domain.RewriteTransform(req.Transform),
+		Level:     domain.UserLvl(req.Level),
+		Length:    req.Length,
+		Language:  req.Language,
+		Tone:      domain.Tone(req.Tone),
+		Save:      req.Save,
+	}
+	if err := h.validate.Struct(dto); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.contentSvc.RewritePost(r.Context(), id, dto)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "post not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to rewrite post", "error", err, "id", id)
+		msg, code := generateError(err)
+		httpx.WriteError(w, msg, code)
+		return
+	}
+
+	httpx.WriteJSON(w, RewriteResponse{Status: httpx.StatusSuccess, RewriteResult: res}, http.StatusOK)
+}
+
+// @Summary      Вечнозелёный пост
+// @Description  Вечнозелёные посты отправляются повторно, когда в очереди аудитории нет новых постов.
+// @Description  Один и тот же пост не отправляется повторно ни аудитории, ни пользователю чаще минимального интервала.
+// @Tags         content
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int               true  "ID поста"
+// @Param        input  body      EvergreenRequest  true  "Флаг"
+// @Success      200    {object}  domain.Post
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      404    {object}  httpx.Response  "Пост не найден"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /content/post/{id}/evergreen [put]
+func (h *handler) HandleSetEvergreen(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req EvergreenRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.contentSvc.SetEvergreen(r.Context(), id, *req.Evergreen)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			httpx.WriteError(w, "post not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to update post", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, post, http.StatusOK)
+}
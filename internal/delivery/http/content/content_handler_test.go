@@ -1,6 +1,7 @@
 package content_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -10,42 +11,148 @@ import (
 	contentHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/content"
 	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/content/mocks"
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
 	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestContentHandler_GenerateContent(t *testing.T) {
-	type MockBehavior func(repo *mocks.ContentService, theme string)
+	type MockBehavior func(svc *mocks.ContentService)
+
+	generated := domain.GeneratedPost{
+		Title:       "title",
+		Body:        "body",
+		Hashtags:    []string{"#tag"},
+		ImagePrompt: "image",
+		Content:     "*title*\n\nbody\n\n#tag",
+	}
 
 	testCases := []struct {
 		name           string
-		theme          string
+		query          string
 		mockBehavior   MockBehavior
 		wantStatusCode int
 		wantBody       string
 	}{
 		{
-			name:  "success",
-			theme: "test_theme",
-			mockBehavior: func(svc *mocks.ContentService, theme string) {
-				svc.EXPECT().GenerateContent(mock.Anything, theme).Return("test_content", nil).Once()
+			name:  "success with defaults",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, domain.GenerateParams{
+					Theme:    "test_theme",
+					Audience: domain.UserLvlDefault,
+					Tone:     domain.ToneFriendly,
+					Length:   domain.LengthLong,
+					Language: "ru",
+				}).Return(generated, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"success","title":"title","body":"body","hashtags":["#tag"],"image_prompt":"image","content":"*title*\n\nbody\n\n#tag"}` + "\n",
+		},
+		{
+			name:  "success with params",
+			query: "theme=test_theme&audience=beginner&tone=expert&length=short&language=en",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, domain.GenerateParams{
+					Theme:    "test_theme",
+					Audience: domain.UserLvlBeginner,
+					Tone:     domain.ToneExpert,
+					Length:   domain.LengthShort,
+					Language: "en",
+				}).Return(generated, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"success","title":"title","body":"body","hashtags":["#tag"],"image_prompt":"image","content":"*title*\n\nbody\n\n#tag"}` + "\n",
+		},
+		{
+			name:  "success with prompt",
+			query: "theme=test_theme&prompt=2&prompt_version=3",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, domain.GenerateParams{
+					Theme:         "test_theme",
+					Audience:      domain.UserLvlDefault,
+					Tone:          domain.ToneFriendly,
+					Length:        domain.LengthLong,
+					Language:      "ru",
+					PromptID:      2,
+					PromptVersion: 3,
+				}).Return(domain.GeneratedPost{Body: "body", Hashtags: []string{}, Content: "body", PromptID: 2, PromptVersion: 3}, nil).Once()
 			},
 			wantStatusCode: http.StatusOK,
-			wantBody:       `{"status":"success","content":"test_content"}` + "\n",
+			wantBody:       `{"status":"success","title":"","body":"body","hashtags":[],"image_prompt":"","content":"body","prompt_id":2,"prompt_version":3}` + "\n",
+		},
+		{
+			name:           "version without prompt",
+			query:          "theme=test_theme&prompt_version=3",
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid params"}` + "\n",
+		},
+		{
+			name:  "prompt not found",
+			query: "theme=test_theme&prompt=2",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, domain.ErrPromptVersionNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"prompt not found"}` + "\n",
+		},
+		{
+			name:  "quota exceeded",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, domain.ErrQuotaExceeded).Once()
+			},
+			wantStatusCode: http.StatusTooManyRequests,
+			wantBody:       `{"status":"error","code":429,"message":"daily quota exceeded"}` + "\n",
+		},
+		{
+			name:  "provider rate limited",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, fmt.Errorf("%w: status 429", ai.ErrRateLimited)).Once()
+			},
+			wantStatusCode: http.StatusTooManyRequests,
+			wantBody:       `{"status":"error","code":429,"message":"ai provider rate limited"}` + "\n",
+		},
+		{
+			name:  "provider unavailable",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, fmt.Errorf("%w: circuit breaker is open", ai.ErrUnavailable)).Once()
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody:       `{"status":"error","code":503,"message":"ai provider unavailable"}` + "\n",
 		},
 		{
 			name:           "no theme",
-			theme:          "",
-			mockBehavior:   func(svc *mocks.ContentService, theme string) {},
+			query:          "theme=",
+			mockBehavior:   func(svc *mocks.ContentService) {},
 			wantStatusCode: http.StatusBadRequest,
 			wantBody:       `{"status":"error","code":400,"message":"theme is required"}` + "\n",
 		},
+		{
+			name:           "invalid tone",
+			query:          "theme=test_theme&tone=angry",
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid params"}` + "\n",
+		},
+		{
+			name:  "invalid generation",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, domain.ErrInvalidGeneration).Once()
+			},
+			wantStatusCode: http.StatusBadGateway,
+			wantBody:       `{"status":"error","code":502,"message":"model returned invalid content"}` + "\n",
+		},
 		{
 			name:  "error",
-			theme: "test_theme",
-			mockBehavior: func(svc *mocks.ContentService, theme string) {
-				svc.EXPECT().GenerateContent(mock.Anything, theme).Return("", fmt.Errorf("error")).Once()
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, fmt.Errorf("error")).Once()
 			},
 			wantStatusCode: http.StatusInternalServerError,
 			wantBody:       `{"status":"error","code":500,"message":"failed to generate content"}` + "\n",
@@ -55,13 +162,12 @@ func TestContentHandler_GenerateContent(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			contentSvc := mocks.NewContentService(t)
-			tc.mockBehavior(contentSvc, tc.theme)
+			tc.mockBehavior(contentSvc)
 
 			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
 
 			rec := httptest.NewRecorder()
-			url := fmt.Sprintf("/content/generate?theme=%s", tc.theme)
-			req := testutils.NewJSONRequest(t, http.MethodGet, url, nil)
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/content/generate?"+tc.query, nil)
 			handler.HandleGenerateContent(rec, req)
 
 			assert.Equal(t, tc.wantStatusCode, rec.Code)
@@ -122,6 +228,15 @@ func TestContentHandler_CreatePost(t *testing.T) {
 			wantStatusCode: http.StatusBadRequest,
 			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
 		},
+		{
+			name: "reviewer unavailable",
+			args: args{content: "test content", audience: domain.UserLvlDefault, withImage: true},
+			mockBehavior: func(svc *mocks.ContentService, args args) {
+				svc.EXPECT().CreatePost(mock.Anything, mock.Anything).Return(domain.Post{}, fmt.Errorf("%w: circuit breaker is open", ai.ErrUnavailable)).Once()
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody:       `{"status":"error","code":503,"message":"post review failed: ai provider unavailable"}` + "\n",
+		},
 		{
 			name: "error",
 			args: args{content: "test content", audience: domain.UserLvlDefault, withImage: true},
@@ -293,3 +408,461 @@ func TestContentHandler_HandleGetPosts(t *testing.T) {
 		})
 	}
 }
+
+func TestContentHandler_HandleUpdatePost(t *testing.T) {
+	type args struct {
+		id      string
+		content string
+	}
+
+	type MockBehavior func(svc *mocks.ContentService, args args)
+
+	testCases := []struct {
+		name           string
+		args           args
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			args: args{id: "1", content: "new content"},
+			mockBehavior: func(svc *mocks.ContentService, args args) {
+				svc.EXPECT().UpdatePost(mock.Anything, int64(1), mock.Anything).
+					Return(domain.Post{ID: 1, Content: args.content, Audience: domain.UserLvlDefault, Images: []string{"http://image.ru"}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":1,"content":"new content","audience":"default","images":["http://image.ru"]}` + "\n",
+		},
+		{
+			name:           "invalid id",
+			args:           args{id: "abc", content: "new content"},
+			mockBehavior:   func(svc *mocks.ContentService, args args) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid id"}` + "\n",
+		},
+		{
+			name:           "nothing to update",
+			args:           args{id: "1"},
+			mockBehavior:   func(svc *mocks.ContentService, args args) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"nothing to update"}` + "\n",
+		},
+		{
+			name: "post not found",
+			args: args{id: "1", content: "new content"},
+			mockBehavior: func(svc *mocks.ContentService, args args) {
+				svc.EXPECT().UpdatePost(mock.Anything, int64(1), mock.Anything).Return(domain.Post{}, domain.ErrPostNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"post not found"}` + "\n",
+		},
+		{
+			name: "reviewer rate limited",
+			args: args{id: "1", content: "new content"},
+			mockBehavior: func(svc *mocks.ContentService, args args) {
+				svc.EXPECT().UpdatePost(mock.Anything, int64(1), mock.Anything).Return(domain.Post{}, fmt.Errorf("%w: status 429", ai.ErrRateLimited)).Once()
+			},
+			wantStatusCode: http.StatusTooManyRequests,
+			wantBody:       `{"status":"error","code":429,"message":"post review failed: ai provider rate limited"}` + "\n",
+		},
+		{
+			name: "error",
+			args: args{id: "1", content: "new content"},
+			mockBehavior: func(svc *mocks.ContentService, args args) {
+				svc.EXPECT().UpdatePost(mock.Anything, int64(1), mock.Anything).Return(domain.Post{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to update post"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc, tc.args)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+			rec := httptest.NewRecorder()
+			body := map[string]any{"content": tc.args.content}
+			req := testutils.NewMultipartRequest(t, http.MethodPatch, "/content/post/"+tc.args.id, body)
+			req.SetPathValue("id", tc.args.id)
+			handler.HandleUpdatePost(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContentHandler_HandleDiffRevisions(t *testing.T) {
+	type MockBehavior func(svc *mocks.ContentService)
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:  "success",
+			query: "from=1&to=2",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().DiffRevisions(mock.Anything, int64(1), 1, 2).Return(domain.PostDiff{
+					PostID:        1,
+					From:          1,
+					To:            2,
+					Content:       []domain.DiffLine{{Op: domain.DiffOpInsert, Text: "line"}},
+					AudienceFrom:  domain.UserLvlDefault,
+					AudienceTo:    domain.UserLvlDefault,
+					ImagesAdded:   []string{},
+					ImagesRemoved: []string{},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"post_id":1,"from":1,"to":2,"content":[{"op":"insert","text":"line"}],"audience_from":"default","audience_to":"default","images_added":[],"images_removed":[]}` + "\n",
+		},
+		{
+			name:           "invalid version",
+			query:          "from=1",
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid to version"}` + "\n",
+		},
+		{
+			name:  "revision not found",
+			query: "from=1&to=5",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().DiffRevisions(mock.Anything, int64(1), 1, 5).Return(domain.PostDiff{}, domain.ErrRevisionNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"revision not found"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/content/post/1/diff?"+tc.query, nil)
+			req.SetPathValue("id", "1")
+			handler.HandleDiffRevisions(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContentHandler_HandleRollbackPost(t *testing.T) {
+	type MockBehavior func(svc *mocks.ContentService)
+
+	testCases := []struct {
+		name           string
+		version        string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success",
+			version: "1",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RollbackPost(mock.Anything, int64(1), 1).
+					Return(domain.Post{ID: 1, Content: "old content", Audience: domain.UserLvlDefault, Images: []string{}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":1,"content":"old content","audience":"default","images":[]}` + "\n",
+		},
+		{
+			name:           "invalid version",
+			version:        "abc",
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid version"}` + "\n",
+		},
+		{
+			name:    "revision not found",
+			version: "3",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RollbackPost(mock.Anything, int64(1), 3).Return(domain.Post{}, domain.ErrRevisionNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"revision not found"}` + "\n",
+		},
+		{
+			name:    "failed review",
+			version: "2",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RollbackPost(mock.Anything, int64(1), 2).Return(domain.Post{}, &domain.ReviewFailedError{Review: domain.Review{
+					Verdict: domain.ReviewVerdictFail,
+					Scores:  domain.ReviewScores{Safety: 2, LevelFit: 7, Accuracy: 8},
+					Flagged: []domain.FlaggedSentence{{Sentence: "Eat nothing.", Category: domain.ReviewCategorySafety, Reason: "starvation"}},
+					Summary: "unsafe diet",
+				}}).Once()
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			wantBody: `{"status":"error","code":422,"message":"post failed review","review":{"verdict":"fail",` +
+				`"scores":{"safety":2,"level_fit":7,"accuracy":8},` +
+				`"flagged":[{"sentence":"Eat nothing.","category":"safety","reason":"starvation"}],"summary":"unsafe diet"}}` + "\n",
+		},
+		{
+			name:    "error",
+			version: "1",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RollbackPost(mock.Anything, int64(1), 1).Return(domain.Post{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to rollback post"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+			rec := httptest.NewRecorder()
+			url := fmt.Sprintf("/content/post/1/revisions/%s/rollback", tc.version)
+			req := testutils.NewJSONRequest(t, http.MethodPost, url, nil)
+			req.SetPathValue("id", "1")
+			req.SetPathValue("version", tc.version)
+			handler.HandleRollbackPost(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContentHandler_RewritePost(t *testing.T) {
+	type MockBehavior func(svc *mocks.ContentService)
+
+	testCases := []struct {
+		name           string
+		body           contentHandler.RewriteRequest
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			body: contentHandler.RewriteRequest{Transform: "adapt_to_level", Level: "advanced", Save: true},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RewritePost(mock.Anything, int64(1), domain.RewriteDTO{
+					Transform: domain.RewriteAdaptToLevel,
+					Level:     domain.UserLvlAdvanced,
+					Save:      true,
+				}).Return(domain.RewriteResult{
+					Post:  domain.GeneratedPost{Title: "Squats", Body: "Go heavy.", Hashtags: []string{}, Content: "*Squats*\n\nGo heavy."},
+					Draft: &domain.Post{ID: 2, Content: "*Squats*\n\nGo heavy.", Audience: domain.UserLvlAdvanced, Images: []string{}, Status: domain.PostStatusDraft, SourceID: 1},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{"status":"success","post":{"title":"Squats","body":"Go heavy.","hashtags":[],"image_prompt":"","content":"*Squats*\n\nGo heavy."},` +
+				`"draft":{"id":2,"content":"*Squats*\n\nGo heavy.","audience":"advanced","images":[],"status":"draft","source_id":1}}` + "\n",
+		},
+		{
+			name:           "missing transform param",
+			body:           contentHandler.RewriteRequest{Transform: "shorten_to"},
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name:           "unknown transform",
+			body:           contentHandler.RewriteRequest{Transform: "summarize"},
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "post not found",
+			body: contentHandler.RewriteRequest{Transform: "translate_to", Language: "en"},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RewritePost(mock.Anything, int64(1), mock.Anything).Return(domain.RewriteResult{}, domain.ErrPostNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"post not found"}` + "\n",
+		},
+		{
+			name: "invalid generation",
+			body: contentHandler.RewriteRequest{Transform: "change_tone", Tone: "expert"},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().RewritePost(mock.Anything, int64(1), mock.Anything).Return(domain.RewriteResult{}, domain.ErrInvalidGeneration).Once()
+			},
+			wantStatusCode: http.StatusBadGateway,
+			wantBody:       `{"status":"error","code":502,"message":"model returned invalid content"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/content/post/1/rewrite", tc.body)
+			req.SetPathValue("id", "1")
+			handler.HandleRewritePost(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContentHandler_StreamContent(t *testing.T) {
+	type MockBehavior func(svc *mocks.ContentService)
+
+	streamed := func(post domain.GeneratedPost, err error, chunks ...string) func(ctx context.Context, params domain.GenerateParams, onChunk func(string) error) (domain.GeneratedPost, error) {
+		return func(ctx context.Context, params domain.GenerateParams, onChunk func(string) error) (domain.GeneratedPost, error) {
+			for _, chunk := range chunks {
+				if err := onChunk(chunk); err != nil {
+					return domain.GeneratedPost{}, err
+				}
+			}
+			return post, err
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		query           string
+		mockBehavior    MockBehavior
+		wantStatusCode  int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:  "success",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(streamed(domain.GeneratedPost{Body: "body", Hashtags: []string{}, Content: "body"}, nil, "bo", "dy")).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/event-stream",
+			wantBody: "event: chunk\ndata: {\"text\":\"bo\"}\n\n" +
+				"event: chunk\ndata: {\"text\":\"dy\"}\n\n" +
+				"event: done\ndata: {\"status\":\"success\",\"title\":\"\",\"body\":\"body\",\"hashtags\":[],\"image_prompt\":\"\",\"content\":\"body\"}\n\n",
+		},
+		{
+			name:  "error after stream started",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(streamed(domain.GeneratedPost{}, domain.ErrInvalidGeneration, "bo")).Once()
+			},
+			wantStatusCode:  http.StatusOK,
+			wantContentType: "text/event-stream",
+			wantBody: "event: chunk\ndata: {\"text\":\"bo\"}\n\n" +
+				"event: error\ndata: {\"status\":\"error\",\"code\":502,\"message\":\"model returned invalid content\"}\n\n",
+		},
+		{
+			name:  "error before stream started",
+			query: "theme=test_theme",
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, domain.ErrQuotaExceeded).Once()
+			},
+			wantStatusCode:  http.StatusTooManyRequests,
+			wantContentType: "application/json",
+			wantBody:        `{"status":"error","code":429,"message":"daily quota exceeded"}` + "\n",
+		},
+		{
+			name:            "no theme",
+			query:           "theme=",
+			mockBehavior:    func(svc *mocks.ContentService) {},
+			wantStatusCode:  http.StatusBadRequest,
+			wantContentType: "application/json",
+			wantBody:        `{"status":"error","code":400,"message":"theme is required"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/content/generate/stream?"+tc.query, nil)
+			handler.HandleStreamContent(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestContentHandler_SetEvergreen(t *testing.T) {
+	type MockBehavior func(svc *mocks.ContentService)
+
+	testCases := []struct {
+		name           string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			body: map[string]any{"evergreen": true},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().SetEvergreen(mock.Anything, int64(1), true).
+					Return(domain.Post{ID: 1, Content: "content", Audience: domain.UserLvlDefault, Images: []string{}, Evergreen: true}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":1,"content":"content","audience":"default","images":[],"evergreen":true}` + "\n",
+		},
+		{
+			name:           "missing flag",
+			body:           map[string]any{},
+			mockBehavior:   func(svc *mocks.ContentService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "post not found",
+			body: map[string]any{"evergreen": false},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().SetEvergreen(mock.Anything, int64(1), false).Return(domain.Post{}, domain.ErrPostNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"post not found"}` + "\n",
+		},
+		{
+			name: "error",
+			body: map[string]any{"evergreen": true},
+			mockBehavior: func(svc *mocks.ContentService) {
+				svc.EXPECT().SetEvergreen(mock.Anything, int64(1), true).Return(domain.Post{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to update post"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contentSvc := mocks.NewContentService(t)
+			tc.mockBehavior(contentSvc)
+
+			handler := contentHandler.New(testutils.NewTestLogger(), contentSvc)
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPut, "/content/post/1/evergreen", tc.body)
+			req.SetPathValue("id", "1")
+			handler.HandleSetEvergreen(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
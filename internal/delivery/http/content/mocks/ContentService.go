@@ -22,6 +22,63 @@ func (_m *ContentService) EXPECT() *ContentService_Expecter {
 	return &ContentService_Expecter{mock: &_m.Mock}
 }
 
+// ApprovePost provides a mock function with given fields: ctx, id
+func (_m *ContentService) ApprovePost(ctx context.Context, id int64) (domain.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApprovePost")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_ApprovePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApprovePost'
+type ContentService_ApprovePost_Call struct {
+	*mock.Call
+}
+
+// ApprovePost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ContentService_Expecter) ApprovePost(ctx interface{}, id interface{}) *ContentService_ApprovePost_Call {
+	return &ContentService_ApprovePost_Call{Call: _e.mock.On("ApprovePost", ctx, id)}
+}
+
+func (_c *ContentService_ApprovePost_Call) Run(run func(ctx context.Context, id int64)) *ContentService_ApprovePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ContentService_ApprovePost_Call) Return(_a0 domain.Post, _a1 error) *ContentService_ApprovePost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_ApprovePost_Call) RunAndReturn(run func(context.Context, int64) (domain.Post, error)) *ContentService_ApprovePost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePost provides a mock function with given fields: ctx, in
 func (_m *ContentService) CreatePost(ctx context.Context, in domain.CreatePostDTO) (domain.Post, error) {
 	ret := _m.Called(ctx, in)
@@ -79,27 +136,144 @@ func (_c *ContentService_CreatePost_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GenerateContent provides a mock function with given fields: ctx, theme
-func (_m *ContentService) GenerateContent(ctx context.Context, theme string) (string, error) {
-	ret := _m.Called(ctx, theme)
+// DiffRevisions provides a mock function with given fields: ctx, id, from, to
+func (_m *ContentService) DiffRevisions(ctx context.Context, id int64, from int, to int) (domain.PostDiff, error) {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRevisions")
+	}
+
+	var r0 domain.PostDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) (domain.PostDiff, error)); ok {
+		return rf(ctx, id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, int) domain.PostDiff); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(domain.PostDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int, int) error); ok {
+		r1 = rf(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type ContentService_DiffRevisions_Call struct {
+	*mock.Call
+}
+
+// DiffRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - from int
+//   - to int
+func (_e *ContentService_Expecter) DiffRevisions(ctx interface{}, id interface{}, from interface{}, to interface{}) *ContentService_DiffRevisions_Call {
+	return &ContentService_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", ctx, id, from, to)}
+}
+
+func (_c *ContentService_DiffRevisions_Call) Run(run func(ctx context.Context, id int64, from int, to int)) *ContentService_DiffRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ContentService_DiffRevisions_Call) Return(_a0 domain.PostDiff, _a1 error) *ContentService_DiffRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_DiffRevisions_Call) RunAndReturn(run func(context.Context, int64, int, int) (domain.PostDiff, error)) *ContentService_DiffRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Drafts provides a mock function with given fields: ctx
+func (_m *ContentService) Drafts(ctx context.Context) ([]domain.Post, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drafts")
+	}
+
+	var r0 []domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Post, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Post); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_Drafts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drafts'
+type ContentService_Drafts_Call struct {
+	*mock.Call
+}
+
+// Drafts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContentService_Expecter) Drafts(ctx interface{}) *ContentService_Drafts_Call {
+	return &ContentService_Drafts_Call{Call: _e.mock.On("Drafts", ctx)}
+}
+
+func (_c *ContentService_Drafts_Call) Run(run func(ctx context.Context)) *ContentService_Drafts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContentService_Drafts_Call) Return(_a0 []domain.Post, _a1 error) *ContentService_Drafts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_Drafts_Call) RunAndReturn(run func(context.Context) ([]domain.Post, error)) *ContentService_Drafts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateContent provides a mock function with given fields: ctx, params
+func (_m *ContentService) GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error) {
+	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateContent")
 	}
 
-	var r0 string
+	var r0 domain.GeneratedPost
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, theme)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams) (domain.GeneratedPost, error)); ok {
+		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, theme)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams) domain.GeneratedPost); ok {
+		r0 = rf(ctx, params)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(domain.GeneratedPost)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, theme)
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GenerateParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -114,31 +288,90 @@ type ContentService_GenerateContent_Call struct {
 
 // GenerateContent is a helper method to define mock.On call
 //   - ctx context.Context
-//   - theme string
-func (_e *ContentService_Expecter) GenerateContent(ctx interface{}, theme interface{}) *ContentService_GenerateContent_Call {
-	return &ContentService_GenerateContent_Call{Call: _e.mock.On("GenerateContent", ctx, theme)}
+//   - params domain.GenerateParams
+func (_e *ContentService_Expecter) GenerateContent(ctx interface{}, params interface{}) *ContentService_GenerateContent_Call {
+	return &ContentService_GenerateContent_Call{Call: _e.mock.On("GenerateContent", ctx, params)}
 }
 
-func (_c *ContentService_GenerateContent_Call) Run(run func(ctx context.Context, theme string)) *ContentService_GenerateContent_Call {
+func (_c *ContentService_GenerateContent_Call) Run(run func(ctx context.Context, params domain.GenerateParams)) *ContentService_GenerateContent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(domain.GenerateParams))
 	})
 	return _c
 }
 
-func (_c *ContentService_GenerateContent_Call) Return(_a0 string, _a1 error) *ContentService_GenerateContent_Call {
+func (_c *ContentService_GenerateContent_Call) Return(_a0 domain.GeneratedPost, _a1 error) *ContentService_GenerateContent_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ContentService_GenerateContent_Call) RunAndReturn(run func(context.Context, string) (string, error)) *ContentService_GenerateContent_Call {
+func (_c *ContentService_GenerateContent_Call) RunAndReturn(run func(context.Context, domain.GenerateParams) (domain.GeneratedPost, error)) *ContentService_GenerateContent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Posts provides a mock function with given fields: ctx, audience, published
-func (_m *ContentService) Posts(ctx context.Context, audience domain.UserLvl, published bool) ([]domain.Post, error) {
-	ret := _m.Called(ctx, audience, published)
+// PostRevisions provides a mock function with given fields: ctx, id
+func (_m *ContentService) PostRevisions(ctx context.Context, id int64) ([]domain.PostRevision, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRevisions")
+	}
+
+	var r0 []domain.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.PostRevision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PostRevision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_PostRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRevisions'
+type ContentService_PostRevisions_Call struct {
+	*mock.Call
+}
+
+// PostRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ContentService_Expecter) PostRevisions(ctx interface{}, id interface{}) *ContentService_PostRevisions_Call {
+	return &ContentService_PostRevisions_Call{Call: _e.mock.On("PostRevisions", ctx, id)}
+}
+
+func (_c *ContentService_PostRevisions_Call) Run(run func(ctx context.Context, id int64)) *ContentService_PostRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ContentService_PostRevisions_Call) Return(_a0 []domain.PostRevision, _a1 error) *ContentService_PostRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_PostRevisions_Call) RunAndReturn(run func(context.Context, int64) ([]domain.PostRevision, error)) *ContentService_PostRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Posts provides a mock function with given fields: ctx, audience, incoming
+func (_m *ContentService) Posts(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error) {
+	ret := _m.Called(ctx, audience, incoming)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Posts")
@@ -147,10 +380,10 @@ func (_m *ContentService) Posts(ctx context.Context, audience domain.UserLvl, pu
 	var r0 []domain.Post
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, bool) ([]domain.Post, error)); ok {
-		return rf(ctx, audience, published)
+		return rf(ctx, audience, incoming)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, bool) []domain.Post); ok {
-		r0 = rf(ctx, audience, published)
+		r0 = rf(ctx, audience, incoming)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Post)
@@ -158,7 +391,7 @@ func (_m *ContentService) Posts(ctx context.Context, audience domain.UserLvl, pu
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl, bool) error); ok {
-		r1 = rf(ctx, audience, published)
+		r1 = rf(ctx, audience, incoming)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -174,12 +407,12 @@ type ContentService_Posts_Call struct {
 // Posts is a helper method to define mock.On call
 //   - ctx context.Context
 //   - audience domain.UserLvl
-//   - published bool
-func (_e *ContentService_Expecter) Posts(ctx interface{}, audience interface{}, published interface{}) *ContentService_Posts_Call {
-	return &ContentService_Posts_Call{Call: _e.mock.On("Posts", ctx, audience, published)}
+//   - incoming bool
+func (_e *ContentService_Expecter) Posts(ctx interface{}, audience interface{}, incoming interface{}) *ContentService_Posts_Call {
+	return &ContentService_Posts_Call{Call: _e.mock.On("Posts", ctx, audience, incoming)}
 }
 
-func (_c *ContentService_Posts_Call) Run(run func(ctx context.Context, audience domain.UserLvl, published bool)) *ContentService_Posts_Call {
+func (_c *ContentService_Posts_Call) Run(run func(ctx context.Context, audience domain.UserLvl, incoming bool)) *ContentService_Posts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(domain.UserLvl), args[2].(bool))
 	})
@@ -243,6 +476,296 @@ func (_c *ContentService_RemovePost_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// RewritePost provides a mock function with given fields: ctx, id, in
+func (_m *ContentService) RewritePost(ctx context.Context, id int64, in domain.RewriteDTO) (domain.RewriteResult, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RewritePost")
+	}
+
+	var r0 domain.RewriteResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.RewriteDTO) (domain.RewriteResult, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.RewriteDTO) domain.RewriteResult); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		r0 = ret.Get(0).(domain.RewriteResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.RewriteDTO) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_RewritePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RewritePost'
+type ContentService_RewritePost_Call struct {
+	*mock.Call
+}
+
+// RewritePost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - in domain.RewriteDTO
+func (_e *ContentService_Expecter) RewritePost(ctx interface{}, id interface{}, in interface{}) *ContentService_RewritePost_Call {
+	return &ContentService_RewritePost_Call{Call: _e.mock.On("RewritePost", ctx, id, in)}
+}
+
+func (_c *ContentService_RewritePost_Call) Run(run func(ctx context.Context, id int64, in domain.RewriteDTO)) *ContentService_RewritePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.RewriteDTO))
+	})
+	return _c
+}
+
+func (_c *ContentService_RewritePost_Call) Return(_a0 domain.RewriteResult, _a1 error) *ContentService_RewritePost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_RewritePost_Call) RunAndReturn(run func(context.Context, int64, domain.RewriteDTO) (domain.RewriteResult, error)) *ContentService_RewritePost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RollbackPost provides a mock function with given fields: ctx, id, version
+func (_m *ContentService) RollbackPost(ctx context.Context, id int64, version int) (domain.Post, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackPost")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.Post, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.Post); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_RollbackPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollbackPost'
+type ContentService_RollbackPost_Call struct {
+	*mock.Call
+}
+
+// RollbackPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *ContentService_Expecter) RollbackPost(ctx interface{}, id interface{}, version interface{}) *ContentService_RollbackPost_Call {
+	return &ContentService_RollbackPost_Call{Call: _e.mock.On("RollbackPost", ctx, id, version)}
+}
+
+func (_c *ContentService_RollbackPost_Call) Run(run func(ctx context.Context, id int64, version int)) *ContentService_RollbackPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ContentService_RollbackPost_Call) Return(_a0 domain.Post, _a1 error) *ContentService_RollbackPost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_RollbackPost_Call) RunAndReturn(run func(context.Context, int64, int) (domain.Post, error)) *ContentService_RollbackPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetEvergreen provides a mock function with given fields: ctx, id, evergreen
+func (_m *ContentService) SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error) {
+	ret := _m.Called(ctx, id, evergreen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEvergreen")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) (domain.Post, error)); ok {
+		return rf(ctx, id, evergreen)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) domain.Post); ok {
+		r0 = rf(ctx, id, evergreen)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, id, evergreen)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_SetEvergreen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEvergreen'
+type ContentService_SetEvergreen_Call struct {
+	*mock.Call
+}
+
+// SetEvergreen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - evergreen bool
+func (_e *ContentService_Expecter) SetEvergreen(ctx interface{}, id interface{}, evergreen interface{}) *ContentService_SetEvergreen_Call {
+	return &ContentService_SetEvergreen_Call{Call: _e.mock.On("SetEvergreen", ctx, id, evergreen)}
+}
+
+func (_c *ContentService_SetEvergreen_Call) Run(run func(ctx context.Context, id int64, evergreen bool)) *ContentService_SetEvergreen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *ContentService_SetEvergreen_Call) Return(_a0 domain.Post, _a1 error) *ContentService_SetEvergreen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_SetEvergreen_Call) RunAndReturn(run func(context.Context, int64, bool) (domain.Post, error)) *ContentService_SetEvergreen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamContent provides a mock function with given fields: ctx, params, onChunk
+func (_m *ContentService) StreamContent(ctx context.Context, params domain.GenerateParams, onChunk func(string) error) (domain.GeneratedPost, error) {
+	ret := _m.Called(ctx, params, onChunk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamContent")
+	}
+
+	var r0 domain.GeneratedPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams, func(string) error) (domain.GeneratedPost, error)); ok {
+		return rf(ctx, params, onChunk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams, func(string) error) domain.GeneratedPost); ok {
+		r0 = rf(ctx, params, onChunk)
+	} else {
+		r0 = ret.Get(0).(domain.GeneratedPost)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GenerateParams, func(string) error) error); ok {
+		r1 = rf(ctx, params, onChunk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_StreamContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamContent'
+type ContentService_StreamContent_Call struct {
+	*mock.Call
+}
+
+// StreamContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params domain.GenerateParams
+//   - onChunk func(string) error
+func (_e *ContentService_Expecter) StreamContent(ctx interface{}, params interface{}, onChunk interface{}) *ContentService_StreamContent_Call {
+	return &ContentService_StreamContent_Call{Call: _e.mock.On("StreamContent", ctx, params, onChunk)}
+}
+
+func (_c *ContentService_StreamContent_Call) Run(run func(ctx context.Context, params domain.GenerateParams, onChunk func(string) error)) *ContentService_StreamContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.GenerateParams), args[2].(func(string) error))
+	})
+	return _c
+}
+
+func (_c *ContentService_StreamContent_Call) Return(_a0 domain.GeneratedPost, _a1 error) *ContentService_StreamContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_StreamContent_Call) RunAndReturn(run func(context.Context, domain.GenerateParams, func(string) error) (domain.GeneratedPost, error)) *ContentService_StreamContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePost provides a mock function with given fields: ctx, id, in
+func (_m *ContentService) UpdatePost(ctx context.Context, id int64, in domain.UpdatePostDTO) (domain.Post, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePost")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UpdatePostDTO) (domain.Post, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UpdatePostDTO) domain.Post); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.UpdatePostDTO) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContentService_UpdatePost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePost'
+type ContentService_UpdatePost_Call struct {
+	*mock.Call
+}
+
+// UpdatePost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - in domain.UpdatePostDTO
+func (_e *ContentService_Expecter) UpdatePost(ctx interface{}, id interface{}, in interface{}) *ContentService_UpdatePost_Call {
+	return &ContentService_UpdatePost_Call{Call: _e.mock.On("UpdatePost", ctx, id, in)}
+}
+
+func (_c *ContentService_UpdatePost_Call) Run(run func(ctx context.Context, id int64, in domain.UpdatePostDTO)) *ContentService_UpdatePost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.UpdatePostDTO))
+	})
+	return _c
+}
+
+func (_c *ContentService_UpdatePost_Call) Return(_a0 domain.Post, _a1 error) *ContentService_UpdatePost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContentService_UpdatePost_Call) RunAndReturn(run func(context.Context, int64, domain.UpdatePostDTO) (domain.Post, error)) *ContentService_UpdatePost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewContentService creates a new instance of ContentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContentService(t interface {
@@ -1,8 +1,43 @@
 package content
 
-import "github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+import (
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+)
 
 type GenerateContentResponse struct {
-	Status  httpx.Status `json:"status"`
-	Content string       `json:"content"`
+	Status httpx.Status `json:"status"`
+	domain.GeneratedPost
+}
+
+type StreamChunk struct {
+	Text string `json:"text" example:"Белок помогает "`
+}
+
+type ReviewFailedResponse struct {
+	httpx.Response
+	Review domain.Review `json:"review"`
+}
+
+type RewriteRequest struct {
+	Transform string `json:"transform" example:"adapt_to_level"`
+	// Level is target audience for adapt_to_level
+	Level string `json:"level" example:"advanced"`
+	// Length is max post length for shorten_to
+	Length int `json:"length" example:"150"`
+	// Language is target language for translate_to
+	Language string `json:"language" example:"en"`
+	// Tone is target tone for change_tone
+	Tone string `json:"tone" example:"expert"`
+	// Save stores result as draft linked to the source post
+	Save bool `json:"save" example:"false"`
+}
+
+type EvergreenRequest struct {
+	Evergreen *bool `json:"evergreen" validate:"required" example:"true"`
+}
+
+type RewriteResponse struct {
+	Status httpx.Status `json:"status"`
+	domain.RewriteResult
 }
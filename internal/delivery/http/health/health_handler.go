@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/SergeyBogomolovv/fitflow/pkg/leader"
+)
+
+type Elector interface {
+	Status() leader.Status
+}
+
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+const pingTimeout = 2 * time.Second
+
+type handler struct {
+	logger  *slog.Logger
+	elector Elector
+	db      Pinger
+}
+
+func New(logger *slog.Logger, elector Elector, db Pinger) *handler {
+	return &handler{logger, elector, db}
+}
+
+func (h *handler) Init(r *http.ServeMux) {
+	r.HandleFunc("GET /health", h.HandleHealth)
+}
+
+// HandleHealth reports database availability and whether this replica runs the scheduler.
+// Replica which is not the leader is healthy, it takes over when the leader dies.
+func (h *handler) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+	defer cancel()
+
+	resp := HealthResponse{Status: "ok", Database: "ok", Leader: h.elector.Status()}
+	code := http.StatusOK
+	if err := h.db.PingContext(ctx); err != nil {
+		h.logger.Error("health check failed", "error", err)
+		resp.Status = "unavailable"
+		resp.Database = "unavailable"
+		code = http.StatusServiceUnavailable
+	}
+
+	httpx.WriteJSON(w, resp, code)
+}
@@ -0,0 +1,73 @@
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	healthHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/health"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/health/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/leader"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthHandler_Health(t *testing.T) {
+	type MockBehavior func(elector *mocks.Elector, db *mocks.Pinger)
+
+	since := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "leader",
+			mockBehavior: func(elector *mocks.Elector, db *mocks.Pinger) {
+				elector.EXPECT().Status().Return(leader.Status{Instance: "bot-1", Leader: true, Since: &since}).Once()
+				db.EXPECT().PingContext(mock.Anything).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"ok","database":"ok","leader":{"instance":"bot-1","leader":true,"since":"2025-03-01T10:00:00Z"}}` + "\n",
+		},
+		{
+			name: "follower",
+			mockBehavior: func(elector *mocks.Elector, db *mocks.Pinger) {
+				elector.EXPECT().Status().Return(leader.Status{Instance: "bot-2"}).Once()
+				db.EXPECT().PingContext(mock.Anything).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"ok","database":"ok","leader":{"instance":"bot-2","leader":false}}` + "\n",
+		},
+		{
+			name: "database unavailable",
+			mockBehavior: func(elector *mocks.Elector, db *mocks.Pinger) {
+				elector.EXPECT().Status().Return(leader.Status{Instance: "bot-2"}).Once()
+				db.EXPECT().PingContext(mock.Anything).Return(assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody:       `{"status":"unavailable","database":"unavailable","leader":{"instance":"bot-2","leader":false}}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elector := mocks.NewElector(t)
+			db := mocks.NewPinger(t)
+			tc.mockBehavior(elector, db)
+
+			handler := healthHandler.New(testutils.NewTestLogger(), elector, db)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/health", nil)
+			handler.HandleHealth(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
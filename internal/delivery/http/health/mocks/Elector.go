@@ -0,0 +1,80 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	leader "github.com/SergeyBogomolovv/fitflow/pkg/leader"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Elector is an autogenerated mock type for the Elector type
+type Elector struct {
+	mock.Mock
+}
+
+type Elector_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Elector) EXPECT() *Elector_Expecter {
+	return &Elector_Expecter{mock: &_m.Mock}
+}
+
+// Status provides a mock function with no fields
+func (_m *Elector) Status() leader.Status {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 leader.Status
+	if rf, ok := ret.Get(0).(func() leader.Status); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(leader.Status)
+	}
+
+	return r0
+}
+
+// Elector_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type Elector_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *Elector_Expecter) Status() *Elector_Status_Call {
+	return &Elector_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *Elector_Status_Call) Run(run func()) *Elector_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Elector_Status_Call) Return(_a0 leader.Status) *Elector_Status_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Elector_Status_Call) RunAndReturn(run func() leader.Status) *Elector_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewElector creates a new instance of Elector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewElector(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Elector {
+	mock := &Elector{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,9 @@
+package health
+
+import "github.com/SergeyBogomolovv/fitflow/pkg/leader"
+
+type HealthResponse struct {
+	Status   string        `json:"status"`
+	Database string        `json:"database"`
+	Leader   leader.Status `json:"leader"`
+}
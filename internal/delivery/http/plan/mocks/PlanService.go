@@ -0,0 +1,267 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PlanService is an autogenerated mock type for the PlanService type
+type PlanService struct {
+	mock.Mock
+}
+
+type PlanService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PlanService) EXPECT() *PlanService_Expecter {
+	return &PlanService_Expecter{mock: &_m.Mock}
+}
+
+// ApproveJob provides a mock function with given fields: ctx, id
+func (_m *PlanService) ApproveJob(ctx context.Context, id string) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveJob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanService_ApproveJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveJob'
+type PlanService_ApproveJob_Call struct {
+	*mock.Call
+}
+
+// ApproveJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PlanService_Expecter) ApproveJob(ctx interface{}, id interface{}) *PlanService_ApproveJob_Call {
+	return &PlanService_ApproveJob_Call{Call: _e.mock.On("ApproveJob", ctx, id)}
+}
+
+func (_c *PlanService_ApproveJob_Call) Run(run func(ctx context.Context, id string)) *PlanService_ApproveJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PlanService_ApproveJob_Call) Return(_a0 int64, _a1 error) *PlanService_ApproveJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanService_ApproveJob_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *PlanService_ApproveJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePlan provides a mock function with given fields: ctx, in
+func (_m *PlanService) CreatePlan(ctx context.Context, in domain.CreatePlanDTO) (domain.PlanJob, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePlan")
+	}
+
+	var r0 domain.PlanJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreatePlanDTO) (domain.PlanJob, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreatePlanDTO) domain.PlanJob); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.PlanJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreatePlanDTO) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanService_CreatePlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePlan'
+type PlanService_CreatePlan_Call struct {
+	*mock.Call
+}
+
+// CreatePlan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in domain.CreatePlanDTO
+func (_e *PlanService_Expecter) CreatePlan(ctx interface{}, in interface{}) *PlanService_CreatePlan_Call {
+	return &PlanService_CreatePlan_Call{Call: _e.mock.On("CreatePlan", ctx, in)}
+}
+
+func (_c *PlanService_CreatePlan_Call) Run(run func(ctx context.Context, in domain.CreatePlanDTO)) *PlanService_CreatePlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CreatePlanDTO))
+	})
+	return _c
+}
+
+func (_c *PlanService_CreatePlan_Call) Return(_a0 domain.PlanJob, _a1 error) *PlanService_CreatePlan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanService_CreatePlan_Call) RunAndReturn(run func(context.Context, domain.CreatePlanDTO) (domain.PlanJob, error)) *PlanService_CreatePlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Job provides a mock function with given fields: ctx, id
+func (_m *PlanService) Job(ctx context.Context, id string) (domain.PlanJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Job")
+	}
+
+	var r0 domain.PlanJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.PlanJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.PlanJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PlanJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanService_Job_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Job'
+type PlanService_Job_Call struct {
+	*mock.Call
+}
+
+// Job is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PlanService_Expecter) Job(ctx interface{}, id interface{}) *PlanService_Job_Call {
+	return &PlanService_Job_Call{Call: _e.mock.On("Job", ctx, id)}
+}
+
+func (_c *PlanService_Job_Call) Run(run func(ctx context.Context, id string)) *PlanService_Job_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PlanService_Job_Call) Return(_a0 domain.PlanJob, _a1 error) *PlanService_Job_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanService_Job_Call) RunAndReturn(run func(context.Context, string) (domain.PlanJob, error)) *PlanService_Job_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JobDrafts provides a mock function with given fields: ctx, id
+func (_m *PlanService) JobDrafts(ctx context.Context, id string) ([]domain.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobDrafts")
+	}
+
+	var r0 []domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanService_JobDrafts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JobDrafts'
+type PlanService_JobDrafts_Call struct {
+	*mock.Call
+}
+
+// JobDrafts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PlanService_Expecter) JobDrafts(ctx interface{}, id interface{}) *PlanService_JobDrafts_Call {
+	return &PlanService_JobDrafts_Call{Call: _e.mock.On("JobDrafts", ctx, id)}
+}
+
+func (_c *PlanService_JobDrafts_Call) Run(run func(ctx context.Context, id string)) *PlanService_JobDrafts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PlanService_JobDrafts_Call) Return(_a0 []domain.Post, _a1 error) *PlanService_JobDrafts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanService_JobDrafts_Call) RunAndReturn(run func(context.Context, string) ([]domain.Post, error)) *PlanService_JobDrafts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPlanService creates a new instance of PlanService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPlanService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PlanService {
+	mock := &PlanService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,190 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type PlanService interface {
+	CreatePlan(ctx context.Context, in domain.CreatePlanDTO) (domain.PlanJob, error)
+	Job(ctx context.Context, id string) (domain.PlanJob, error)
+	JobDrafts(ctx context.Context, id string) ([]domain.Post, error)
+	ApproveJob(ctx context.Context, id string) (int64, error)
+}
+
+type handler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	planSvc  PlanService
+}
+
+func New(logger *slog.Logger, planSvc PlanService) *handler {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &handler{logger, validate, planSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /plans", h.HandleCreatePlan)
+	router.HandleFunc("GET /plans/{id}", h.HandleGetJob)
+	router.HandleFunc("GET /plans/{id}/drafts", h.HandleGetDrafts)
+	router.HandleFunc("POST /plans/{id}/approve", h.HandleApproveJob)
+	r.Handle("/plans", auth(router))
+	r.Handle("/plans/", auth(router))
+}
+
+// @Summary      Генерация контент-плана
+// @Description  Запускает фоновую генерацию черновиков на период: по одному посту на каждый слот и аудиторию.
+// @Description  Cadence задает количество постов в неделю для каждой аудитории, темы используются по кругу.
+// @Tags         plans
+// @Accept       json
+// @Produce      json
+// @Param        input  body      CreatePlanRequest  true  "Параметры плана"
+// @Success      202    {object}  domain.PlanJob
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /plans [post]
+func (h *handler) HandleCreatePlan(w http.ResponseWriter, r *http.Request) {
+	var req CreatePlanRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	dto := domain.CreatePlanDTO{
+		Cadence:  make(map[domain.UserLvl]int, len(req.Cadence)),
+		Themes:   req.Themes,
+		Tone:     domain.Tone(valueOr(req.Tone, string(domain.ToneFriendly))),
+		Length:   domain.Length(valueOr(req.Length, string(domain.LengthLong))),
+		Language: valueOr(req.Language, "ru"),
+		PromptID: req.PromptID,
+	}
+	dto.From, _ = time.Parse(time.DateOnly, req.From)
+	dto.To, _ = time.Parse(time.DateOnly, req.To)
+	for lvl, count := range req.Cadence {
+		dto.Cadence[domain.UserLvl(lvl)] = count
+	}
+	if err := h.validate.Struct(dto); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.planSvc.CreatePlan(r.Context(), dto)
+	if err != nil {
+		if errors.Is(err, domain.ErrPlanTooLarge) {
+			httpx.WriteError(w, "plan is too large", http.StatusBadRequest)
+			return
+		}
+		httpx.WriteError(w, "failed to create plan", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, job, http.StatusAccepted)
+}
+
+// @Summary      Статус генерации контент-плана
+// @Tags         plans
+// @Produce      json
+// @Param        id   path      string  true  "ID задачи"
+// @Success      200  {object}  domain.PlanJob
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Задача не найдена"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /plans/{id} [get]
+func (h *handler) HandleGetJob(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if uuid.Validate(id) != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.planSvc.Job(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			httpx.WriteError(w, "job not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get job", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, job, http.StatusOK)
+}
+
+// @Summary      Черновики контент-плана
+// @Tags         plans
+// @Produce      json
+// @Param        id   path      string  true  "ID задачи"
+// @Success      200  {array}   domain.Post  "Неодобренные черновики"
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Задача не найдена"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /plans/{id}/drafts [get]
+func (h *handler) HandleGetDrafts(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if uuid.Validate(id) != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	drafts, err := h.planSvc.JobDrafts(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			httpx.WriteError(w, "job not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get drafts", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, drafts, http.StatusOK)
+}
+
+// @Summary      Одобрение контент-плана
+// @Description  Переводит все оставшиеся черновики плана в очередь на отправку
+// @Tags         plans
+// @Produce      json
+// @Param        id   path      string  true  "ID задачи"
+// @Success      200  {object}  ApproveJobResponse
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Задача не найдена"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /plans/{id}/approve [post]
+func (h *handler) HandleApproveJob(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if uuid.Validate(id) != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.planSvc.ApproveJob(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			httpx.WriteError(w, "job not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to approve drafts", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, ApproveJobResponse{Status: httpx.StatusSuccess, Approved: count}, http.StatusOK)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,183 @@
+package plan_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	planHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/plan"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/plan/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const jobID = "0195b4a4-0a5e-7c4e-9d5f-1b2c3d4e5f60"
+
+func TestPlanHandler_CreatePlan(t *testing.T) {
+	type MockBehavior func(svc *mocks.PlanService)
+
+	validBody := planHandler.CreatePlanRequest{
+		From:    "2025-03-03",
+		To:      "2025-03-09",
+		Cadence: map[string]int{"beginner": 2},
+		Themes:  []string{"protein"},
+	}
+
+	testCases := []struct {
+		name           string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			body: validBody,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().CreatePlan(mock.Anything, domain.CreatePlanDTO{
+					From:     time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+					To:       time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC),
+					Cadence:  map[domain.UserLvl]int{domain.UserLvlBeginner: 2},
+					Themes:   []string{"protein"},
+					Tone:     domain.ToneFriendly,
+					Length:   domain.LengthLong,
+					Language: "ru",
+				}).Return(domain.PlanJob{ID: jobID, Status: domain.JobStatusPending, Total: 2}, nil).Once()
+			},
+			wantStatusCode: http.StatusAccepted,
+			wantBody:       `{"id":"` + jobID + `","status":"pending","params":{"from":"0001-01-01T00:00:00Z","to":"0001-01-01T00:00:00Z","cadence":null,"themes":null,"tone":"","length":"","language":"","slots":null},"total":2,"completed":0,"failed":0,"progress":0,"created_by":"","created_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name: "invalid dates",
+			body: planHandler.CreatePlanRequest{
+				From:    "2025-03-09",
+				To:      "2025-03-03",
+				Cadence: map[string]int{"beginner": 2},
+				Themes:  []string{"protein"},
+			},
+			mockBehavior:   func(svc *mocks.PlanService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "invalid audience",
+			body: planHandler.CreatePlanRequest{
+				From:    "2025-03-03",
+				To:      "2025-03-09",
+				Cadence: map[string]int{"expert": 2},
+				Themes:  []string{"protein"},
+			},
+			mockBehavior:   func(svc *mocks.PlanService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name:           "invalid body",
+			body:           "invalid",
+			mockBehavior:   func(svc *mocks.PlanService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid body"}` + "\n",
+		},
+		{
+			name: "plan too large",
+			body: validBody,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().CreatePlan(mock.Anything, mock.Anything).Return(domain.PlanJob{}, domain.ErrPlanTooLarge).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"plan is too large"}` + "\n",
+		},
+		{
+			name: "error",
+			body: validBody,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().CreatePlan(mock.Anything, mock.Anything).Return(domain.PlanJob{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to create plan"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			planSvc := mocks.NewPlanService(t)
+			tc.mockBehavior(planSvc)
+
+			handler := planHandler.New(testutils.NewTestLogger(), planSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/plans", tc.body)
+			handler.HandleCreatePlan(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestPlanHandler_ApproveJob(t *testing.T) {
+	type MockBehavior func(svc *mocks.PlanService)
+
+	testCases := []struct {
+		name           string
+		id             string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			id:   jobID,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().ApproveJob(mock.Anything, jobID).Return(5, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"success","approved":5}` + "\n",
+		},
+		{
+			name:           "invalid id",
+			id:             "invalid",
+			mockBehavior:   func(svc *mocks.PlanService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid id"}` + "\n",
+		},
+		{
+			name: "not found",
+			id:   jobID,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().ApproveJob(mock.Anything, jobID).Return(0, domain.ErrJobNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"job not found"}` + "\n",
+		},
+		{
+			name: "error",
+			id:   jobID,
+			mockBehavior: func(svc *mocks.PlanService) {
+				svc.EXPECT().ApproveJob(mock.Anything, jobID).Return(0, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to approve drafts"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			planSvc := mocks.NewPlanService(t)
+			tc.mockBehavior(planSvc)
+
+			handler := planHandler.New(testutils.NewTestLogger(), planSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/plans/"+tc.id+"/approve", nil)
+			req.SetPathValue("id", tc.id)
+			handler.HandleApproveJob(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
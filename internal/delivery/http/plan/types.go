@@ -0,0 +1,19 @@
+package plan
+
+import "github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+
+type CreatePlanRequest struct {
+	From     string         `json:"from" validate:"required,datetime=2006-01-02" example:"2025-03-03"`
+	To       string         `json:"to" validate:"required,datetime=2006-01-02" example:"2025-03-09"`
+	Cadence  map[string]int `json:"cadence" validate:"required,min=1"`
+	Themes   []string       `json:"themes" validate:"required,min=1" example:"Польза протеина,Растяжка после тренировки"`
+	Tone     string         `json:"tone" example:"friendly"`
+	Length   string         `json:"length" example:"long"`
+	Language string         `json:"language" example:"ru"`
+	PromptID int64          `json:"prompt_id" example:"1"`
+}
+
+type ApproveJobResponse struct {
+	Status   httpx.Status `json:"status"`
+	Approved int64        `json:"approved" example:"10"`
+}
@@ -0,0 +1,489 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PromptService is an autogenerated mock type for the PromptService type
+type PromptService struct {
+	mock.Mock
+}
+
+type PromptService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PromptService) EXPECT() *PromptService_Expecter {
+	return &PromptService_Expecter{mock: &_m.Mock}
+}
+
+// ActivateVersion provides a mock function with given fields: ctx, id, version
+func (_m *PromptService) ActivateVersion(ctx context.Context, id int64, version int) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateVersion")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.Prompt, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.Prompt); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_ActivateVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateVersion'
+type PromptService_ActivateVersion_Call struct {
+	*mock.Call
+}
+
+// ActivateVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *PromptService_Expecter) ActivateVersion(ctx interface{}, id interface{}, version interface{}) *PromptService_ActivateVersion_Call {
+	return &PromptService_ActivateVersion_Call{Call: _e.mock.On("ActivateVersion", ctx, id, version)}
+}
+
+func (_c *PromptService_ActivateVersion_Call) Run(run func(ctx context.Context, id int64, version int)) *PromptService_ActivateVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PromptService_ActivateVersion_Call) Return(_a0 domain.Prompt, _a1 error) *PromptService_ActivateVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_ActivateVersion_Call) RunAndReturn(run func(context.Context, int64, int) (domain.Prompt, error)) *PromptService_ActivateVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePrompt provides a mock function with given fields: ctx, in
+func (_m *PromptService) CreatePrompt(ctx context.Context, in domain.CreatePromptDTO) (domain.Prompt, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePrompt")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreatePromptDTO) (domain.Prompt, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreatePromptDTO) domain.Prompt); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreatePromptDTO) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_CreatePrompt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePrompt'
+type PromptService_CreatePrompt_Call struct {
+	*mock.Call
+}
+
+// CreatePrompt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in domain.CreatePromptDTO
+func (_e *PromptService_Expecter) CreatePrompt(ctx interface{}, in interface{}) *PromptService_CreatePrompt_Call {
+	return &PromptService_CreatePrompt_Call{Call: _e.mock.On("CreatePrompt", ctx, in)}
+}
+
+func (_c *PromptService_CreatePrompt_Call) Run(run func(ctx context.Context, in domain.CreatePromptDTO)) *PromptService_CreatePrompt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CreatePromptDTO))
+	})
+	return _c
+}
+
+func (_c *PromptService_CreatePrompt_Call) Return(_a0 domain.Prompt, _a1 error) *PromptService_CreatePrompt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_CreatePrompt_Call) RunAndReturn(run func(context.Context, domain.CreatePromptDTO) (domain.Prompt, error)) *PromptService_CreatePrompt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prompt provides a mock function with given fields: ctx, id
+func (_m *PromptService) Prompt(ctx context.Context, id int64) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prompt")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Prompt, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Prompt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_Prompt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prompt'
+type PromptService_Prompt_Call struct {
+	*mock.Call
+}
+
+// Prompt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptService_Expecter) Prompt(ctx interface{}, id interface{}) *PromptService_Prompt_Call {
+	return &PromptService_Prompt_Call{Call: _e.mock.On("Prompt", ctx, id)}
+}
+
+func (_c *PromptService_Prompt_Call) Run(run func(ctx context.Context, id int64)) *PromptService_Prompt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptService_Prompt_Call) Return(_a0 domain.Prompt, _a1 error) *PromptService_Prompt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_Prompt_Call) RunAndReturn(run func(context.Context, int64) (domain.Prompt, error)) *PromptService_Prompt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PromptVersion provides a mock function with given fields: ctx, id, version
+func (_m *PromptService) PromptVersion(ctx context.Context, id int64, version int) (domain.PromptVersion, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromptVersion")
+	}
+
+	var r0 domain.PromptVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.PromptVersion, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.PromptVersion); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.PromptVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_PromptVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromptVersion'
+type PromptService_PromptVersion_Call struct {
+	*mock.Call
+}
+
+// PromptVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *PromptService_Expecter) PromptVersion(ctx interface{}, id interface{}, version interface{}) *PromptService_PromptVersion_Call {
+	return &PromptService_PromptVersion_Call{Call: _e.mock.On("PromptVersion", ctx, id, version)}
+}
+
+func (_c *PromptService_PromptVersion_Call) Run(run func(ctx context.Context, id int64, version int)) *PromptService_PromptVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PromptService_PromptVersion_Call) Return(_a0 domain.PromptVersion, _a1 error) *PromptService_PromptVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_PromptVersion_Call) RunAndReturn(run func(context.Context, int64, int) (domain.PromptVersion, error)) *PromptService_PromptVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PromptVersions provides a mock function with given fields: ctx, id
+func (_m *PromptService) PromptVersions(ctx context.Context, id int64) ([]domain.PromptVersion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromptVersions")
+	}
+
+	var r0 []domain.PromptVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.PromptVersion, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PromptVersion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PromptVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_PromptVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromptVersions'
+type PromptService_PromptVersions_Call struct {
+	*mock.Call
+}
+
+// PromptVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptService_Expecter) PromptVersions(ctx interface{}, id interface{}) *PromptService_PromptVersions_Call {
+	return &PromptService_PromptVersions_Call{Call: _e.mock.On("PromptVersions", ctx, id)}
+}
+
+func (_c *PromptService_PromptVersions_Call) Run(run func(ctx context.Context, id int64)) *PromptService_PromptVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptService_PromptVersions_Call) Return(_a0 []domain.PromptVersion, _a1 error) *PromptService_PromptVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_PromptVersions_Call) RunAndReturn(run func(context.Context, int64) ([]domain.PromptVersion, error)) *PromptService_PromptVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Prompts provides a mock function with given fields: ctx
+func (_m *PromptService) Prompts(ctx context.Context) ([]domain.Prompt, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Prompts")
+	}
+
+	var r0 []domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Prompt, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Prompt); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Prompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_Prompts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Prompts'
+type PromptService_Prompts_Call struct {
+	*mock.Call
+}
+
+// Prompts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PromptService_Expecter) Prompts(ctx interface{}) *PromptService_Prompts_Call {
+	return &PromptService_Prompts_Call{Call: _e.mock.On("Prompts", ctx)}
+}
+
+func (_c *PromptService_Prompts_Call) Run(run func(ctx context.Context)) *PromptService_Prompts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PromptService_Prompts_Call) Return(_a0 []domain.Prompt, _a1 error) *PromptService_Prompts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_Prompts_Call) RunAndReturn(run func(context.Context) ([]domain.Prompt, error)) *PromptService_Prompts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePrompt provides a mock function with given fields: ctx, id
+func (_m *PromptService) RemovePrompt(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePrompt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PromptService_RemovePrompt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePrompt'
+type PromptService_RemovePrompt_Call struct {
+	*mock.Call
+}
+
+// RemovePrompt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptService_Expecter) RemovePrompt(ctx interface{}, id interface{}) *PromptService_RemovePrompt_Call {
+	return &PromptService_RemovePrompt_Call{Call: _e.mock.On("RemovePrompt", ctx, id)}
+}
+
+func (_c *PromptService_RemovePrompt_Call) Run(run func(ctx context.Context, id int64)) *PromptService_RemovePrompt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptService_RemovePrompt_Call) Return(_a0 error) *PromptService_RemovePrompt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PromptService_RemovePrompt_Call) RunAndReturn(run func(context.Context, int64) error) *PromptService_RemovePrompt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrompt provides a mock function with given fields: ctx, id, in
+func (_m *PromptService) UpdatePrompt(ctx context.Context, id int64, in domain.UpdatePromptDTO) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrompt")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UpdatePromptDTO) (domain.Prompt, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UpdatePromptDTO) domain.Prompt); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, domain.UpdatePromptDTO) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptService_UpdatePrompt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrompt'
+type PromptService_UpdatePrompt_Call struct {
+	*mock.Call
+}
+
+// UpdatePrompt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - in domain.UpdatePromptDTO
+func (_e *PromptService_Expecter) UpdatePrompt(ctx interface{}, id interface{}, in interface{}) *PromptService_UpdatePrompt_Call {
+	return &PromptService_UpdatePrompt_Call{Call: _e.mock.On("UpdatePrompt", ctx, id, in)}
+}
+
+func (_c *PromptService_UpdatePrompt_Call) Run(run func(ctx context.Context, id int64, in domain.UpdatePromptDTO)) *PromptService_UpdatePrompt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.UpdatePromptDTO))
+	})
+	return _c
+}
+
+func (_c *PromptService_UpdatePrompt_Call) Return(_a0 domain.Prompt, _a1 error) *PromptService_UpdatePrompt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptService_UpdatePrompt_Call) RunAndReturn(run func(context.Context, int64, domain.UpdatePromptDTO) (domain.Prompt, error)) *PromptService_UpdatePrompt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPromptService creates a new instance of PromptService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromptService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromptService {
+	mock := &PromptService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,319 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/go-playground/validator/v10"
+)
+
+type PromptService interface {
+	CreatePrompt(ctx context.Context, in domain.CreatePromptDTO) (domain.Prompt, error)
+	Prompts(ctx context.Context) ([]domain.Prompt, error)
+	Prompt(ctx context.Context, id int64) (domain.Prompt, error)
+	UpdatePrompt(ctx context.Context, id int64, in domain.UpdatePromptDTO) (domain.Prompt, error)
+	RemovePrompt(ctx context.Context, id int64) error
+	PromptVersions(ctx context.Context, id int64) ([]domain.PromptVersion, error)
+	PromptVersion(ctx context.Context, id int64, version int) (domain.PromptVersion, error)
+	ActivateVersion(ctx context.Context, id int64, version int) (domain.Prompt, error)
+}
+
+type handler struct {
+	logger    *slog.Logger
+	validate  *validator.Validate
+	promptSvc PromptService
+}
+
+func New(logger *slog.Logger, promptSvc PromptService) *handler {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &handler{logger, validate, promptSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /prompts", h.HandleCreatePrompt)
+	router.HandleFunc("GET /prompts", h.HandleGetPrompts)
+	router.HandleFunc("GET /prompts/{id}", h.HandleGetPrompt)
+	router.HandleFunc("PATCH /prompts/{id}", h.HandleUpdatePrompt)
+	router.HandleFunc("DELETE /prompts/{id}", h.HandleRemovePrompt)
+	router.HandleFunc("GET /prompts/{id}/versions", h.HandleGetVersions)
+	router.HandleFunc("GET /prompts/{id}/versions/{version}", h.HandleGetVersion)
+	router.HandleFunc("POST /prompts/{id}/versions/{version}/activate", h.HandleActivateVersion)
+	r.Handle("/prompts", auth(router))
+	r.Handle("/prompts/", auth(router))
+}
+
+// @Summary      Создание шаблона промпта
+// @Description  Шаблон задается в формате go text/template, доступны переменные .Theme, .Level, .Language, .Tone, .Length.
+// @Description  Шаблон сохраняется как первая версия и используется как системный промпт при генерации.
+// @Tags         prompts
+// @Accept       json
+// @Produce      json
+// @Param        input  body      CreatePromptRequest  true  "Шаблон промпта"
+// @Success      201    {object}  domain.Prompt
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      409    {object}  httpx.Response  "Шаблон с таким именем уже существует"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts [post]
+func (h *handler) HandleCreatePrompt(w http.ResponseWriter, r *http.Request) {
+	var req CreatePromptRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	prompt, err := h.promptSvc.CreatePrompt(r.Context(), domain.CreatePromptDTO{
+		Name:        req.Name,
+		Description: req.Description,
+		Template:    req.Template,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTemplate):
+			httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrPromptAlreadyExists):
+			httpx.WriteError(w, "prompt already exists", http.StatusConflict)
+		default:
+			httpx.WriteError(w, "failed to create prompt", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, prompt, http.StatusCreated)
+}
+
+// @Summary      Список шаблонов промптов
+// @Tags         prompts
+// @Produce      json
+// @Success      200  {array}   domain.Prompt  "Шаблоны с активной версией"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts [get]
+func (h *handler) HandleGetPrompts(w http.ResponseWriter, r *http.Request) {
+	prompts, err := h.promptSvc.Prompts(r.Context())
+	if err != nil {
+		httpx.WriteError(w, "failed to get prompts", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, prompts, http.StatusOK)
+}
+
+// @Summary      Получение шаблона промпта
+// @Tags         prompts
+// @Produce      json
+// @Param        id   path      int  true  "ID шаблона"
+// @Success      200  {object}  domain.Prompt
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Шаблон не найден"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id} [get]
+func (h *handler) HandleGetPrompt(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	prompt, err := h.promptSvc.Prompt(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			httpx.WriteError(w, "prompt not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get prompt", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, prompt, http.StatusOK)
+}
+
+// @Summary      Изменение шаблона промпта
+// @Description  Новый текст шаблона сохраняется следующей версией и становится активным, описание меняется на месте
+// @Tags         prompts
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                  true  "ID шаблона"
+// @Param        input  body      UpdatePromptRequest  true  "Изменяемые поля"
+// @Success      200    {object}  domain.Prompt
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      404    {object}  httpx.Response  "Шаблон не найден"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id} [patch]
+func (h *handler) HandleUpdatePrompt(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdatePromptRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil || (req.Description == nil && req.Template == nil) {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	prompt, err := h.promptSvc.UpdatePrompt(r.Context(), id, domain.UpdatePromptDTO{
+		Description: req.Description,
+		Template:    req.Template,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTemplate):
+			httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrPromptNotFound):
+			httpx.WriteError(w, "prompt not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "failed to update prompt", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, prompt, http.StatusOK)
+}
+
+// @Summary      Удаление шаблона промпта
+// @Description  Удаляет шаблон со всеми версиями, у созданных по нему постов ссылка на шаблон очищается
+// @Tags         prompts
+// @Produce      json
+// @Param        id   path      int  true  "ID шаблона"
+// @Success      200  {object}  httpx.Response  "Шаблон успешно удалён"
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Шаблон не найден"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id} [delete]
+func (h *handler) HandleRemovePrompt(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.promptSvc.RemovePrompt(r.Context(), id); err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			httpx.WriteError(w, "prompt not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to delete prompt", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteSuccess(w, "prompt deleted", http.StatusOK)
+}
+
+// @Summary      История версий шаблона промпта
+// @Tags         prompts
+// @Produce      json
+// @Param        id   path      int  true  "ID шаблона"
+// @Success      200  {array}   domain.PromptVersion  "Версии от новых к старым"
+// @Failure      400  {object}  httpx.Response  "Некорректный ID"
+// @Failure      404  {object}  httpx.Response  "Шаблон не найден"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id}/versions [get]
+func (h *handler) HandleGetVersions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		httpx.WriteError(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	versions, err := h.promptSvc.PromptVersions(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			httpx.WriteError(w, "prompt not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get versions", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, versions, http.StatusOK)
+}
+
+// @Summary      Получение версии шаблона промпта
+// @Tags         prompts
+// @Produce      json
+// @Param        id       path      int  true  "ID шаблона"
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  domain.PromptVersion
+// @Failure      400      {object}  httpx.Response  "Некорректный ID или версия"
+// @Failure      404      {object}  httpx.Response  "Версия не найдена"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id}/versions/{version} [get]
+func (h *handler) HandleGetVersion(w http.ResponseWriter, r *http.Request) {
+	id, version, err := parseVersionPath(r)
+	if err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.promptSvc.PromptVersion(r.Context(), id, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptVersionNotFound) {
+			httpx.WriteError(w, "version not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get version", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, v, http.StatusOK)
+}
+
+// @Summary      Активация версии шаблона промпта
+// @Description  Делает выбранную версию активной, используется для отката изменений шаблона
+// @Tags         prompts
+// @Produce      json
+// @Param        id       path      int  true  "ID шаблона"
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  domain.Prompt
+// @Failure      400      {object}  httpx.Response  "Некорректный ID или версия"
+// @Failure      404      {object}  httpx.Response  "Шаблон или версия не найдены"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /prompts/{id}/versions/{version}/activate [post]
+func (h *handler) HandleActivateVersion(w http.ResponseWriter, r *http.Request) {
+	id, version, err := parseVersionPath(r)
+	if err != nil {
+		httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	prompt, err := h.promptSvc.ActivateVersion(r.Context(), id, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPromptNotFound):
+			httpx.WriteError(w, "prompt not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrPromptVersionNotFound):
+			httpx.WriteError(w, "version not found", http.StatusNotFound)
+		default:
+			httpx.WriteError(w, "failed to activate version", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteJSON(w, prompt, http.StatusOK)
+}
+
+func parseVersionPath(r *http.Request) (int64, int, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid id")
+	}
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil || version < 1 {
+		return 0, 0, errors.New("invalid version")
+	}
+	return id, version, nil
+}
@@ -0,0 +1,215 @@
+package prompt_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	promptHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/prompt"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/prompt/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromptHandler_CreatePrompt(t *testing.T) {
+	type MockBehavior func(svc *mocks.PromptService)
+
+	testCases := []struct {
+		name           string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			body: promptHandler.CreatePromptRequest{Name: "expert", Template: "{{.Theme}}"},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().CreatePrompt(mock.Anything, domain.CreatePromptDTO{Name: "expert", Template: "{{.Theme}}"}).
+					Return(domain.Prompt{ID: 1, Name: "expert", ActiveVersion: 1, Template: "{{.Theme}}"}, nil).Once()
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody:       `{"id":1,"name":"expert","description":"","active_version":1,"template":"{{.Theme}}","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:           "invalid payload",
+			body:           promptHandler.CreatePromptRequest{Name: "expert"},
+			mockBehavior:   func(svc *mocks.PromptService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "invalid template",
+			body: promptHandler.CreatePromptRequest{Name: "expert", Template: "{{.Topic}}"},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().CreatePrompt(mock.Anything, mock.Anything).Return(domain.Prompt{}, domain.ErrInvalidTemplate).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid prompt template"}` + "\n",
+		},
+		{
+			name: "already exists",
+			body: promptHandler.CreatePromptRequest{Name: "expert", Template: "{{.Theme}}"},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().CreatePrompt(mock.Anything, mock.Anything).Return(domain.Prompt{}, domain.ErrPromptAlreadyExists).Once()
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"status":"error","code":409,"message":"prompt already exists"}` + "\n",
+		},
+		{
+			name: "error",
+			body: promptHandler.CreatePromptRequest{Name: "expert", Template: "{{.Theme}}"},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().CreatePrompt(mock.Anything, mock.Anything).Return(domain.Prompt{}, fmt.Errorf("error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to create prompt"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			promptSvc := mocks.NewPromptService(t)
+			tc.mockBehavior(promptSvc)
+
+			handler := promptHandler.New(testutils.NewTestLogger(), promptSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/prompts", tc.body)
+			handler.HandleCreatePrompt(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestPromptHandler_UpdatePrompt(t *testing.T) {
+	type MockBehavior func(svc *mocks.PromptService)
+
+	template := "{{.Theme}}"
+
+	testCases := []struct {
+		name           string
+		id             string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			id:   "1",
+			body: promptHandler.UpdatePromptRequest{Template: &template},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().UpdatePrompt(mock.Anything, int64(1), domain.UpdatePromptDTO{Template: &template}).
+					Return(domain.Prompt{ID: 1, Name: "expert", ActiveVersion: 2, Template: template}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":1,"name":"expert","description":"","active_version":2,"template":"{{.Theme}}","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:           "invalid id",
+			id:             "invalid",
+			body:           promptHandler.UpdatePromptRequest{Template: &template},
+			mockBehavior:   func(svc *mocks.PromptService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid id"}` + "\n",
+		},
+		{
+			name:           "nothing to update",
+			id:             "1",
+			body:           promptHandler.UpdatePromptRequest{},
+			mockBehavior:   func(svc *mocks.PromptService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "not found",
+			id:   "1",
+			body: promptHandler.UpdatePromptRequest{Template: &template},
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().UpdatePrompt(mock.Anything, int64(1), mock.Anything).Return(domain.Prompt{}, domain.ErrPromptNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"prompt not found"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			promptSvc := mocks.NewPromptService(t)
+			tc.mockBehavior(promptSvc)
+
+			handler := promptHandler.New(testutils.NewTestLogger(), promptSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPatch, "/prompts/"+tc.id, tc.body)
+			req.SetPathValue("id", tc.id)
+			handler.HandleUpdatePrompt(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestPromptHandler_ActivateVersion(t *testing.T) {
+	type MockBehavior func(svc *mocks.PromptService)
+
+	testCases := []struct {
+		name           string
+		version        string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success",
+			version: "1",
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().ActivateVersion(mock.Anything, int64(1), 1).
+					Return(domain.Prompt{ID: 1, Name: "expert", ActiveVersion: 1}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"id":1,"name":"expert","description":"","active_version":1,"template":"","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:           "invalid version",
+			version:        "0",
+			mockBehavior:   func(svc *mocks.PromptService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid version"}` + "\n",
+		},
+		{
+			name:    "version not found",
+			version: "5",
+			mockBehavior: func(svc *mocks.PromptService) {
+				svc.EXPECT().ActivateVersion(mock.Anything, int64(1), 5).Return(domain.Prompt{}, domain.ErrPromptVersionNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"version not found"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			promptSvc := mocks.NewPromptService(t)
+			tc.mockBehavior(promptSvc)
+
+			handler := promptHandler.New(testutils.NewTestLogger(), promptSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/prompts/1/versions/"+tc.version+"/activate", nil)
+			req.SetPathValue("id", "1")
+			req.SetPathValue("version", tc.version)
+			handler.HandleActivateVersion(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package prompt
+
+type CreatePromptRequest struct {
+	Name        string `json:"name" validate:"required,max=100" example:"expert"`
+	Description string `json:"description" validate:"max=500" example:"Экспертный стиль с отсылками к исследованиям"`
+	// Template is go text/template, available variables: .Theme, .Level, .Language, .Tone, .Length
+	Template string `json:"template" validate:"required,max=5000" example:"Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."`
+}
+
+type UpdatePromptRequest struct {
+	Description *string `json:"description" validate:"omitempty,max=500" example:"Экспертный стиль"`
+	// Template is saved as new active version
+	Template *string `json:"template" validate:"omitempty,min=1,max=5000" example:"Ты фитнес тренер. Тема: {{.Theme}}."`
+}
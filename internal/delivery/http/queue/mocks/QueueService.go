@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QueueService is an autogenerated mock type for the QueueService type
+type QueueService struct {
+	mock.Mock
+}
+
+type QueueService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *QueueService) EXPECT() *QueueService_Expecter {
+	return &QueueService_Expecter{mock: &_m.Mock}
+}
+
+// Depths provides a mock function with given fields: ctx
+func (_m *QueueService) Depths(ctx context.Context) ([]domain.QueueDepth, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Depths")
+	}
+
+	var r0 []domain.QueueDepth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.QueueDepth, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.QueueDepth); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QueueDepth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QueueService_Depths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Depths'
+type QueueService_Depths_Call struct {
+	*mock.Call
+}
+
+// Depths is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QueueService_Expecter) Depths(ctx interface{}) *QueueService_Depths_Call {
+	return &QueueService_Depths_Call{Call: _e.mock.On("Depths", ctx)}
+}
+
+func (_c *QueueService_Depths_Call) Run(run func(ctx context.Context)) *QueueService_Depths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QueueService_Depths_Call) Return(_a0 []domain.QueueDepth, _a1 error) *QueueService_Depths_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QueueService_Depths_Call) RunAndReturn(run func(context.Context) ([]domain.QueueDepth, error)) *QueueService_Depths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewQueueService creates a new instance of QueueService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQueueService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QueueService {
+	mock := &QueueService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
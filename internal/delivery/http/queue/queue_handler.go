@@ -0,0 +1,49 @@
+package queue
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+)
+
+type QueueService interface {
+	Depths(ctx context.Context) ([]domain.QueueDepth, error)
+}
+
+type handler struct {
+	logger   *slog.Logger
+	queueSvc QueueService
+}
+
+func New(logger *slog.Logger, queueSvc QueueService) *handler {
+	return &handler{logger, queueSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /queues", h.HandleGetQueues)
+	r.Handle("/queues", auth(router))
+}
+
+// @Summary      Глубина очереди постов
+// @Description  Количество одобренных неотправленных постов каждой аудитории, доступных для рассылки сейчас, и на сколько дней их хватит при текущем расписании.
+// @Description  Посты, запланированные на будущее, не учитываются.
+// @Description  Статус low и critical выставляется по настраиваемым порогам в днях, empty означает, что новых постов нет и рассылаются повторно вечнозелёные посты, а без них рассылки пропускаются.
+// @Description  Для отключенного расписания количество дней не указывается.
+// @Tags         queues
+// @Produce      json
+// @Success      200  {array}   domain.QueueDepth
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /queues [get]
+func (h *handler) HandleGetQueues(w http.ResponseWriter, r *http.Request) {
+	depths, err := h.queueSvc.Depths(r.Context())
+	if err != nil {
+		httpx.WriteError(w, "failed to get queues", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, depths, http.StatusOK)
+}
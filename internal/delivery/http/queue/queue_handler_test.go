@@ -0,0 +1,64 @@
+package queue_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	queueHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/queue"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/queue/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueueHandler_GetQueues(t *testing.T) {
+	type MockBehavior func(svc *mocks.QueueService)
+
+	daysLeft := 1.5
+
+	testCases := []struct {
+		name           string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			mockBehavior: func(svc *mocks.QueueService) {
+				svc.EXPECT().Depths(mock.Anything).Return([]domain.QueueDepth{
+					{Audience: domain.UserLvlDefault, Posts: 4, Status: domain.QueueStatusOK},
+					{Audience: domain.UserLvlBeginner, Posts: 3, RunsPerDay: 2, DaysLeft: &daysLeft, Status: domain.QueueStatusCritical},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `[{"audience":"default","posts":4,"runs_per_day":0,"status":"ok"},` +
+				`{"audience":"beginner","posts":3,"runs_per_day":2,"days_left":1.5,"status":"critical"}]` + "\n",
+		},
+		{
+			name: "error",
+			mockBehavior: func(svc *mocks.QueueService) {
+				svc.EXPECT().Depths(mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to get queues"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queueSvc := mocks.NewQueueService(t)
+			tc.mockBehavior(queueSvc)
+
+			handler := queueHandler.New(testutils.NewTestLogger(), queueSvc)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/queues", nil)
+			handler.HandleGetQueues(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
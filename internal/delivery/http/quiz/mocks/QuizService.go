@@ -0,0 +1,486 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QuizService is an autogenerated mock type for the QuizService type
+type QuizService struct {
+	mock.Mock
+}
+
+type QuizService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *QuizService) EXPECT() *QuizService_Expecter {
+	return &QuizService_Expecter{mock: &_m.Mock}
+}
+
+// ActivateQuiz provides a mock function with given fields: ctx, version
+func (_m *QuizService) ActivateQuiz(ctx context.Context, version int) (domain.Quiz, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateQuiz")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Quiz, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Quiz); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_ActivateQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActivateQuiz'
+type QuizService_ActivateQuiz_Call struct {
+	*mock.Call
+}
+
+// ActivateQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizService_Expecter) ActivateQuiz(ctx interface{}, version interface{}) *QuizService_ActivateQuiz_Call {
+	return &QuizService_ActivateQuiz_Call{Call: _e.mock.On("ActivateQuiz", ctx, version)}
+}
+
+func (_c *QuizService_ActivateQuiz_Call) Run(run func(ctx context.Context, version int)) *QuizService_ActivateQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizService_ActivateQuiz_Call) Return(_a0 domain.Quiz, _a1 error) *QuizService_ActivateQuiz_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_ActivateQuiz_Call) RunAndReturn(run func(context.Context, int) (domain.Quiz, error)) *QuizService_ActivateQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ActiveQuiz provides a mock function with given fields: ctx
+func (_m *QuizService) ActiveQuiz(ctx context.Context) (domain.Quiz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActiveQuiz")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Quiz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Quiz); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_ActiveQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ActiveQuiz'
+type QuizService_ActiveQuiz_Call struct {
+	*mock.Call
+}
+
+// ActiveQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QuizService_Expecter) ActiveQuiz(ctx interface{}) *QuizService_ActiveQuiz_Call {
+	return &QuizService_ActiveQuiz_Call{Call: _e.mock.On("ActiveQuiz", ctx)}
+}
+
+func (_c *QuizService_ActiveQuiz_Call) Run(run func(ctx context.Context)) *QuizService_ActiveQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QuizService_ActiveQuiz_Call) Return(_a0 domain.Quiz, _a1 error) *QuizService_ActiveQuiz_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_ActiveQuiz_Call) RunAndReturn(run func(context.Context) (domain.Quiz, error)) *QuizService_ActiveQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuiz provides a mock function with given fields: ctx, in
+func (_m *QuizService) CreateQuiz(ctx context.Context, in domain.CreateQuizDTO) (domain.Quiz, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuiz")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateQuizDTO) (domain.Quiz, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.CreateQuizDTO) domain.Quiz); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.CreateQuizDTO) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_CreateQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuiz'
+type QuizService_CreateQuiz_Call struct {
+	*mock.Call
+}
+
+// CreateQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in domain.CreateQuizDTO
+func (_e *QuizService_Expecter) CreateQuiz(ctx interface{}, in interface{}) *QuizService_CreateQuiz_Call {
+	return &QuizService_CreateQuiz_Call{Call: _e.mock.On("CreateQuiz", ctx, in)}
+}
+
+func (_c *QuizService_CreateQuiz_Call) Run(run func(ctx context.Context, in domain.CreateQuizDTO)) *QuizService_CreateQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.CreateQuizDTO))
+	})
+	return _c
+}
+
+func (_c *QuizService_CreateQuiz_Call) Return(_a0 domain.Quiz, _a1 error) *QuizService_CreateQuiz_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_CreateQuiz_Call) RunAndReturn(run func(context.Context, domain.CreateQuizDTO) (domain.Quiz, error)) *QuizService_CreateQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LevelStats provides a mock function with given fields: ctx, days, version
+func (_m *QuizService) LevelStats(ctx context.Context, days int, version int) ([]domain.LevelStats, error) {
+	ret := _m.Called(ctx, days, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LevelStats")
+	}
+
+	var r0 []domain.LevelStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]domain.LevelStats, error)); ok {
+		return rf(ctx, days, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []domain.LevelStats); ok {
+		r0 = rf(ctx, days, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LevelStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, days, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_LevelStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LevelStats'
+type QuizService_LevelStats_Call struct {
+	*mock.Call
+}
+
+// LevelStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - days int
+//   - version int
+func (_e *QuizService_Expecter) LevelStats(ctx interface{}, days interface{}, version interface{}) *QuizService_LevelStats_Call {
+	return &QuizService_LevelStats_Call{Call: _e.mock.On("LevelStats", ctx, days, version)}
+}
+
+func (_c *QuizService_LevelStats_Call) Run(run func(ctx context.Context, days int, version int)) *QuizService_LevelStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *QuizService_LevelStats_Call) Return(_a0 []domain.LevelStats, _a1 error) *QuizService_LevelStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_LevelStats_Call) RunAndReturn(run func(context.Context, int, int) ([]domain.LevelStats, error)) *QuizService_LevelStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Quiz provides a mock function with given fields: ctx, version
+func (_m *QuizService) Quiz(ctx context.Context, version int) (domain.Quiz, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quiz")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Quiz, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Quiz); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_Quiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Quiz'
+type QuizService_Quiz_Call struct {
+	*mock.Call
+}
+
+// Quiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizService_Expecter) Quiz(ctx interface{}, version interface{}) *QuizService_Quiz_Call {
+	return &QuizService_Quiz_Call{Call: _e.mock.On("Quiz", ctx, version)}
+}
+
+func (_c *QuizService_Quiz_Call) Run(run func(ctx context.Context, version int)) *QuizService_Quiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizService_Quiz_Call) Return(_a0 domain.Quiz, _a1 error) *QuizService_Quiz_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_Quiz_Call) RunAndReturn(run func(context.Context, int) (domain.Quiz, error)) *QuizService_Quiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QuizStats provides a mock function with given fields: ctx, version
+func (_m *QuizService) QuizStats(ctx context.Context, version int) (domain.QuizStats, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuizStats")
+	}
+
+	var r0 domain.QuizStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.QuizStats, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.QuizStats); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Get(0).(domain.QuizStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_QuizStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuizStats'
+type QuizService_QuizStats_Call struct {
+	*mock.Call
+}
+
+// QuizStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizService_Expecter) QuizStats(ctx interface{}, version interface{}) *QuizService_QuizStats_Call {
+	return &QuizService_QuizStats_Call{Call: _e.mock.On("QuizStats", ctx, version)}
+}
+
+func (_c *QuizService_QuizStats_Call) Run(run func(ctx context.Context, version int)) *QuizService_QuizStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizService_QuizStats_Call) Return(_a0 domain.QuizStats, _a1 error) *QuizService_QuizStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_QuizStats_Call) RunAndReturn(run func(context.Context, int) (domain.QuizStats, error)) *QuizService_QuizStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Quizzes provides a mock function with given fields: ctx
+func (_m *QuizService) Quizzes(ctx context.Context) ([]domain.Quiz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quizzes")
+	}
+
+	var r0 []domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Quiz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Quiz); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Quiz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizService_Quizzes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Quizzes'
+type QuizService_Quizzes_Call struct {
+	*mock.Call
+}
+
+// Quizzes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QuizService_Expecter) Quizzes(ctx interface{}) *QuizService_Quizzes_Call {
+	return &QuizService_Quizzes_Call{Call: _e.mock.On("Quizzes", ctx)}
+}
+
+func (_c *QuizService_Quizzes_Call) Run(run func(ctx context.Context)) *QuizService_Quizzes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QuizService_Quizzes_Call) Return(_a0 []domain.Quiz, _a1 error) *QuizService_Quizzes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizService_Quizzes_Call) RunAndReturn(run func(context.Context) ([]domain.Quiz, error)) *QuizService_Quizzes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveQuiz provides a mock function with given fields: ctx, version
+func (_m *QuizService) RemoveQuiz(ctx context.Context, version int) error {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveQuiz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QuizService_RemoveQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveQuiz'
+type QuizService_RemoveQuiz_Call struct {
+	*mock.Call
+}
+
+// RemoveQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizService_Expecter) RemoveQuiz(ctx interface{}, version interface{}) *QuizService_RemoveQuiz_Call {
+	return &QuizService_RemoveQuiz_Call{Call: _e.mock.On("RemoveQuiz", ctx, version)}
+}
+
+func (_c *QuizService_RemoveQuiz_Call) Run(run func(ctx context.Context, version int)) *QuizService_RemoveQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizService_RemoveQuiz_Call) Return(_a0 error) *QuizService_RemoveQuiz_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QuizService_RemoveQuiz_Call) RunAndReturn(run func(context.Context, int) error) *QuizService_RemoveQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewQuizService creates a new instance of QuizService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQuizService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuizService {
+	mock := &QuizService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,298 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/go-playground/validator/v10"
+)
+
+type QuizService interface {
+	CreateQuiz(ctx context.Context, in domain.CreateQuizDTO) (domain.Quiz, error)
+	Quizzes(ctx context.Context) ([]domain.Quiz, error)
+	Quiz(ctx context.Context, version int) (domain.Quiz, error)
+	ActiveQuiz(ctx context.Context) (domain.Quiz, error)
+	ActivateQuiz(ctx context.Context, version int) (domain.Quiz, error)
+	RemoveQuiz(ctx context.Context, version int) error
+	QuizStats(ctx context.Context, version int) (domain.QuizStats, error)
+	LevelStats(ctx context.Context, days, version int) ([]domain.LevelStats, error)
+}
+
+const maxStatsDays = 365
+
+type handler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	quizSvc  QuizService
+}
+
+func New(logger *slog.Logger, quizSvc QuizService) *handler {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &handler{logger, validate, quizSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("POST /quizzes", h.HandleCreateQuiz)
+	router.HandleFunc("GET /quizzes", h.HandleGetQuizzes)
+	router.HandleFunc("GET /quizzes/active", h.HandleGetActiveQuiz)
+	router.HandleFunc("GET /quizzes/levels", h.HandleGetLevelStats)
+	router.HandleFunc("GET /quizzes/{version}", h.HandleGetQuiz)
+	router.HandleFunc("GET /quizzes/{version}/stats", h.HandleGetQuizStats)
+	router.HandleFunc("POST /quizzes/{version}/activate", h.HandleActivateQuiz)
+	router.HandleFunc("DELETE /quizzes/{version}", h.HandleRemoveQuiz)
+	r.Handle("/quizzes", auth(router))
+	r.Handle("/quizzes/", auth(router))
+}
+
+// @Summary      Создание версии теста уровня
+// @Description  Версии теста неизменяемы, изменение вопросов, весов или порогов сохраняется новой версией.
+// @Description  Уровень определяется по сумме весов выбранных ответов: выбирается наибольший достигнутый порог.
+// @Tags         quizzes
+// @Accept       json
+// @Produce      json
+// @Param        input  body      CreateQuizRequest  true  "Вопросы, ответы с весами и пороги уровней"
+// @Success      201    {object}  domain.Quiz
+// @Failure      400    {object}  httpx.Response  "Неверные данные в запросе"
+// @Failure      500    {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes [post]
+func (h *handler) HandleCreateQuiz(w http.ResponseWriter, r *http.Request) {
+	var req CreateQuizRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	quiz, err := h.quizSvc.CreateQuiz(r.Context(), domain.CreateQuizDTO{
+		Questions:  req.Questions,
+		Thresholds: req.Thresholds,
+		Activate:   req.Activate,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuiz) {
+			httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		httpx.WriteError(w, "failed to create quiz", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, quiz, http.StatusCreated)
+}
+
+// @Summary      Список версий теста уровня
+// @Tags         quizzes
+// @Produce      json
+// @Success      200  {array}   domain.Quiz     "Версии от новых к старым"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes [get]
+func (h *handler) HandleGetQuizzes(w http.ResponseWriter, r *http.Request) {
+	quizzes, err := h.quizSvc.Quizzes(r.Context())
+	if err != nil {
+		httpx.WriteError(w, "failed to get quizzes", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, quizzes, http.StatusOK)
+}
+
+// @Summary      Активная версия теста уровня
+// @Description  Версия, которую бот выдает пользователям
+// @Tags         quizzes
+// @Produce      json
+// @Success      200  {object}  domain.Quiz
+// @Failure      404  {object}  httpx.Response  "Активная версия не выбрана"
+// @Failure      500  {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/active [get]
+func (h *handler) HandleGetActiveQuiz(w http.ResponseWriter, r *http.Request) {
+	quiz, err := h.quizSvc.ActiveQuiz(r.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			httpx.WriteError(w, "quiz not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get quiz", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, quiz, http.StatusOK)
+}
+
+// @Summary      Получение версии теста уровня
+// @Tags         quizzes
+// @Produce      json
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  domain.Quiz
+// @Failure      400      {object}  httpx.Response  "Некорректная версия"
+// @Failure      404      {object}  httpx.Response  "Версия не найдена"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/{version} [get]
+func (h *handler) HandleGetQuiz(w http.ResponseWriter, r *http.Request) {
+	version, err := parseVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	quiz, err := h.quizSvc.Quiz(r.Context(), version)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			httpx.WriteError(w, "quiz not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get quiz", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, quiz, http.StatusOK)
+}
+
+// @Summary      Активация версии теста уровня
+// @Description  Новые прохождения теста в боте используют выбранную версию, начатые прохождения завершаются по своей версии
+// @Tags         quizzes
+// @Produce      json
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  domain.Quiz
+// @Failure      400      {object}  httpx.Response  "Некорректная версия"
+// @Failure      404      {object}  httpx.Response  "Версия не найдена"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/{version}/activate [post]
+func (h *handler) HandleActivateQuiz(w http.ResponseWriter, r *http.Request) {
+	version, err := parseVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	quiz, err := h.quizSvc.ActivateQuiz(r.Context(), version)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			httpx.WriteError(w, "quiz not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to activate quiz", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, quiz, http.StatusOK)
+}
+
+// @Summary      Удаление версии теста уровня
+// @Description  Нельзя удалить активную версию и версию, которую уже проходили пользователи
+// @Tags         quizzes
+// @Produce      json
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  httpx.Response  "Версия успешно удалена"
+// @Failure      400      {object}  httpx.Response  "Некорректная версия"
+// @Failure      404      {object}  httpx.Response  "Версия не найдена"
+// @Failure      409      {object}  httpx.Response  "Версия активна или уже пройдена пользователями"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/{version} [delete]
+func (h *handler) HandleRemoveQuiz(w http.ResponseWriter, r *http.Request) {
+	version, err := parseVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.quizSvc.RemoveQuiz(r.Context(), version); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrQuizNotFound):
+			httpx.WriteError(w, "quiz not found", http.StatusNotFound)
+		case errors.Is(err, domain.ErrQuizActive), errors.Is(err, domain.ErrQuizInUse):
+			httpx.WriteError(w, err.Error(), http.StatusConflict)
+		default:
+			httpx.WriteError(w, "failed to delete quiz", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	httpx.WriteSuccess(w, "quiz deleted", http.StatusOK)
+}
+
+// @Summary      Статистика ответов версии теста
+// @Description  Количество и доля прохождений, в которых был выбран каждый вариант ответа
+// @Tags         quizzes
+// @Produce      json
+// @Param        version  path      int  true  "Номер версии"
+// @Success      200      {object}  domain.QuizStats
+// @Failure      400      {object}  httpx.Response  "Некорректная версия"
+// @Failure      404      {object}  httpx.Response  "Версия не найдена"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/{version}/stats [get]
+func (h *handler) HandleGetQuizStats(w http.ResponseWriter, r *http.Request) {
+	version, err := parseVersion(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.quizSvc.QuizStats(r.Context(), version)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			httpx.WriteError(w, "quiz not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get quiz stats", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, stats, http.StatusOK)
+}
+
+// @Summary      Распределение уровней по дням
+// @Description  Количество прохождений теста по итоговому уровню за каждый день, дни без прохождений пропускаются.
+// @Description  Дни считаются по UTC.
+// @Tags         quizzes
+// @Produce      json
+// @Param        days     query     int  false  "Количество дней истории (1-365)" default(30)
+// @Param        version  query     int  false  "Номер версии, по умолчанию все версии"
+// @Success      200      {array}   domain.LevelStats
+// @Failure      400      {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      500      {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /quizzes/levels [get]
+func (h *handler) HandleGetLevelStats(w http.ResponseWriter, r *http.Request) {
+	days := 30
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxStatsDays {
+			httpx.WriteError(w, "invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+	version := 0
+	if value := r.URL.Query().Get("version"); value != "" {
+		var err error
+		version, err = strconv.Atoi(value)
+		if err != nil || version < 1 {
+			httpx.WriteError(w, "invalid version", http.StatusBadRequest)
+			return
+		}
+	}
+
+	stats, err := h.quizSvc.LevelStats(r.Context(), days, version)
+	if err != nil {
+		httpx.WriteError(w, "failed to get level stats", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, stats, http.StatusOK)
+}
+
+func parseVersion(r *http.Request) (int, error) {
+	version, err := strconv.Atoi(r.PathValue("version"))
+	if err != nil || version < 1 {
+		return 0, errors.New("invalid version")
+	}
+	return version, nil
+}
@@ -0,0 +1,246 @@
+package quiz_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	quizHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/quiz"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/quiz/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQuizHandler_CreateQuiz(t *testing.T) {
+	type MockBehavior func(svc *mocks.QuizService)
+
+	questions := []domain.QuizQuestion{{
+		Text:    "Как часто вы тренируетесь?",
+		Options: []domain.QuizOption{{Text: "Редко", Weight: 1}, {Text: "Часто", Weight: 3}},
+	}}
+	thresholds := []domain.QuizThreshold{{Level: domain.UserLvlBeginner, MinScore: 0}, {Level: domain.UserLvlAdvanced, MinScore: 3}}
+
+	testCases := []struct {
+		name           string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success",
+			body: quizHandler.CreateQuizRequest{Questions: questions, Thresholds: thresholds, Activate: true},
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().CreateQuiz(mock.Anything, domain.CreateQuizDTO{Questions: questions, Thresholds: thresholds, Activate: true}).
+					Return(domain.Quiz{Version: 2, Questions: questions, Thresholds: thresholds, Active: true}, nil).Once()
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody: `{"version":2,"questions":[{"text":"Как часто вы тренируетесь?","options":[{"text":"Редко","weight":1},{"text":"Часто","weight":3}]}],` +
+				`"thresholds":[{"level":"beginner","min_score":0},{"level":"advanced","min_score":3}],"active":true,"author":"","created_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name: "single option",
+			body: quizHandler.CreateQuizRequest{
+				Questions:  []domain.QuizQuestion{{Text: "Вопрос", Options: []domain.QuizOption{{Text: "Да", Weight: 1}}}},
+				Thresholds: thresholds,
+			},
+			mockBehavior:   func(svc *mocks.QuizService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "unknown level",
+			body: quizHandler.CreateQuizRequest{
+				Questions:  questions,
+				Thresholds: []domain.QuizThreshold{{Level: domain.UserLvlDefault, MinScore: 0}},
+			},
+			mockBehavior:   func(svc *mocks.QuizService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name: "invalid quiz",
+			body: quizHandler.CreateQuizRequest{Questions: questions, Thresholds: thresholds},
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().CreateQuiz(mock.Anything, mock.Anything).
+					Return(domain.Quiz{}, fmt.Errorf("%w: duplicate threshold for beginner", domain.ErrInvalidQuiz)).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid quiz: duplicate threshold for beginner"}` + "\n",
+		},
+		{
+			name: "error",
+			body: quizHandler.CreateQuizRequest{Questions: questions, Thresholds: thresholds},
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().CreateQuiz(mock.Anything, mock.Anything).Return(domain.Quiz{}, fmt.Errorf("error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to create quiz"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quizSvc := mocks.NewQuizService(t)
+			tc.mockBehavior(quizSvc)
+
+			handler := quizHandler.New(testutils.NewTestLogger(), quizSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPost, "/quizzes", tc.body)
+			handler.HandleCreateQuiz(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestQuizHandler_RemoveQuiz(t *testing.T) {
+	type MockBehavior func(svc *mocks.QuizService)
+
+	testCases := []struct {
+		name           string
+		version        string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:    "success",
+			version: "2",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().RemoveQuiz(mock.Anything, 2).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"status":"success","code":200,"message":"quiz deleted"}` + "\n",
+		},
+		{
+			name:           "invalid version",
+			version:        "abc",
+			mockBehavior:   func(svc *mocks.QuizService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid version"}` + "\n",
+		},
+		{
+			name:    "not found",
+			version: "2",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().RemoveQuiz(mock.Anything, 2).Return(domain.ErrQuizNotFound).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       `{"status":"error","code":404,"message":"quiz not found"}` + "\n",
+		},
+		{
+			name:    "active",
+			version: "2",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().RemoveQuiz(mock.Anything, 2).Return(domain.ErrQuizActive).Once()
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"status":"error","code":409,"message":"quiz version is active"}` + "\n",
+		},
+		{
+			name:    "in use",
+			version: "2",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().RemoveQuiz(mock.Anything, 2).Return(domain.ErrQuizInUse).Once()
+			},
+			wantStatusCode: http.StatusConflict,
+			wantBody:       `{"status":"error","code":409,"message":"quiz version was taken by users"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quizSvc := mocks.NewQuizService(t)
+			tc.mockBehavior(quizSvc)
+
+			handler := quizHandler.New(testutils.NewTestLogger(), quizSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodDelete, "/quizzes/"+tc.version, nil)
+			req.SetPathValue("version", tc.version)
+			handler.HandleRemoveQuiz(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestQuizHandler_GetLevelStats(t *testing.T) {
+	type MockBehavior func(svc *mocks.QuizService)
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().LevelStats(mock.Anything, 30, 0).Return([]domain.LevelStats{
+					{Day: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Levels: map[domain.UserLvl]int{domain.UserLvlBeginner: 2}},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `[{"day":"2025-03-01T00:00:00Z","levels":{"beginner":2}}]` + "\n",
+		},
+		{
+			name:  "days and version",
+			query: "?days=7&version=2",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().LevelStats(mock.Anything, 7, 2).Return([]domain.LevelStats{}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `[]` + "\n",
+		},
+		{
+			name:           "invalid days",
+			query:          "?days=400",
+			mockBehavior:   func(svc *mocks.QuizService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid days"}` + "\n",
+		},
+		{
+			name:           "invalid version",
+			query:          "?version=0",
+			mockBehavior:   func(svc *mocks.QuizService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid version"}` + "\n",
+		},
+		{
+			name:  "service error",
+			query: "",
+			mockBehavior: func(svc *mocks.QuizService) {
+				svc.EXPECT().LevelStats(mock.Anything, 30, 0).Return(nil, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to get level stats"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quizSvc := mocks.NewQuizService(t)
+			tc.mockBehavior(quizSvc)
+
+			handler := quizHandler.New(testutils.NewTestLogger(), quizSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/quizzes/levels"+tc.query, nil)
+			handler.HandleGetLevelStats(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
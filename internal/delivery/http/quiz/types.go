@@ -0,0 +1,11 @@
+package quiz
+
+import "github.com/SergeyBogomolovv/fitflow/internal/domain"
+
+type CreateQuizRequest struct {
+	Questions []domain.QuizQuestion `json:"questions" validate:"required,min=1,max=20,dive"`
+	// Thresholds assign level by sum of answer weights, the highest reached threshold wins
+	Thresholds []domain.QuizThreshold `json:"thresholds" validate:"required,min=1,max=3,dive"`
+	// Activate makes new version active right away
+	Activate bool `json:"activate" example:"true"`
+}
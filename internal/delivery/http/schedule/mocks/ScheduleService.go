@@ -0,0 +1,213 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScheduleService is an autogenerated mock type for the ScheduleService type
+type ScheduleService struct {
+	mock.Mock
+}
+
+type ScheduleService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleService) EXPECT() *ScheduleService_Expecter {
+	return &ScheduleService_Expecter{mock: &_m.Mock}
+}
+
+// Schedule provides a mock function with given fields: ctx, audience, runs
+func (_m *ScheduleService) Schedule(ctx context.Context, audience domain.UserLvl, runs int) (domain.Schedule, error) {
+	ret := _m.Called(ctx, audience, runs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedule")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, int) (domain.Schedule, error)); ok {
+		return rf(ctx, audience, runs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, int) domain.Schedule); ok {
+		r0 = rf(ctx, audience, runs)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl, int) error); ok {
+		r1 = rf(ctx, audience, runs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleService_Schedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedule'
+type ScheduleService_Schedule_Call struct {
+	*mock.Call
+}
+
+// Schedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+//   - runs int
+func (_e *ScheduleService_Expecter) Schedule(ctx interface{}, audience interface{}, runs interface{}) *ScheduleService_Schedule_Call {
+	return &ScheduleService_Schedule_Call{Call: _e.mock.On("Schedule", ctx, audience, runs)}
+}
+
+func (_c *ScheduleService_Schedule_Call) Run(run func(ctx context.Context, audience domain.UserLvl, runs int)) *ScheduleService_Schedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *ScheduleService_Schedule_Call) Return(_a0 domain.Schedule, _a1 error) *ScheduleService_Schedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleService_Schedule_Call) RunAndReturn(run func(context.Context, domain.UserLvl, int) (domain.Schedule, error)) *ScheduleService_Schedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Schedules provides a mock function with given fields: ctx, runs
+func (_m *ScheduleService) Schedules(ctx context.Context, runs int) ([]domain.Schedule, error) {
+	ret := _m.Called(ctx, runs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Schedules")
+	}
+
+	var r0 []domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Schedule, error)); ok {
+		return rf(ctx, runs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Schedule); ok {
+		r0 = rf(ctx, runs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, runs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleService_Schedules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Schedules'
+type ScheduleService_Schedules_Call struct {
+	*mock.Call
+}
+
+// Schedules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runs int
+func (_e *ScheduleService_Expecter) Schedules(ctx interface{}, runs interface{}) *ScheduleService_Schedules_Call {
+	return &ScheduleService_Schedules_Call{Call: _e.mock.On("Schedules", ctx, runs)}
+}
+
+func (_c *ScheduleService_Schedules_Call) Run(run func(ctx context.Context, runs int)) *ScheduleService_Schedules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *ScheduleService_Schedules_Call) Return(_a0 []domain.Schedule, _a1 error) *ScheduleService_Schedules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleService_Schedules_Call) RunAndReturn(run func(context.Context, int) ([]domain.Schedule, error)) *ScheduleService_Schedules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSchedule provides a mock function with given fields: ctx, audience, in, runs
+func (_m *ScheduleService) UpdateSchedule(ctx context.Context, audience domain.UserLvl, in domain.UpdateScheduleDTO, runs int) (domain.Schedule, error) {
+	ret := _m.Called(ctx, audience, in, runs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSchedule")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, domain.UpdateScheduleDTO, int) (domain.Schedule, error)); ok {
+		return rf(ctx, audience, in, runs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, domain.UpdateScheduleDTO, int) domain.Schedule); ok {
+		r0 = rf(ctx, audience, in, runs)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl, domain.UpdateScheduleDTO, int) error); ok {
+		r1 = rf(ctx, audience, in, runs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleService_UpdateSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSchedule'
+type ScheduleService_UpdateSchedule_Call struct {
+	*mock.Call
+}
+
+// UpdateSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+//   - in domain.UpdateScheduleDTO
+//   - runs int
+func (_e *ScheduleService_Expecter) UpdateSchedule(ctx interface{}, audience interface{}, in interface{}, runs interface{}) *ScheduleService_UpdateSchedule_Call {
+	return &ScheduleService_UpdateSchedule_Call{Call: _e.mock.On("UpdateSchedule", ctx, audience, in, runs)}
+}
+
+func (_c *ScheduleService_UpdateSchedule_Call) Run(run func(ctx context.Context, audience domain.UserLvl, in domain.UpdateScheduleDTO, runs int)) *ScheduleService_UpdateSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl), args[2].(domain.UpdateScheduleDTO), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *ScheduleService_UpdateSchedule_Call) Return(_a0 domain.Schedule, _a1 error) *ScheduleService_UpdateSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleService_UpdateSchedule_Call) RunAndReturn(run func(context.Context, domain.UserLvl, domain.UpdateScheduleDTO, int) (domain.Schedule, error)) *ScheduleService_UpdateSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleService creates a new instance of ScheduleService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleService {
+	mock := &ScheduleService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
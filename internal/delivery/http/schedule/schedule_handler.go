@@ -0,0 +1,167 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+	"github.com/go-playground/validator/v10"
+)
+
+type ScheduleService interface {
+	Schedules(ctx context.Context, runs int) ([]domain.Schedule, error)
+	Schedule(ctx context.Context, audience domain.UserLvl, runs int) (domain.Schedule, error)
+	UpdateSchedule(ctx context.Context, audience domain.UserLvl, in domain.UpdateScheduleDTO, runs int) (domain.Schedule, error)
+}
+
+const (
+	defaultRuns = 5
+	maxRuns     = 50
+)
+
+type handler struct {
+	logger      *slog.Logger
+	validate    *validator.Validate
+	scheduleSvc ScheduleService
+}
+
+func New(logger *slog.Logger, scheduleSvc ScheduleService) *handler {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &handler{logger, validate, scheduleSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /schedules", h.HandleGetSchedules)
+	router.HandleFunc("GET /schedules/{audience}", h.HandleGetSchedule)
+	router.HandleFunc("PUT /schedules/{audience}", h.HandleUpdateSchedule)
+	r.Handle("/schedules", auth(router))
+	r.Handle("/schedules/", auth(router))
+}
+
+// @Summary      Расписания рассылки
+// @Description  Расписание задается для каждой аудитории, аудитория default рассылается всем подписчикам.
+// @Description  Время следующих запусков указано в часовом поясе сервера.
+// @Tags         schedules
+// @Produce      json
+// @Param        runs  query     int  false  "Количество следующих запусков (0-50)" default(5)
+// @Success      200   {array}   domain.Schedule
+// @Failure      400   {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      500   {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /schedules [get]
+func (h *handler) HandleGetSchedules(w http.ResponseWriter, r *http.Request) {
+	runs, err := parseRuns(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid runs", http.StatusBadRequest)
+		return
+	}
+
+	schedules, err := h.scheduleSvc.Schedules(r.Context(), runs)
+	if err != nil {
+		httpx.WriteError(w, "failed to get schedules", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, schedules, http.StatusOK)
+}
+
+// @Summary      Расписание рассылки аудитории
+// @Tags         schedules
+// @Produce      json
+// @Param        audience  path      string  true   "Аудитория"  Enums(default, beginner, intermediate, advanced)
+// @Param        runs      query     int     false  "Количество следующих запусков (0-50)" default(5)
+// @Success      200       {object}  domain.Schedule
+// @Failure      400       {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      404       {object}  httpx.Response  "Расписание не найдено"
+// @Failure      500       {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /schedules/{audience} [get]
+func (h *handler) HandleGetSchedule(w http.ResponseWriter, r *http.Request) {
+	audience := domain.UserLvl(r.PathValue("audience"))
+	if !audience.Valid() {
+		httpx.WriteError(w, "invalid audience", http.StatusBadRequest)
+		return
+	}
+	runs, err := parseRuns(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid runs", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.scheduleSvc.Schedule(r.Context(), audience, runs)
+	if err != nil {
+		if errors.Is(err, domain.ErrScheduleNotFound) {
+			httpx.WriteError(w, "schedule not found", http.StatusNotFound)
+			return
+		}
+		httpx.WriteError(w, "failed to get schedule", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, schedule, http.StatusOK)
+}
+
+// @Summary      Изменение расписания рассылки аудитории
+// @Description  Cron выражение содержит поле секунд, поддерживаются дескрипторы вида @daily.
+// @Description  Бот применяет новое расписание без перезапуска в течение интервала перезагрузки.
+// @Tags         schedules
+// @Accept       json
+// @Produce      json
+// @Param        audience  path      string                 true   "Аудитория"  Enums(default, beginner, intermediate, advanced)
+// @Param        runs      query     int                    false  "Количество следующих запусков (0-50)" default(5)
+// @Param        input     body      UpdateScheduleRequest  true   "Расписание"
+// @Success      200       {object}  domain.Schedule
+// @Failure      400       {object}  httpx.Response  "Неверные данные в запросе или некорректное cron выражение"
+// @Failure      500       {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /schedules/{audience} [put]
+func (h *handler) HandleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	audience := domain.UserLvl(r.PathValue("audience"))
+	if !audience.Valid() {
+		httpx.WriteError(w, "invalid audience", http.StatusBadRequest)
+		return
+	}
+	runs, err := parseRuns(r)
+	if err != nil {
+		httpx.WriteError(w, "invalid runs", http.StatusBadRequest)
+		return
+	}
+	var req UpdateScheduleRequest
+	if err := httpx.DecodeBody(r, &req); err != nil {
+		httpx.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		httpx.WriteError(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.scheduleSvc.UpdateSchedule(r.Context(), audience, domain.UpdateScheduleDTO{
+		Spec:    req.Spec,
+		Enabled: *req.Enabled,
+	}, runs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSchedule) {
+			httpx.WriteError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		httpx.WriteError(w, "failed to update schedule", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, schedule, http.StatusOK)
+}
+
+func parseRuns(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("runs")
+	if value == "" {
+		return defaultRuns, nil
+	}
+	runs, err := strconv.Atoi(value)
+	if err != nil || runs < 0 || runs > maxRuns {
+		return 0, errors.New("invalid runs")
+	}
+	return runs, nil
+}
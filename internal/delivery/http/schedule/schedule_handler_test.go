@@ -0,0 +1,118 @@
+package schedule_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	scheduleHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/schedule"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/schedule/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduleHandler_UpdateSchedule(t *testing.T) {
+	type MockBehavior func(svc *mocks.ScheduleService)
+
+	enabled := true
+	updatedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	next := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		audience       string
+		query          string
+		body           any
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:     "success",
+			audience: "beginner",
+			query:    "?runs=1",
+			body:     scheduleHandler.UpdateScheduleRequest{Spec: "0 0 10 * * *", Enabled: &enabled},
+			mockBehavior: func(svc *mocks.ScheduleService) {
+				svc.EXPECT().UpdateSchedule(mock.Anything, domain.UserLvlBeginner, domain.UpdateScheduleDTO{Spec: "0 0 10 * * *", Enabled: true}, 1).
+					Return(domain.Schedule{
+						Audience:  domain.UserLvlBeginner,
+						Spec:      "0 0 10 * * *",
+						Enabled:   true,
+						UpdatedBy: "admin",
+						UpdatedAt: updatedAt,
+						NextRuns:  []time.Time{next},
+					}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: `{"audience":"beginner","spec":"0 0 10 * * *","enabled":true,"updated_by":"admin",` +
+				`"updated_at":"2025-03-01T09:00:00Z","next_runs":["2025-03-01T10:00:00Z"]}` + "\n",
+		},
+		{
+			name:           "unknown audience",
+			audience:       "expert",
+			body:           scheduleHandler.UpdateScheduleRequest{Spec: "0 0 10 * * *", Enabled: &enabled},
+			mockBehavior:   func(svc *mocks.ScheduleService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid audience"}` + "\n",
+		},
+		{
+			name:           "invalid runs",
+			audience:       "beginner",
+			query:          "?runs=100",
+			body:           scheduleHandler.UpdateScheduleRequest{Spec: "0 0 10 * * *", Enabled: &enabled},
+			mockBehavior:   func(svc *mocks.ScheduleService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid runs"}` + "\n",
+		},
+		{
+			name:           "missing enabled",
+			audience:       "beginner",
+			body:           scheduleHandler.UpdateScheduleRequest{Spec: "0 0 10 * * *"},
+			mockBehavior:   func(svc *mocks.ScheduleService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid payload"}` + "\n",
+		},
+		{
+			name:     "invalid spec",
+			audience: "default",
+			body:     scheduleHandler.UpdateScheduleRequest{Spec: "0 10 * * *", Enabled: &enabled},
+			mockBehavior: func(svc *mocks.ScheduleService) {
+				svc.EXPECT().UpdateSchedule(mock.Anything, domain.UserLvlDefault, mock.Anything, 5).
+					Return(domain.Schedule{}, fmt.Errorf("%w: expected exactly 6 fields, found 5: [0 10 * * *]", domain.ErrInvalidSchedule)).Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid schedule: expected exactly 6 fields, found 5: [0 10 * * *]"}` + "\n",
+		},
+		{
+			name:     "error",
+			audience: "default",
+			body:     scheduleHandler.UpdateScheduleRequest{Spec: "@daily", Enabled: &enabled},
+			mockBehavior: func(svc *mocks.ScheduleService) {
+				svc.EXPECT().UpdateSchedule(mock.Anything, domain.UserLvlDefault, mock.Anything, 5).Return(domain.Schedule{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to update schedule"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheduleSvc := mocks.NewScheduleService(t)
+			tc.mockBehavior(scheduleSvc)
+
+			handler := scheduleHandler.New(testutils.NewTestLogger(), scheduleSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodPut, "/schedules/"+tc.audience+tc.query, tc.body)
+			req.SetPathValue("audience", tc.audience)
+			handler.HandleUpdateSchedule(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package schedule
+
+type UpdateScheduleRequest struct {
+	// Spec is cron expression with seconds field, descriptors like @daily are supported
+	Spec    string `json:"spec" validate:"required,max=100" example:"0 0 10 * * *"`
+	Enabled *bool  `json:"enabled" validate:"required" example:"true"`
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UsageService is an autogenerated mock type for the UsageService type
+type UsageService struct {
+	mock.Mock
+}
+
+type UsageService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UsageService) EXPECT() *UsageService_Expecter {
+	return &UsageService_Expecter{mock: &_m.Mock}
+}
+
+// Report provides a mock function with given fields: ctx, admin, days
+func (_m *UsageService) Report(ctx context.Context, admin string, days int) (domain.UsageReport, error) {
+	ret := _m.Called(ctx, admin, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Report")
+	}
+
+	var r0 domain.UsageReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (domain.UsageReport, error)); ok {
+		return rf(ctx, admin, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) domain.UsageReport); ok {
+		r0 = rf(ctx, admin, days)
+	} else {
+		r0 = ret.Get(0).(domain.UsageReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, admin, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsageService_Report_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Report'
+type UsageService_Report_Call struct {
+	*mock.Call
+}
+
+// Report is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - days int
+func (_e *UsageService_Expecter) Report(ctx interface{}, admin interface{}, days interface{}) *UsageService_Report_Call {
+	return &UsageService_Report_Call{Call: _e.mock.On("Report", ctx, admin, days)}
+}
+
+func (_c *UsageService_Report_Call) Run(run func(ctx context.Context, admin string, days int)) *UsageService_Report_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *UsageService_Report_Call) Return(_a0 domain.UsageReport, _a1 error) *UsageService_Report_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsageService_Report_Call) RunAndReturn(run func(context.Context, string, int) (domain.UsageReport, error)) *UsageService_Report_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUsageService creates a new instance of UsageService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsageService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsageService {
+	mock := &UsageService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+package usage
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/SergeyBogomolovv/fitflow/pkg/httpx"
+)
+
+const maxHistoryDays = 90
+
+type UsageService interface {
+	Report(ctx context.Context, admin string, days int) (domain.UsageReport, error)
+}
+
+type handler struct {
+	logger   *slog.Logger
+	usageSvc UsageService
+}
+
+func New(logger *slog.Logger, usageSvc UsageService) *handler {
+	return &handler{logger, usageSvc}
+}
+
+func (h *handler) Init(r *http.ServeMux, auth httpx.Middleware) {
+	r.Handle("GET /usage", auth(http.HandlerFunc(h.HandleGetUsage)))
+}
+
+// @Summary      Использование AI
+// @Description  Возвращает расход токенов и запросов к модели текущим администратором за сегодня, остаток дневной квоты и историю по дням.
+// @Description  Дни считаются по UTC, null в остатке квоты означает отсутствие ограничения.
+// @Tags         usage
+// @Produce      json
+// @Param        days  query     int  false  "Количество дней истории (1-90)" default(7)
+// @Success      200   {object}  domain.UsageReport
+// @Failure      400   {object}  httpx.Response  "Неверный формат запроса"
+// @Failure      500   {object}  httpx.Response  "Внутренняя ошибка сервера"
+// @Router       /usage [get]
+func (h *handler) HandleGetUsage(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxHistoryDays {
+			httpx.WriteError(w, "invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.usageSvc.Report(r.Context(), auth.AdminLogin(r.Context()), days)
+	if err != nil {
+		httpx.WriteError(w, "failed to get usage", http.StatusInternalServerError)
+		return
+	}
+
+	httpx.WriteJSON(w, report, http.StatusOK)
+}
@@ -0,0 +1,89 @@
+package usage_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	usageHandler "github.com/SergeyBogomolovv/fitflow/internal/delivery/http/usage"
+	"github.com/SergeyBogomolovv/fitflow/internal/delivery/http/usage/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsageHandler_GetUsage(t *testing.T) {
+	type MockBehavior func(svc *mocks.UsageService)
+
+	day := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	remaining := int64(800)
+
+	testCases := []struct {
+		name           string
+		query          string
+		mockBehavior   MockBehavior
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "success with defaults",
+			mockBehavior: func(svc *mocks.UsageService) {
+				today := domain.Usage{Day: day, Requests: 2, TokenUsage: domain.TokenUsage{PromptTokens: 150, CompletionTokens: 50, TotalTokens: 200}}
+				svc.EXPECT().Report(mock.Anything, "admin", 7).Return(domain.UsageReport{
+					Admin:     "admin",
+					Today:     today,
+					Quota:     domain.Quota{Tokens: 1000},
+					Remaining: domain.Remaining{Tokens: &remaining},
+					History:   []domain.Usage{today},
+				}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"admin":"admin","today":{"day":"2025-03-01T00:00:00Z","requests":2,"prompt_tokens":150,"completion_tokens":50,"total_tokens":200},"quota":{"tokens":1000,"requests":0},"remaining":{"tokens":800,"requests":null},"history":[{"day":"2025-03-01T00:00:00Z","requests":2,"prompt_tokens":150,"completion_tokens":50,"total_tokens":200}]}` + "\n",
+		},
+		{
+			name:  "custom days",
+			query: "?days=30",
+			mockBehavior: func(svc *mocks.UsageService) {
+				svc.EXPECT().Report(mock.Anything, "admin", 30).Return(domain.UsageReport{Admin: "admin", Today: domain.Usage{Day: day}, History: []domain.Usage{}}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"admin":"admin","today":{"day":"2025-03-01T00:00:00Z","requests":0,"prompt_tokens":0,"completion_tokens":0,"total_tokens":0},"quota":{"tokens":0,"requests":0},"remaining":{"tokens":null,"requests":null},"history":[]}` + "\n",
+		},
+		{
+			name:           "invalid days",
+			query:          "?days=365",
+			mockBehavior:   func(svc *mocks.UsageService) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       `{"status":"error","code":400,"message":"invalid days"}` + "\n",
+		},
+		{
+			name: "error",
+			mockBehavior: func(svc *mocks.UsageService) {
+				svc.EXPECT().Report(mock.Anything, "admin", 7).Return(domain.UsageReport{}, assert.AnError).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       `{"status":"error","code":500,"message":"failed to get usage"}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usageSvc := mocks.NewUsageService(t)
+			tc.mockBehavior(usageSvc)
+
+			handler := usageHandler.New(testutils.NewTestLogger(), usageSvc)
+
+			rec := httptest.NewRecorder()
+			req := testutils.NewJSONRequest(t, http.MethodGet, "/usage"+tc.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), auth.AdminLoginKey{}, "admin"))
+			handler.HandleGetUsage(rec, req)
+
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	tele "gopkg.in/telebot.v4"
+)
+
+// handleAsk answers question from command payload, without payload it enters ask mode
+func (h *handler) handleAsk(c tele.Context) error {
+	userID := c.Sender().ID
+
+	if err := h.users.EnsureUserExists(context.TODO(), userID, c.Sender().LanguageCode); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+
+	if question := c.Message().Payload; question != "" {
+		return h.answerQuestion(c, question)
+	}
+	if err := h.setState(userID, &AskState{}); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return c.Send(askMessage, defaultKeyboard)
+}
+
+func (h *handler) answerQuestion(c tele.Context, question string) error {
+	userID := c.Sender().ID
+	c.Notify(tele.Typing)
+
+	answer, err := h.assistant.Ask(context.TODO(), userID, question)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptyQuestion):
+			return c.Send(askMessage)
+		case errors.Is(err, domain.ErrAskLimitExceeded):
+			return c.Send(askLimitMessage)
+		case errors.Is(err, ai.ErrRateLimited), errors.Is(err, ai.ErrUnavailable):
+			return c.Send(askUnavailableMessage)
+		default:
+			return c.Send("Произошла непредвиденная ошибка.")
+		}
+	}
+
+	// answer is sent as plain text, model markdown is not guaranteed to be valid for telegram
+	text := answer.Text + "\n\n" + askDisclaimer
+	if answer.Remaining >= 0 {
+		text += fmt.Sprintf("\n\nОсталось вопросов на сегодня: %d", answer.Remaining)
+	}
+	return c.Send(text)
+}
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	tele "gopkg.in/telebot.v4"
 )
 
@@ -11,11 +12,19 @@ const (
 	cmdUnsubscribe = "/unsubscribe"
 	cmdTest        = "/test"
 	cmdCancel      = "/cancel"
+	cmdAsk         = "/ask"
+	cmdPlan        = "/plan"
+	cmdMyPlan      = "/myplan"
+	cmdReplan      = "/replan"
+	cmdSettings    = "/settings"
 )
 
 const (
 	startMessage = "💥*Дорогой пользователь*, мы рады что вы решили менять свою жизнь выбирая работу над собой, мы же в свою очередь поможем вам с этим 🏋🏿‍♂️\n\n" +
 		"📝 Пройди тест для определения уровня - /test\n\n" +
+		"💬 Задай вопрос о тренировках и питании - /ask\n\n" +
+		"🗓 Получи персональный план тренировок - /plan\n\n" +
+		"⚙️ Выбери уровень и частоту рассылки вручную - /settings\n\n" +
 		"📢 Иногда будем делиться крутыми предложениями.\n\n" +
 		"🔔 Чтобы получать наши посты, нажми 👉 /subscribe\n" +
 		"❌ Чтобы отписаться в любой момент – /unsubscribe"
@@ -24,36 +33,63 @@ const (
 		"Этот бот создан для тех, кто хочет развиваться, становиться сильнее и здоровее! 💪\n\n" +
 		"💡 Мы делимся полезными советами по тренировкам, питанию и восстановлению.\n" +
 		"📊 Ты можешь пройти /test и получать рекомендации по уровню подготовки.\n" +
+		"⚙️ Уровень, подписку и частоту рассылки можно изменить в /settings\n" +
+		"💬 Ассистент ответит на вопросы о тренировках и питании — /ask\n" +
+		"🗓 Персональный план тренировок на неделю — /plan, посмотреть его снова — /myplan\n" +
 		"🔥 Иногда мы предлагаем крутые бонусы и акции.\n\n" +
 		"Будь в форме — оставайся с нами! 🚀\n\n" +
 		"📢 Подписаться на обновления — /subscribe\n" +
 		"❌ Отписаться в любой момент — /unsubscribe"
 
-	unknownMessage = "Команда не распознана. Если хотите пройти тест, используйте - /test"
+	unknownMessage = "Команда не распознана. Если хотите пройти тест, используйте - /test\n" +
+		"Чтобы задать вопрос ассистенту, используйте - /ask"
+
+	askMessage = "💬 Задайте вопрос о тренировках, питании или восстановлении.\n" +
+		"Ассистент помнит предыдущие вопросы и учитывает ваш уровень подготовки.\n\n" +
+		"Чтобы выйти из режима вопросов, используйте - /cancel"
+
+	askDisclaimer = "⚠️ Ответ сгенерирован AI и не заменяет консультацию врача или тренера. " +
+		"При боли, травмах и хронических заболеваниях обратитесь к специалисту."
+
+	askLimitMessage        = "Лимит вопросов на сегодня исчерпан, возвращайтесь завтра."
+	planGeneratingMessage  = "⏳ Составляю план тренировок, это займет до минуты..."
+	planHintMessage        = "Чтобы изменить план, используйте /replan и опишите, что поменять, например: /replan меньше бега, больше упражнений на спину"
+	noPlanMessage          = "У вас пока нет плана тренировок. Составьте его - /plan"
+	replanMessage          = "Опишите, что изменить в плане. Чтобы выйти, используйте - /cancel"
+	planLimitMessage       = "Лимит планов на сегодня исчерпан, возвращайтесь завтра."
+	noInjuriesAnswer       = "Нет травм"
+	askUnavailableMessage  = "Ассистент временно недоступен, попробуйте позже."
+	cancelledMessage       = "Действие отменено."
+	testUnavailableMessage = "Тест временно недоступен, попробуйте позже."
+	flowExpiredMessage     = "Время ожидания ответа истекло, начните заново."
+	staleAnswerMessage     = "Этот вопрос уже неактуален."
+	flowBackText           = "⬅️ Назад"
+	flowCancelText         = "❌ Отмена"
 )
 
-type Question struct {
-	Question string
-	Answers  map[string]int
+var levelNames = map[domain.UserLvl]string{
+	domain.UserLvlDefault:      "Не определен",
+	domain.UserLvlBeginner:     "Новичок",
+	domain.UserLvlIntermediate: "Средний",
+	domain.UserLvlAdvanced:     "Продвинутый",
+}
+
+type option[T any] struct {
+	Text  string
+	Value T
+}
+
+var goalOptions = []option[domain.WorkoutGoal]{
+	{"Снизить вес", domain.WorkoutGoalLoseWeight},
+	{"Набрать мышечную массу", domain.WorkoutGoalBuildMuscle},
+	{"Развить выносливость", domain.WorkoutGoalEndurance},
+	{"Здоровье и тонус", domain.WorkoutGoalHealth},
 }
 
-var questions = []Question{
-	{
-		Question: "Как часто вы тренируетесь в зале?",
-		Answers:  map[string]int{"1 Раз в неделю": 1, "2 Раза в неделю": 2, "3 и более раз в неделю": 3},
-	},
-	{
-		Question: "Сколько лет вы занимаетесь силовыми тренировками?",
-		Answers:  map[string]int{"Меньше 6 месяцев": 1, "1-2 Года": 2, "3 и более лет": 3},
-	},
-	{
-		Question: "Какие веса используете в базовых упражнениях?",
-		Answers: map[string]int{
-			"Только с собственным весом или легкими гантелями": 1,
-			"Средние веса (50-70% от собственного веса)":       2,
-			"Тяжелые веса (больше 100% собственного веса)":     3,
-		},
-	},
+var equipmentOptions = []option[domain.Equipment]{
+	{"Без инвентаря", domain.EquipmentNone},
+	{"Дома: гантели, резинки, турник", domain.EquipmentHome},
+	{"Тренажерный зал", domain.EquipmentGym},
 }
 
 var (
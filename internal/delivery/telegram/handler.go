@@ -3,35 +3,104 @@ package telegram
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/flow"
 	"github.com/SergeyBogomolovv/fitflow/pkg/state"
 	tele "gopkg.in/telebot.v4"
 )
 
 type UserService interface {
-	EnsureUserExists(ctx context.Context, id int64) error
+	EnsureUserExists(ctx context.Context, id int64, languageCode string) error
 	UpdateSubscribed(ctx context.Context, id int64, subscribed bool) error
 	UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserLvl) error
+	UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error
+	UpdateTimeZone(ctx context.Context, id int64, timeZone string) error
+	UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error
+	User(ctx context.Context, id int64) (domain.User, error)
 	SubscribersIds(ctx context.Context, lvl domain.UserLvl) ([]int64, error)
 }
 
+type AssistantService interface {
+	Ask(ctx context.Context, userID int64, question string) (domain.AssistantAnswer, error)
+}
+
+type WorkoutService interface {
+	CreatePlan(ctx context.Context, userID int64, params domain.WorkoutParams) (domain.WorkoutPlan, error)
+	AdjustPlan(ctx context.Context, userID int64, adjustments string) (domain.WorkoutPlan, error)
+	LatestPlan(ctx context.Context, userID int64) (domain.WorkoutPlan, error)
+}
+
+type QuizService interface {
+	ActiveQuiz(ctx context.Context) (domain.Quiz, error)
+	Quiz(ctx context.Context, version int) (domain.Quiz, error)
+	CompleteQuiz(ctx context.Context, userID int64, version int, answers []int) (domain.QuizResult, error)
+	CheckCooldown(ctx context.Context, userID int64) error
+}
+
 type PostService interface {
 	PickLatest(ctx context.Context, audience domain.UserLvl) (domain.Post, error)
+	PickEvergreen(ctx context.Context, audience domain.UserLvl) (domain.Post, error)
 	MarkAsPosted(ctx context.Context, id int64) error
+	Post(ctx context.Context, id int64) (domain.Post, error)
+}
+
+type BroadcastService interface {
+	StartRun(ctx context.Context, audience domain.UserLvl) (int64, error)
+	FinishRun(ctx context.Context, id int64, res domain.BroadcastResult) error
+}
+
+type ScheduleService interface {
+	Schedules(ctx context.Context, runs int) ([]domain.Schedule, error)
+}
+
+type DeliveryService interface {
+	Enqueue(ctx context.Context, runID, postID int64, userIDs []int64) error
+	ExcludeRecent(ctx context.Context, postID int64, userIDs []int64, now time.Time) ([]int64, error)
+	Due(ctx context.Context, now time.Time) ([]domain.Delivery, error)
+	Complete(ctx context.Context, id int64, sendErr error) error
 }
 
 type handler struct {
-	logger *slog.Logger
-	bot    *tele.Bot
-	users  UserService
-	posts  PostService
-	state  state.State
+	logger     *slog.Logger
+	bot        *tele.Bot
+	users      UserService
+	posts      PostService
+	assistant  AssistantService
+	workouts   WorkoutService
+	quizzes    QuizService
+	deliveries DeliveryService
+	schedules  ScheduleService
+	broadcasts BroadcastService
+	state      state.State
+	flows      *flow.Engine
 }
 
-func New(logger *slog.Logger, bot *tele.Bot, posts PostService, users UserService) *handler {
-	state := state.NewState()
-	return &handler{logger, bot, users, posts, state}
+func New(logger *slog.Logger, bot *tele.Bot, state state.State, posts PostService, users UserService, assistant AssistantService, workouts WorkoutService, quizzes QuizService, deliveries DeliveryService, schedules ScheduleService, broadcasts BroadcastService) *handler {
+	h := &handler{
+		logger:     logger,
+		bot:        bot,
+		users:      users,
+		posts:      posts,
+		assistant:  assistant,
+		workouts:   workouts,
+		quizzes:    quizzes,
+		deliveries: deliveries,
+		schedules:  schedules,
+		broadcasts: broadcasts,
+		state:      state,
+	}
+	h.flows = flow.NewEngine(logger, state, flow.Options{
+		BackText:   flowBackText,
+		CancelText: flowCancelText,
+		Cancelled:  cancelledMessage,
+		Expired:    flowExpiredMessage,
+		Keyboard:   defaultKeyboard,
+		Stale:      staleAnswerMessage,
+		Load:       h.loadFlow,
+	})
+	return h
 }
 
 func (h *handler) Init() {
@@ -40,14 +109,21 @@ func (h *handler) Init() {
 	h.bot.Handle(cmdSubscribe, h.handleSubscribe)
 	h.bot.Handle(cmdUnsubscribe, h.handleUnsubscribe)
 	h.bot.Handle(cmdTest, h.handleStartTest)
+	h.bot.Handle(cmdAsk, h.handleAsk)
+	h.bot.Handle(cmdPlan, h.handleStartPlan)
+	h.bot.Handle(cmdMyPlan, h.handleMyPlan)
+	h.bot.Handle(cmdReplan, h.handleReplan)
+	h.bot.Handle(cmdSettings, h.handleSettings)
+	h.bot.Handle(settingsCallback, h.handleSettingsCallback)
 	h.bot.Handle(tele.OnText, h.handleText)
+	h.bot.Handle(flow.Callback, h.handleFlowCallback)
 	h.bot.Handle(cmdCancel, h.handleCancel)
 }
 
 func (h *handler) handleStart(c tele.Context) error {
 	userID := c.Sender().ID
 
-	if err := h.users.EnsureUserExists(context.TODO(), userID); err != nil {
+	if err := h.users.EnsureUserExists(context.TODO(), userID, c.Sender().LanguageCode); err != nil {
 		return c.Send("Произошла непредвиденная ошибка.")
 	}
 	return c.Send(startMessage, defaultKeyboard, tele.ModeMarkdown)
@@ -59,16 +135,59 @@ func (h *handler) handleAbout(c tele.Context) error {
 
 func (h *handler) handleText(c tele.Context) error {
 	userID := c.Sender().ID
-	state := h.state.Get(userID)
+	state := h.getState(userID)
 	switch state := state.(type) {
-	case *UserTestState:
-		return h.handleTestAnswer(c, state)
+	case *flow.Session:
+		if err := h.flows.Handle(c, state); err != nil {
+			h.logger.Error("failed to handle flow", "flow", state.Flow, "user_id", userID, "error", err)
+			return c.Send("Произошла непредвиденная ошибка.", defaultKeyboard)
+		}
+		return nil
+	case *WorkoutPlanState:
+		return h.handleWorkoutAnswer(c, state)
+	case *WorkoutAdjustState:
+		h.deleteState(userID)
+		return h.adjustPlan(c, c.Text())
+	case *AskState:
+		return h.answerQuestion(c, c.Text())
 	default:
 		return c.Send(unknownMessage)
 	}
 }
 
 func (h *handler) handleCancel(c tele.Context) error {
-	h.state.Delete(c.Sender().ID)
-	return c.Send("Действие отменено.", defaultKeyboard)
+	h.deleteState(c.Sender().ID)
+	return c.Send(cancelledMessage, defaultKeyboard)
+}
+
+func (h *handler) handleFlowCallback(c tele.Context) error {
+	if err := h.flows.HandleCallback(c); err != nil {
+		h.logger.Error("failed to handle flow callback", "user_id", c.Sender().ID, "error", err)
+		return c.Send("Произошла непредвиденная ошибка.", defaultKeyboard)
+	}
+	return nil
+}
+
+// getState treats broken state as absent, so user can start over
+func (h *handler) getState(userID int64) any {
+	state, err := h.state.Get(context.TODO(), userID)
+	if err != nil {
+		h.logger.Error("failed to get state", "user_id", userID, "error", err)
+		return nil
+	}
+	return state
+}
+
+func (h *handler) setState(userID int64, state any) error {
+	if err := h.state.Set(context.TODO(), userID, state); err != nil {
+		h.logger.Error("failed to set state", "user_id", userID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) deleteState(userID int64) {
+	if err := h.state.Delete(context.TODO(), userID); err != nil {
+		h.logger.Error("failed to delete state", "user_id", userID, "error", err)
+	}
 }
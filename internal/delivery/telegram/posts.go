@@ -2,76 +2,211 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	"github.com/robfig/cron/v3"
 	tele "gopkg.in/telebot.v4"
 )
 
-func (h *handler) RunScheduler(ctx context.Context, broadcastSpec, levelSpec string) {
+// scheduledJob is cron entry of audience schedule
+type scheduledJob struct {
+	spec string
+	id   cron.EntryID
+}
+
+// RunScheduler queues posts by audience schedules until ctx is done, running jobs are waited for.
+// Schedules are reloaded from database every reloadInterval, so changes apply without restart.
+func (h *handler) RunScheduler(ctx context.Context, reloadInterval time.Duration) {
 	const op = "telegram.RunScheduler"
 	logger := h.logger.With(slog.String("op", op))
-	logger.Info("starting posts scheduler")
+	logger.Info("starting posts scheduler", "reload_interval", reloadInterval)
 
-	cron := cron.New(cron.WithSeconds())
+	c := cron.New(cron.WithSeconds())
+	jobs := make(map[domain.UserLvl]scheduledJob)
+	h.reloadSchedules(ctx, c, jobs)
+	c.Start()
 
-	broadcastID, err := cron.AddFunc(broadcastSpec, func() {
-		h.notifySubscribers(ctx, domain.UserLvlDefault)
-	})
-	if err != nil {
-		logger.Error("failed to add cron job", "error", err, "id", broadcastID)
-		return
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			<-c.Stop().Done()
+			logger.Info("posts scheduler stopped")
+			return
+		case <-ticker.C:
+			h.reloadSchedules(ctx, c, jobs)
+		}
 	}
+}
 
-	lvlID, err := cron.AddFunc(levelSpec, func() {
-		h.notifySubscribers(ctx, domain.UserLvlBeginner)
-		h.notifySubscribers(ctx, domain.UserLvlIntermediate)
-		h.notifySubscribers(ctx, domain.UserLvlAdvanced)
-	})
+// reloadSchedules applies changed schedules to cron, on error current jobs are kept
+func (h *handler) reloadSchedules(ctx context.Context, c *cron.Cron, jobs map[domain.UserLvl]scheduledJob) {
+	const op = "telegram.reloadSchedules"
+	logger := h.logger.With(slog.String("op", op))
+
+	schedules, err := h.schedules.Schedules(ctx, 0)
 	if err != nil {
-		logger.Error("failed to add cron job", "error", err, "id", lvlID)
 		return
 	}
 
-	cron.Start()
+	wanted := make(map[domain.UserLvl]string, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.Enabled {
+			wanted[schedule.Audience] = schedule.Spec
+		}
+	}
+
+	for audience, job := range jobs {
+		if spec, ok := wanted[audience]; !ok || spec != job.spec {
+			c.Remove(job.id)
+			delete(jobs, audience)
+			if !ok {
+				logger.Info("schedule disabled", "audience", audience)
+			}
+		}
+	}
+	for audience, spec := range wanted {
+		if _, ok := jobs[audience]; ok {
+			continue
+		}
+		id, err := c.AddFunc(spec, func() {
+			h.notifySubscribers(ctx, audience)
+		})
+		if err != nil {
+			logger.Error("failed to add cron job", "audience", audience, "spec", spec, "error", err)
+			continue
+		}
+		jobs[audience] = scheduledJob{spec: spec, id: id}
+		logger.Info("schedule applied", "audience", audience, "spec", spec)
+	}
 }
 
+// notifySubscribers runs broadcast to audience and records it in broadcast history.
+// Broadcast is not skipped when history is not available, its deliveries are not linked to a run then.
 func (h *handler) notifySubscribers(ctx context.Context, lvl domain.UserLvl) {
 	const op = "telegram.notifySubscribers"
-	logger := h.logger.With(slog.String("op", op))
+	logger := h.logger.With(slog.String("op", op), slog.String("audience", string(lvl)))
 
-	post, err := h.posts.PickLatest(ctx, lvl)
+	runID, err := h.broadcasts.StartRun(ctx, lvl)
 	if err != nil {
+		logger.Warn("broadcast is not recorded in history", "error", err)
+	}
+	res := h.broadcast(ctx, runID, lvl)
+	if runID == 0 {
+		logger.Info("broadcast finished without history", "outcome", res.Outcome, "post_id", res.PostID, "recipients", res.Recipients, "error", res.Err)
 		return
 	}
+	// result is recorded even if scheduler is stopping in the middle of run
+	h.broadcasts.FinishRun(context.WithoutCancel(ctx), runID, res)
+}
+
+func (h *handler) broadcast(ctx context.Context, runID int64, lvl domain.UserLvl) domain.BroadcastResult {
+	post, err := h.posts.PickLatest(ctx, lvl)
+	evergreen := errors.Is(err, domain.ErrNoPosts)
+	if evergreen {
+		// queue is empty, evergreen post is sent again instead of skipping the run
+		post, err = h.posts.PickEvergreen(ctx, lvl)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNoPosts) {
+			return domain.BroadcastResult{Outcome: domain.BroadcastOutcomeNoPosts}
+		}
+		return domain.BroadcastResult{Outcome: domain.BroadcastOutcomeFailed, Err: err}
+	}
+	res := domain.BroadcastResult{PostID: post.ID, Evergreen: evergreen}
+
 	subscribers, err := h.users.SubscribersIds(ctx, lvl)
+	if err == nil && evergreen && len(subscribers) > 0 {
+		// users who got this post recently are skipped, e.g. they changed level or it was sent from queue
+		subscribers, err = h.deliveries.ExcludeRecent(ctx, post.ID, subscribers, time.Now())
+	}
 	if err != nil {
+		res.Outcome, res.Err = domain.BroadcastOutcomeFailed, err
+		return res
+	}
+	if len(subscribers) == 0 {
+		if evergreen {
+			// rotation moves on, otherwise the same post would be picked every run
+			h.posts.MarkAsPosted(ctx, post.ID)
+		}
+		res.Outcome = domain.BroadcastOutcomeNoSubscribers
+		return res
+	}
+	// post is sent by dispatcher when delivery window of subscriber opens
+	if err := h.deliveries.Enqueue(ctx, runID, post.ID, subscribers); err != nil {
+		res.Outcome, res.Err = domain.BroadcastOutcomeFailed, err
+		return res
+	}
+	h.posts.MarkAsPosted(ctx, post.ID)
+	res.Recipients, res.Outcome = len(subscribers), domain.BroadcastOutcomeSent
+	return res
+}
+
+// RunDispatcher sends queued posts to subscribers whose delivery window is open, until ctx is done
+func (h *handler) RunDispatcher(ctx context.Context, interval time.Duration) {
+	const op = "telegram.RunDispatcher"
+	logger := h.logger.With(slog.String("op", op))
+	logger.Info("starting posts dispatcher", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.dispatch(ctx)
+		}
+	}
+}
+
+func (h *handler) dispatch(ctx context.Context) {
+	const op = "telegram.dispatch"
+	logger := h.logger.With(slog.String("op", op))
+
+	due, err := h.deliveries.Due(ctx, time.Now())
+	if err != nil || len(due) == 0 {
 		return
 	}
-	if count := h.sendPost(subscribers, post); count > 0 {
-		h.posts.MarkAsPosted(ctx, post.ID)
-		logger.Info("notified subscribers", "count", count)
+
+	byPost := make(map[int64][]domain.Delivery)
+	for _, d := range due {
+		byPost[d.PostID] = append(byPost[d.PostID], d)
+	}
+	for postID, deliveries := range byPost {
+		post, err := h.posts.Post(ctx, postID)
+		if err != nil {
+			continue
+		}
+		sent := h.sendPost(ctx, deliveries, post)
+		logger.Info("sent post to subscribers", "post_id", postID, "count", sent, "failed", int64(len(deliveries))-sent)
 	}
 }
 
-func (h *handler) sendPost(subscribers []int64, post domain.Post) int64 {
+// sendPost returns number of subscribers who received the post
+func (h *handler) sendPost(ctx context.Context, deliveries []domain.Delivery, post domain.Post) int64 {
 	const op = "telegram.sendPost"
 	logger := h.logger.With(slog.String("op", op))
 
 	count := int64(0)
 	wg := sync.WaitGroup{}
-	for _, id := range subscribers {
+	for _, d := range deliveries {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := h.sendMessage(tele.ChatID(id), post); err != nil {
-				logger.Error("failed to send post", "subscriber_id", id, "error", err, "post", post)
+			err := h.sendMessage(tele.ChatID(d.UserID), post)
+			if err != nil {
+				logger.Error("failed to send post", "subscriber_id", d.UserID, "error", err, "post", post)
 			} else {
 				atomic.AddInt64(&count, 1)
 			}
+			h.deliveries.Complete(ctx, d.ID, err)
 		}()
 	}
 	wg.Wait()
@@ -0,0 +1,209 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	tele "gopkg.in/telebot.v4"
+)
+
+// settingsCallback is endpoint of /settings buttons, data is action and optional value
+var settingsCallback = &tele.Btn{Unique: "settings"}
+
+const (
+	settingsMenu      = "menu"
+	settingsLevel     = "level"
+	settingsSubscribe = "subscribe"
+	settingsFrequency = "frequency"
+	settingsWindow    = "window"
+	settingsTimeZone  = "timezone"
+)
+
+var levelOptions = []option[domain.UserLvl]{
+	{levelNames[domain.UserLvlBeginner], domain.UserLvlBeginner},
+	{levelNames[domain.UserLvlIntermediate], domain.UserLvlIntermediate},
+	{levelNames[domain.UserLvlAdvanced], domain.UserLvlAdvanced},
+}
+
+var frequencyOptions = []option[domain.DeliveryFrequency]{
+	{"При каждой рассылке", domain.DeliveryFrequencyAll},
+	{"Не чаще раза в день", domain.DeliveryFrequencyDaily},
+	{"Не чаще раза в неделю", domain.DeliveryFrequencyWeekly},
+}
+
+// windowOptions values are domain.DeliveryWindow in text form
+var windowOptions = []option[string]{
+	{"Утром, 8:00–12:00", "8-12"},
+	{"Днем, 12:00–17:00", "12-17"},
+	{"Вечером, 17:00–22:00", "17-22"},
+	{"В течение дня, 9:00–21:00", "9-21"},
+}
+
+var timeZoneOptions = []option[string]{
+	{"Калининград (UTC+2)", "Europe/Kaliningrad"},
+	{"Москва (UTC+3)", "Europe/Moscow"},
+	{"Самара (UTC+4)", "Europe/Samara"},
+	{"Екатеринбург (UTC+5)", "Asia/Yekaterinburg"},
+	{"Омск (UTC+6)", "Asia/Omsk"},
+	{"Новосибирск (UTC+7)", "Asia/Novosibirsk"},
+	{"Иркутск (UTC+8)", "Asia/Irkutsk"},
+	{"Якутск (UTC+9)", "Asia/Yakutsk"},
+	{"Владивосток (UTC+10)", "Asia/Vladivostok"},
+	{"Киев", "Europe/Kyiv"},
+	{"Минск (UTC+3)", "Europe/Minsk"},
+	{"Алматы (UTC+5)", "Asia/Almaty"},
+	{"Берлин", "Europe/Berlin"},
+	{"UTC", "UTC"},
+}
+
+func (h *handler) handleSettings(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := context.TODO()
+
+	if err := h.users.EnsureUserExists(ctx, userID, c.Sender().LanguageCode); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	user, err := h.users.User(ctx, userID)
+	if err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return c.Send(settingsText(user), settingsMarkup(user))
+}
+
+// handleSettingsCallback edits settings message in place, changes are saved right away
+func (h *handler) handleSettingsCallback(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := context.TODO()
+
+	user, err := h.users.User(ctx, userID)
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Произошла непредвиденная ошибка."})
+	}
+
+	action, value, _ := strings.Cut(c.Callback().Data, "|")
+	switch {
+	case action == settingsLevel && value == "":
+		c.Respond()
+		return c.Edit("🎚 Выберите уровень подготовки. Определить его по тесту можно с помощью - /test", choiceMarkup(settingsLevel, levelOptions, user.Lvl))
+	case action == settingsFrequency && value == "":
+		c.Respond()
+		return c.Edit("📬 Как часто присылать посты?", choiceMarkup(settingsFrequency, frequencyOptions, user.Frequency))
+	case action == settingsWindow && value == "":
+		c.Respond()
+		return c.Edit("🕒 В какое время присылать посты? Время указано по вашему часовому поясу.", choiceMarkup(settingsWindow, windowOptions, user.Window.String()))
+	case action == settingsTimeZone && value == "":
+		c.Respond()
+		return c.Edit("🌍 Выберите часовой пояс.", choiceMarkup(settingsTimeZone, timeZoneOptions, user.TimeZone))
+	case action == settingsLevel:
+		lvl, ok := findValue(levelOptions, domain.UserLvl(value))
+		if !ok {
+			return c.Respond(&tele.CallbackResponse{Text: staleAnswerMessage})
+		}
+		err = h.users.UpdateUserLvl(ctx, userID, lvl)
+		user.Lvl = lvl
+	case action == settingsFrequency:
+		frequency, ok := findValue(frequencyOptions, domain.DeliveryFrequency(value))
+		if !ok {
+			return c.Respond(&tele.CallbackResponse{Text: staleAnswerMessage})
+		}
+		err = h.users.UpdateFrequency(ctx, userID, frequency)
+		user.Frequency = frequency
+	case action == settingsWindow:
+		window, parseErr := domain.ParseDeliveryWindow(value)
+		if _, ok := findValue(windowOptions, value); !ok || parseErr != nil {
+			return c.Respond(&tele.CallbackResponse{Text: staleAnswerMessage})
+		}
+		err = h.users.UpdateWindow(ctx, userID, window)
+		user.Window = window
+	case action == settingsTimeZone:
+		timeZone, ok := findValue(timeZoneOptions, value)
+		if !ok {
+			return c.Respond(&tele.CallbackResponse{Text: staleAnswerMessage})
+		}
+		err = h.users.UpdateTimeZone(ctx, userID, timeZone)
+		user.TimeZone = timeZone
+	case action == settingsSubscribe:
+		err = h.users.UpdateSubscribed(ctx, userID, !user.Subscribed)
+		user.Subscribed = !user.Subscribed
+	case action == settingsMenu:
+		c.Respond()
+		return c.Edit(settingsText(user), settingsMarkup(user))
+	default:
+		return c.Respond(&tele.CallbackResponse{Text: staleAnswerMessage})
+	}
+
+	if err != nil {
+		return c.Respond(&tele.CallbackResponse{Text: "Произошла ошибка при сохранении настроек."})
+	}
+	c.Respond(&tele.CallbackResponse{Text: "Сохранено"})
+	return c.Edit(settingsText(user), settingsMarkup(user))
+}
+
+func settingsText(user domain.User) string {
+	subscription := "выключена"
+	if user.Subscribed {
+		subscription = "включена"
+	}
+	frequency, _ := findText(frequencyOptions, user.Frequency)
+	timeZone, ok := findText(timeZoneOptions, user.TimeZone)
+	if !ok {
+		timeZone = user.TimeZone
+	}
+	return fmt.Sprintf("⚙️ Настройки\n\n🎚 Уровень: %s\n🔔 Рассылка: %s\n📬 Частота: %s\n🕒 Время: %d:00–%d:00\n🌍 Часовой пояс: %s",
+		levelNames[user.Lvl], subscription, strings.ToLower(frequency), user.Window.Start, user.Window.End, timeZone)
+}
+
+func settingsMarkup(user domain.User) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	subscribe := "🔔 Подписаться на рассылку"
+	if user.Subscribed {
+		subscribe = "🔕 Отписаться от рассылки"
+	}
+	markup.Inline(
+		tele.Row{markup.Data("🎚 Изменить уровень", settingsCallback.Unique, settingsLevel)},
+		tele.Row{markup.Data(subscribe, settingsCallback.Unique, settingsSubscribe)},
+		tele.Row{markup.Data("📬 Частота рассылки", settingsCallback.Unique, settingsFrequency)},
+		tele.Row{
+			markup.Data("🕒 Время", settingsCallback.Unique, settingsWindow),
+			markup.Data("🌍 Часовой пояс", settingsCallback.Unique, settingsTimeZone),
+		},
+	)
+	return markup
+}
+
+// choiceMarkup lists options of setting with the current value marked
+func choiceMarkup[T ~string](action string, options []option[T], current T) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0, len(options)+1)
+	for _, opt := range options {
+		text := opt.Text
+		if opt.Value == current {
+			text = "✅ " + text
+		}
+		rows = append(rows, tele.Row{markup.Data(text, settingsCallback.Unique, action, string(opt.Value))})
+	}
+	rows = append(rows, tele.Row{markup.Data(flowBackText, settingsCallback.Unique, settingsMenu)})
+	markup.Inline(rows...)
+	return markup
+}
+
+func findValue[T comparable](options []option[T], value T) (T, bool) {
+	for _, opt := range options {
+		if opt.Value == value {
+			return opt.Value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func findText[T comparable](options []option[T], value T) (string, bool) {
+	for _, opt := range options {
+		if opt.Value == value {
+			return opt.Text, true
+		}
+	}
+	return "", false
+}
@@ -1,6 +1,32 @@
 package telegram
 
-type UserTestState struct {
-	CurrentQuestion int
-	Score           int
+import (
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+)
+
+// names are persisted with the state and must not change
+func init() {
+	state.Register("ask", &AskState{})
+	state.Register("workout_plan", &WorkoutPlanState{})
+	state.Register("workout_adjust", &WorkoutAdjustState{})
+}
+
+// AskState means free text is sent to assistant until /cancel
+type AskState struct{}
+
+const (
+	workoutStepGoal = iota
+	workoutStepDays
+	workoutStepEquipment
+	workoutStepInjuries
+)
+
+// WorkoutPlanState collects /plan questionnaire answers
+type WorkoutPlanState struct {
+	Step   int
+	Params domain.WorkoutParams
 }
+
+// WorkoutAdjustState means next text is wishes for plan regeneration
+type WorkoutAdjustState struct{}
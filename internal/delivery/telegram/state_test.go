@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// states are persisted between messages and restarts, so every registered type must survive encoding
+func TestState_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value any
+	}{
+		{name: "ask", value: &AskState{}},
+		{name: "workout adjust", value: &WorkoutAdjustState{}},
+		{
+			name: "workout plan",
+			value: &WorkoutPlanState{Step: workoutStepInjuries, Params: domain.WorkoutParams{
+				Goal:        domain.WorkoutGoalBuildMuscle,
+				DaysPerWeek: 3,
+				Equipment:   domain.EquipmentHome,
+				Injuries:    "колено",
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := state.NewMemory(0)
+			require.NoError(t, s.Set(context.Background(), 1, tc.value))
+
+			got, err := s.Get(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, got)
+		})
+	}
+}
@@ -11,7 +11,7 @@ func (h *handler) handleSubscribe(c tele.Context) error {
 	userID := c.Sender().ID
 
 	ctx := context.TODO()
-	if err := h.users.EnsureUserExists(ctx, userID); err != nil {
+	if err := h.users.EnsureUserExists(ctx, userID, c.Sender().LanguageCode); err != nil {
 		return c.Send(errText)
 	}
 
@@ -26,7 +26,7 @@ func (h *handler) handleUnsubscribe(c tele.Context) error {
 	userID := c.Sender().ID
 
 	ctx := context.TODO()
-	if err := h.users.EnsureUserExists(ctx, userID); err != nil {
+	if err := h.users.EnsureUserExists(ctx, userID, c.Sender().LanguageCode); err != nil {
 		return c.Send(errText)
 	}
 
@@ -2,94 +2,134 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math"
-	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/flow"
 	tele "gopkg.in/telebot.v4"
 )
 
-func (h *handler) handleStartTest(c tele.Context) error {
-	userID := c.Sender().ID
-	ctx := context.TODO()
-
-	if err := h.users.EnsureUserExists(ctx, userID); err != nil {
-		return c.Send("Произошла непредвиденная ошибка.")
-	}
+const (
+	quizFlowPrefix = "quiz_"
+	testTimeout    = 30 * time.Minute
+)
 
-	state := &UserTestState{CurrentQuestion: 0, Score: 0}
-	h.state.Set(userID, state)
-	return h.askQuestion(c, state)
+// every quiz version is a separate flow, so started tests finish with their own version
+func quizFlowName(version int) string {
+	return quizFlowPrefix + strconv.Itoa(version)
 }
 
-func (h *handler) askQuestion(c tele.Context, state *UserTestState) error {
-	if state.CurrentQuestion >= len(questions) {
-		return h.finishTest(c, state)
+// loadFlow builds flows of level test versions, versions never change once saved
+func (h *handler) loadFlow(name string) (flow.Flow, error) {
+	v, ok := strings.CutPrefix(name, quizFlowPrefix)
+	if !ok {
+		return flow.Flow{}, fmt.Errorf("unknown flow %s", name)
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return flow.Flow{}, fmt.Errorf("invalid quiz version %s", v)
 	}
-	q := questions[state.CurrentQuestion]
 
-	type answer struct {
-		text string
-		num  int
+	quiz, err := h.quizzes.Quiz(context.TODO(), version)
+	if err != nil {
+		return flow.Flow{}, err
 	}
-	answers := make([]answer, 0, len(q.Answers))
-	for ans, num := range q.Answers {
-		answers = append(answers, answer{ans, num})
+	return h.quizFlow(quiz), nil
+}
+
+// quizFlow asks every question in order, answers are stored as option indexes
+func (h *handler) quizFlow(quiz domain.Quiz) flow.Flow {
+	steps := make([]flow.Step, 0, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		steps = append(steps, flow.Step{
+			Name: questionStep(i),
+			Prompt: func(*flow.Session) flow.Prompt {
+				rows := make([][]string, 0, len(q.Options))
+				for _, option := range q.Options {
+					rows = append(rows, []string{option.Text})
+				}
+				return flow.Prompt{Text: q.Text, Options: rows}
+			},
+			Validate: func(_ *flow.Session, answer string) (string, bool) {
+				for i, option := range q.Options {
+					if option.Text == answer {
+						return strconv.Itoa(i), true
+					}
+				}
+				return "", false
+			},
+			Invalid: "Выберите ответ с помощью кнопок под вопросом.",
+		})
 	}
-	slices.SortFunc(answers, func(a, b answer) int {
-		return a.num - b.num
-	})
-
-	markup := &tele.ReplyMarkup{}
-	rows := make([]tele.Row, 0, len(q.Answers))
-	for _, ans := range answers {
-		rows = append(rows, tele.Row{markup.Text(ans.text)})
+
+	return flow.Flow{
+		Name:     quizFlowName(quiz.Version),
+		Steps:    steps,
+		Timeout:  testTimeout,
+		Inline:   true,
+		Progress: true,
+		OnFinish: func(c tele.Context, s *flow.Session) error {
+			return h.finishTest(c, quiz, s)
+		},
 	}
+}
 
-	markup.Reply(rows...)
-	return c.Send(q.Question, markup)
+func questionStep(i int) string {
+	return fmt.Sprintf("question_%d", i)
 }
 
-func (h *handler) handleTestAnswer(c tele.Context, state *UserTestState) error {
-	answer := c.Text()
-	q := questions[state.CurrentQuestion]
-	score, ok := q.Answers[answer]
-	if !ok {
-		return c.Send("Выберите один из предложенных вариантов.")
+func (h *handler) handleStartTest(c tele.Context) error {
+	userID := c.Sender().ID
+	ctx := context.TODO()
+
+	if err := h.users.EnsureUserExists(ctx, userID, c.Sender().LanguageCode); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
 	}
 
-	state.Score += score
-	state.CurrentQuestion++
-	return h.askQuestion(c, state)
-}
+	if err := h.quizzes.CheckCooldown(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrQuizCooldown) {
+			return c.Send(testErrorMessage(err))
+		}
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
 
-func (h *handler) finishTest(c tele.Context, state *UserTestState) error {
-	ctx := context.TODO()
-	userID := c.Sender().ID
+	quiz, err := h.quizzes.ActiveQuiz(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			return c.Send(testUnavailableMessage)
+		}
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+
+	if err := h.flows.Start(c, quizFlowName(quiz.Version)); err != nil {
+		h.logger.Error("failed to start flow", "version", quiz.Version, "user_id", userID, "error", err)
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return nil
+}
 
-	var levelRu string
-	var lvl domain.UserLvl
-	avgScore := math.Round(float64(state.Score) / float64(len(questions)))
-	switch avgScore {
-	case 1:
-		lvl = domain.UserLvlBeginner
-		levelRu = "Новичок"
-	case 2:
-		lvl = domain.UserLvlIntermediate
-		levelRu = "Средний"
-	case 3:
-		lvl = domain.UserLvlAdvanced
-		levelRu = "Продвинутый"
-	default:
-		lvl = domain.UserLvlDefault
-		levelRu = "Не определен"
+func (h *handler) finishTest(c tele.Context, quiz domain.Quiz, s *flow.Session) error {
+	answers := make([]int, len(quiz.Questions))
+	for i := range answers {
+		answers[i], _ = strconv.Atoi(s.Data[questionStep(i)])
 	}
 
-	if err := h.users.UpdateUserLvl(ctx, userID, lvl); err != nil {
-		return c.Send("Произошла ошибка при обновлении уровня.", defaultKeyboard)
+	result, err := h.quizzes.CompleteQuiz(context.TODO(), c.Sender().ID, quiz.Version, answers)
+	if err != nil {
+		return c.Send(testErrorMessage(err), defaultKeyboard)
 	}
 
-	h.state.Delete(userID)
-	return c.Send(fmt.Sprintf("Ваш уровень: %s", levelRu), defaultKeyboard)
+	return c.Send(fmt.Sprintf("Ваш уровень: %s", levelNames[result.Level]), defaultKeyboard)
+}
+
+func testErrorMessage(err error) string {
+	var cooldown *domain.QuizCooldownError
+	if errors.As(err, &cooldown) {
+		return fmt.Sprintf("Тест можно пройти повторно после %s (UTC).", cooldown.RetryAt.UTC().Format("02.01.2006 15:04"))
+	}
+	return "Произошла ошибка при обновлении уровня."
 }
@@ -0,0 +1,201 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	tele "gopkg.in/telebot.v4"
+)
+
+// maxMessageLength leaves room below telegram limit of 4096 characters
+const maxMessageLength = 4000
+
+func (h *handler) handleStartPlan(c tele.Context) error {
+	userID := c.Sender().ID
+
+	if err := h.users.EnsureUserExists(context.TODO(), userID, c.Sender().LanguageCode); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+
+	state := &WorkoutPlanState{Step: workoutStepGoal}
+	if err := h.setState(userID, state); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return h.askWorkoutStep(c, state)
+}
+
+func (h *handler) askWorkoutStep(c tele.Context, state *WorkoutPlanState) error {
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true}
+	rows := make([]tele.Row, 0)
+
+	var question string
+	switch state.Step {
+	case workoutStepGoal:
+		question = "🎯 Какая у вас цель?"
+		for _, opt := range goalOptions {
+			rows = append(rows, tele.Row{markup.Text(opt.Text)})
+		}
+	case workoutStepDays:
+		question = "📅 Сколько дней в неделю вы готовы тренироваться?"
+		row := tele.Row{}
+		for days := domain.MinWorkoutDays; days <= domain.MaxWorkoutDays; days++ {
+			row = append(row, markup.Text(strconv.Itoa(days)))
+		}
+		rows = append(rows, row)
+	case workoutStepEquipment:
+		question = "🏋️ Какой инвентарь вам доступен?"
+		for _, opt := range equipmentOptions {
+			rows = append(rows, tele.Row{markup.Text(opt.Text)})
+		}
+	default:
+		question = "🩹 Есть ли у вас травмы или ограничения по здоровью? Опишите их или нажмите кнопку."
+		rows = append(rows, tele.Row{markup.Text(noInjuriesAnswer)})
+	}
+
+	markup.Reply(rows...)
+	return c.Send(question, markup)
+}
+
+func (h *handler) handleWorkoutAnswer(c tele.Context, state *WorkoutPlanState) error {
+	answer := strings.TrimSpace(c.Text())
+
+	switch state.Step {
+	case workoutStepGoal:
+		goal, ok := findOption(goalOptions, answer)
+		if !ok {
+			return c.Send("Выберите один из предложенных вариантов.")
+		}
+		state.Params.Goal = goal
+	case workoutStepDays:
+		days, err := strconv.Atoi(answer)
+		if err != nil || days < domain.MinWorkoutDays || days > domain.MaxWorkoutDays {
+			return c.Send(fmt.Sprintf("Введите число от %d до %d.", domain.MinWorkoutDays, domain.MaxWorkoutDays))
+		}
+		state.Params.DaysPerWeek = days
+	case workoutStepEquipment:
+		equipment, ok := findOption(equipmentOptions, answer)
+		if !ok {
+			return c.Send("Выберите один из предложенных вариантов.")
+		}
+		state.Params.Equipment = equipment
+	default:
+		if answer != noInjuriesAnswer {
+			state.Params.Injuries = answer
+		}
+		h.deleteState(c.Sender().ID)
+		return h.createPlan(c, state.Params)
+	}
+
+	state.Step++
+	if err := h.setState(c.Sender().ID, state); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return h.askWorkoutStep(c, state)
+}
+
+func (h *handler) createPlan(c tele.Context, params domain.WorkoutParams) error {
+	c.Send(planGeneratingMessage, defaultKeyboard)
+	c.Notify(tele.Typing)
+
+	plan, err := h.workouts.CreatePlan(context.TODO(), c.Sender().ID, params)
+	if err != nil {
+		return c.Send(workoutErrorMessage(err), defaultKeyboard)
+	}
+	return h.sendPlan(c, plan)
+}
+
+func (h *handler) handleMyPlan(c tele.Context) error {
+	plan, err := h.workouts.LatestPlan(context.TODO(), c.Sender().ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrWorkoutPlanNotFound) {
+			return c.Send(noPlanMessage)
+		}
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return h.sendPlan(c, plan)
+}
+
+// handleReplan regenerates plan with wishes from payload, without payload it asks for them
+func (h *handler) handleReplan(c tele.Context) error {
+	if adjustments := c.Message().Payload; adjustments != "" {
+		return h.adjustPlan(c, adjustments)
+	}
+
+	if _, err := h.workouts.LatestPlan(context.TODO(), c.Sender().ID); err != nil {
+		if errors.Is(err, domain.ErrWorkoutPlanNotFound) {
+			return c.Send(noPlanMessage)
+		}
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	if err := h.setState(c.Sender().ID, &WorkoutAdjustState{}); err != nil {
+		return c.Send("Произошла непредвиденная ошибка.")
+	}
+	return c.Send(replanMessage, defaultKeyboard)
+}
+
+func (h *handler) adjustPlan(c tele.Context, adjustments string) error {
+	c.Send(planGeneratingMessage, defaultKeyboard)
+	c.Notify(tele.Typing)
+
+	plan, err := h.workouts.AdjustPlan(context.TODO(), c.Sender().ID, adjustments)
+	if err != nil {
+		return c.Send(workoutErrorMessage(err), defaultKeyboard)
+	}
+	return h.sendPlan(c, plan)
+}
+
+func (h *handler) sendPlan(c tele.Context, plan domain.WorkoutPlan) error {
+	header := fmt.Sprintf("🗓 Ваш план тренировок от %s\n\n", plan.CreatedAt.Format("02.01.2006"))
+	for _, part := range splitMessage(header + plan.Content) {
+		if err := c.Send(part, defaultKeyboard); err != nil {
+			return err
+		}
+	}
+	return c.Send(askDisclaimer + "\n\n" + planHintMessage)
+}
+
+func workoutErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrWorkoutPlanNotFound):
+		return noPlanMessage
+	case errors.Is(err, domain.ErrEmptyWorkoutAdjustment):
+		return replanMessage
+	case errors.Is(err, domain.ErrWorkoutLimitExceeded):
+		return planLimitMessage
+	case errors.Is(err, ai.ErrRateLimited), errors.Is(err, ai.ErrUnavailable):
+		return askUnavailableMessage
+	default:
+		return "Произошла непредвиденная ошибка."
+	}
+}
+
+func findOption[T any](options []option[T], text string) (T, bool) {
+	for _, opt := range options {
+		if opt.Text == text {
+			return opt.Value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// splitMessage cuts long text on line boundaries into telegram sized messages
+func splitMessage(text string) []string {
+	var parts []string
+	for utf8.RuneCountInString(text) > maxMessageLength {
+		runes := []rune(text)
+		cut := string(runes[:maxMessageLength])
+		if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+			cut = cut[:i]
+		}
+		parts = append(parts, cut)
+		text = strings.TrimLeft(text[len(cut):], "\n")
+	}
+	return append(parts, text)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type AssistantRole string
+
+const (
+	AssistantRoleUser      AssistantRole = "user"
+	AssistantRoleAssistant AssistantRole = "assistant"
+)
+
+// AssistantMessage is single turn of user conversation with fitness assistant
+type AssistantMessage struct {
+	Role      AssistantRole
+	Content   string
+	CreatedAt time.Time
+}
+
+type AssistantAnswer struct {
+	Text string
+	// Remaining is number of questions left for today, negative means no limit
+	Remaining int
+}
+
+var (
+	ErrAskLimitExceeded = errors.New("daily question limit exceeded")
+	ErrEmptyQuestion    = errors.New("question is empty")
+)
@@ -0,0 +1,10 @@
+package domain
+
+// AutopilotRefill is drafts generated by autopilot for audience with short queue
+type AutopilotRefill struct {
+	Audience UserLvl `json:"audience" example:"beginner"`
+	// Queued is number of approved posts in queue at the moment of refill
+	Queued   int      `json:"queued" example:"1"`
+	DraftIDs []int64  `json:"draft_ids" example:"10,11"`
+	Themes   []string `json:"themes" example:"Польза протеина,Растяжка после тренировки"`
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type BroadcastOutcome string
+
+const (
+	// BroadcastOutcomeRunning is outcome of run which is not finished, e.g. it was interrupted by restart
+	BroadcastOutcomeRunning       BroadcastOutcome = "running"
+	BroadcastOutcomeSent          BroadcastOutcome = "sent"
+	BroadcastOutcomeNoPosts       BroadcastOutcome = "no_posts"
+	BroadcastOutcomeNoSubscribers BroadcastOutcome = "no_subscribers"
+	BroadcastOutcomeFailed        BroadcastOutcome = "failed"
+)
+
+var BroadcastOutcomes = []BroadcastOutcome{
+	BroadcastOutcomeRunning,
+	BroadcastOutcomeSent,
+	BroadcastOutcomeNoPosts,
+	BroadcastOutcomeNoSubscribers,
+	BroadcastOutcomeFailed,
+}
+
+func (o BroadcastOutcome) Valid() bool {
+	return slices.Contains(BroadcastOutcomes, o)
+}
+
+// BroadcastRun is one scheduled broadcast to audience
+type BroadcastRun struct {
+	ID       int64            `json:"id" example:"1"`
+	Audience UserLvl          `json:"audience" example:"beginner"`
+	PostID   *int64           `json:"post_id,omitempty" example:"10"`
+	Outcome  BroadcastOutcome `json:"outcome" example:"sent"`
+	Error    string           `json:"error,omitempty"`
+	// Evergreen is set when queue was empty and evergreen post was sent again
+	Evergreen bool `json:"evergreen,omitempty" example:"false"`
+	// Recipients is number of queued deliveries, Sent and Failed are counted as dispatcher sends them
+	Recipients int        `json:"recipients" example:"120"`
+	Sent       int        `json:"sent" example:"118"`
+	Failed     int        `json:"failed" example:"2"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// BroadcastResult is result of broadcast run, Err is set for failed runs
+type BroadcastResult struct {
+	PostID     int64
+	Recipients int
+	Outcome    BroadcastOutcome
+	Evergreen  bool
+	Err        error
+}
+
+type BroadcastRunsFilter struct {
+	// Audience and Outcome are not filtered when empty
+	Audience UserLvl
+	Outcome  BroadcastOutcome
+	Limit    int
+	Offset   int
+}
+
+// LastBroadcast is the last successful broadcast of audience, times are empty if audience never got a post
+type LastBroadcast struct {
+	Audience UserLvl    `json:"audience" example:"beginner"`
+	SentAt   *time.Time `json:"sent_at,omitempty"`
+	// SinceSeconds is time since the last successful broadcast
+	SinceSeconds *int64 `json:"since_seconds,omitempty" example:"3600"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+	// deliveries are scheduled in user time zones, so zones must not depend on host
+	_ "time/tzdata"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending DeliveryStatus = "pending"
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+	// DeliveryStatusExpired is set to deliveries which were not sent in time, e.g. user window was too short
+	DeliveryStatusExpired DeliveryStatus = "expired"
+)
+
+// Delivery is post queued for one subscriber, it is sent in delivery window of subscriber
+type Delivery struct {
+	ID        int64
+	UserID    int64
+	PostID    int64
+	TimeZone  string
+	Window    DeliveryWindow
+	CreatedAt time.Time
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "errors"
+
+type Tone string
+
+const (
+	ToneFriendly     Tone = "friendly"
+	ToneMotivational Tone = "motivational"
+	ToneExpert       Tone = "expert"
+	ToneHumorous     Tone = "humorous"
+)
+
+type Length string
+
+const (
+	LengthShort  Length = "short"
+	LengthMedium Length = "medium"
+	LengthLong   Length = "long"
+)
+
+// MaxPostLength is the same limit as CreatePostDTO.Content
+const MaxPostLength = 400
+
+// Limit returns max post length in characters
+func (l Length) Limit() int {
+	switch l {
+	case LengthShort:
+		return 150
+	case LengthMedium:
+		return 250
+	default:
+		return MaxPostLength
+	}
+}
+
+type GenerateParams struct {
+	Theme    string  `validate:"required,max=200"`
+	Audience UserLvl `validate:"required,oneof=beginner intermediate advanced default"`
+	Tone     Tone    `validate:"required,oneof=friendly motivational expert humorous"`
+	Length   Length  `validate:"required,oneof=short medium long"`
+	Language string  `validate:"required,bcp47_language_tag"`
+	// PromptID selects prompt template from library, default system prompt is used when empty
+	PromptID int64 `validate:"omitempty,min=1"`
+	// PromptVersion pins template version, active version is used when empty
+	PromptVersion int `validate:"omitempty,min=1"`
+	// NoCache skips generation cache, background jobs ask for the same theme repeatedly and need distinct posts
+	NoCache bool
+}
+
+type GeneratedPost struct {
+	Title       string   `json:"title" example:"Протеин после тренировки"`
+	Body        string   `json:"body" example:"Белок помогает мышцам восстановиться..."`
+	Hashtags    []string `json:"hashtags" example:"#питание,#протеин"`
+	ImagePrompt string   `json:"image_prompt" example:"protein shake on a gym bench"`
+	// Content is title, body and hashtags assembled into ready to post text
+	Content string `json:"content" example:"*Протеин после тренировки*\n\nБелок помогает мышцам восстановиться..."`
+	// PromptID and PromptVersion are set when post was generated with template from library
+	PromptID      int64 `json:"prompt_id,omitempty" example:"1"`
+	PromptVersion int   `json:"prompt_version,omitempty" example:"2"`
+	// Review is set when review is enabled and reviewer responded
+	Review *Review `json:"review,omitempty"`
+}
+
+var ErrInvalidGeneration = errors.New("generated content does not match post constraints")
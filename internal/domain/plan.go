@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDone    JobStatus = "done"
+	JobStatusFailed  JobStatus = "failed"
+)
+
+// PlanSlot is a single post to generate in content plan
+type PlanSlot struct {
+	Audience UserLvl   `json:"audience" example:"beginner"`
+	Theme    string    `json:"theme" example:"Польза протеина"`
+	Date     time.Time `json:"date" example:"2025-03-03T00:00:00Z"`
+}
+
+type PlanParams struct {
+	From     time.Time       `json:"from" example:"2025-03-03T00:00:00Z"`
+	To       time.Time       `json:"to" example:"2025-03-09T00:00:00Z"`
+	Cadence  map[UserLvl]int `json:"cadence"`
+	Themes   []string        `json:"themes" example:"Польза протеина,Растяжка после тренировки"`
+	Tone     Tone            `json:"tone" example:"friendly"`
+	Length   Length          `json:"length" example:"long"`
+	Language string          `json:"language" example:"ru"`
+	PromptID int64           `json:"prompt_id,omitempty" example:"1"`
+	Slots    []PlanSlot      `json:"slots"`
+}
+
+type PlanJob struct {
+	ID         string     `json:"id" example:"0195b4a4-0a5e-7c4e-9d5f-1b2c3d4e5f60"`
+	Status     JobStatus  `json:"status" example:"running"`
+	Params     PlanParams `json:"params"`
+	Total      int        `json:"total" example:"10"`
+	Completed  int        `json:"completed" example:"4"`
+	Failed     int        `json:"failed" example:"1"`
+	Progress   int        `json:"progress" example:"50"`
+	Error      string     `json:"error,omitempty"`
+	CreatedBy  string     `json:"created_by" example:"admin"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-03-01T12:00:00Z"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-03-01T12:05:00Z"`
+}
+
+type CreatePlanDTO struct {
+	From time.Time `validate:"required"`
+	To   time.Time `validate:"required,gtefield=From"`
+	// Cadence is number of posts per week for each audience
+	Cadence  map[UserLvl]int `validate:"required,min=1,dive,keys,oneof=beginner intermediate advanced default,endkeys,min=1,max=14"`
+	Themes   []string        `validate:"required,min=1,max=50,dive,required,max=200"`
+	Tone     Tone            `validate:"required,oneof=friendly motivational expert humorous"`
+	Length   Length          `validate:"required,oneof=short medium long"`
+	Language string          `validate:"required,bcp47_language_tag"`
+	PromptID int64           `validate:"omitempty,min=1"`
+}
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrNoJobs       = errors.New("no jobs")
+	ErrPlanTooLarge = errors.New("plan is too large")
+)
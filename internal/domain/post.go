@@ -3,13 +3,35 @@ package domain
 import (
 	"errors"
 	"mime/multipart"
+	"time"
+)
+
+type PostStatus string
+
+const (
+	// PostStatusDraft posts are waiting for admin review and never sent to subscribers
+	PostStatusDraft    PostStatus = "draft"
+	PostStatusApproved PostStatus = "approved"
 )
 
 type Post struct {
-	ID       int64    `json:"id" example:"123"`
-	Content  string   `json:"content" example:"Польза протеина в диете"`
-	Audience UserLvl  `json:"audience" example:"beginner"`
-	Images   []string `json:"images" example:"image1.jpg,image2.jpg"`
+	ID           int64      `json:"id" example:"123"`
+	Content      string     `json:"content" example:"Польза протеина в диете"`
+	Audience     UserLvl    `json:"audience" example:"beginner"`
+	Images       []string   `json:"images" example:"image1.jpg,image2.jpg"`
+	Status       PostStatus `json:"status,omitempty" example:"approved"`
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty" example:"2025-03-03T00:00:00Z"`
+	JobID        string     `json:"job_id,omitempty" example:"0195b4a4-0a5e-7c4e-9d5f-1b2c3d4e5f60"`
+	// PromptID and PromptVersion record which prompt template produced generated draft
+	PromptID      int64 `json:"prompt_id,omitempty" example:"1"`
+	PromptVersion int   `json:"prompt_version,omitempty" example:"2"`
+	// Review is result of the last AI review of post content
+	Review *Review `json:"review,omitempty"`
+	// SourceID is set for drafts rewritten from another post
+	SourceID int64 `json:"source_id,omitempty" example:"42"`
+	// Evergreen posts are sent again when queue of audience is empty
+	Evergreen  bool       `json:"evergreen,omitempty" example:"true"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty" example:"2025-03-03T10:00:00Z"`
 }
 
 var (
@@ -22,3 +44,9 @@ type CreatePostDTO struct {
 	Audience UserLvl                 `validate:"required,oneof=beginner intermediate advanced default"`
 	Images   []*multipart.FileHeader `validate:"required,min=1,dive,required"`
 }
+
+type UpdatePostDTO struct {
+	Content  string                  `validate:"omitempty,max=400"`
+	Audience UserLvl                 `validate:"omitempty,oneof=beginner intermediate advanced default"`
+	Images   []*multipart.FileHeader `validate:"omitempty,dive,required"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type Prompt struct {
+	ID            int64     `json:"id" example:"1"`
+	Name          string    `json:"name" example:"expert"`
+	Description   string    `json:"description" example:"Экспертный стиль с отсылками к исследованиям"`
+	ActiveVersion int       `json:"active_version" example:"2"`
+	Template      string    `json:"template" example:"Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."`
+	CreatedAt     time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2025-02-21T12:00:00Z"`
+}
+
+type PromptVersion struct {
+	PromptID  int64     `json:"prompt_id" example:"1"`
+	Version   int       `json:"version" example:"2"`
+	Template  string    `json:"template" example:"Ты фитнес тренер. Напиши пост на тему {{.Theme}} для уровня {{.Level}} на языке {{.Language}}."`
+	Author    string    `json:"author" example:"admin"`
+	CreatedAt time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+}
+
+// PromptVars are variables available in prompt template
+type PromptVars struct {
+	Theme    string
+	Level    UserLvl
+	Language string
+	Tone     Tone
+	Length   Length
+}
+
+// RenderPrompt executes prompt template, unknown variables are reported as error
+func RenderPrompt(tmpl string, vars PromptVars) (string, error) {
+	t, err := template.New("prompt").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, vars); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return sb.String(), nil
+}
+
+type CreatePromptDTO struct {
+	Name        string `validate:"required,max=100"`
+	Description string `validate:"max=500"`
+	Template    string `validate:"required,max=5000"`
+}
+
+// UpdatePromptDTO changes description in place, new template is saved as next active version
+type UpdatePromptDTO struct {
+	Description *string `validate:"omitempty,max=500"`
+	Template    *string `validate:"omitempty,min=1,max=5000"`
+}
+
+var (
+	ErrPromptNotFound        = errors.New("prompt not found")
+	ErrPromptAlreadyExists   = errors.New("prompt already exists")
+	ErrPromptVersionNotFound = errors.New("prompt version not found")
+	ErrInvalidTemplate       = errors.New("invalid prompt template")
+)
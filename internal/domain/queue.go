@@ -0,0 +1,45 @@
+package domain
+
+type QueueStatus string
+
+const (
+	QueueStatusOK       QueueStatus = "ok"
+	QueueStatusLow      QueueStatus = "low"
+	QueueStatusCritical QueueStatus = "critical"
+	// QueueStatusEmpty means broadcasts of audience are skipped
+	QueueStatusEmpty QueueStatus = "empty"
+)
+
+// QueueDepth is supply of approved posts of audience at its broadcast cadence
+type QueueDepth struct {
+	Audience UserLvl `json:"audience" example:"beginner"`
+	// Posts are approved posts which can be sent now, posts scheduled for later are not counted
+	Posts int `json:"posts" example:"12"`
+	// RunsPerDay is average number of broadcasts a day, zero when schedule is disabled
+	RunsPerDay float64 `json:"runs_per_day" example:"2"`
+	// DaysLeft is empty when schedule is disabled, queue is not consumed then
+	DaysLeft *float64    `json:"days_left,omitempty" example:"6"`
+	Status   QueueStatus `json:"status" example:"ok"`
+}
+
+// QueueThresholds are days of content left when queue status becomes low and critical
+type QueueThresholds struct {
+	Low      float64
+	Critical float64
+}
+
+// Status of queue with posts which last for daysLeft, nil daysLeft means queue is not consumed
+func (t QueueThresholds) Status(posts int, daysLeft *float64) QueueStatus {
+	switch {
+	case daysLeft == nil:
+		return QueueStatusOK
+	case posts == 0:
+		return QueueStatusEmpty
+	case *daysLeft < t.Critical:
+		return QueueStatusCritical
+	case *daysLeft < t.Low:
+		return QueueStatusLow
+	default:
+		return QueueStatusOK
+	}
+}
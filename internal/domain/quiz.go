@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// QuizOption is answer of level test question, weights of chosen options are summed up
+type QuizOption struct {
+	Text   string `json:"text" validate:"required,max=60" example:"3 и более раз в неделю"`
+	Weight int    `json:"weight" validate:"min=0,max=100" example:"3"`
+}
+
+type QuizQuestion struct {
+	Text    string       `json:"text" validate:"required,max=300" example:"Как часто вы тренируетесь в зале?"`
+	Options []QuizOption `json:"options" validate:"required,min=2,max=8,dive"`
+}
+
+// QuizThreshold assigns level to users who scored at least MinScore
+type QuizThreshold struct {
+	Level    UserLvl `json:"level" validate:"required,oneof=beginner intermediate advanced" example:"intermediate"`
+	MinScore int     `json:"min_score" validate:"min=0" example:"5"`
+}
+
+// Quiz is immutable version of level test, changes are saved as new version
+type Quiz struct {
+	Version    int             `json:"version" example:"2"`
+	Questions  []QuizQuestion  `json:"questions"`
+	Thresholds []QuizThreshold `json:"thresholds"`
+	Active     bool            `json:"active" example:"true"`
+	Author     string          `json:"author" example:"admin"`
+	CreatedAt  time.Time       `json:"created_at" example:"2025-02-20T12:00:00Z"`
+}
+
+// Score sums weights of chosen options, answers are option indexes by question
+func (q Quiz) Score(answers []int) (int, error) {
+	if len(answers) != len(q.Questions) {
+		return 0, fmt.Errorf("%w: expected %d answers, got %d", ErrInvalidQuizAnswers, len(q.Questions), len(answers))
+	}
+	var score int
+	for i, answer := range answers {
+		options := q.Questions[i].Options
+		if answer < 0 || answer >= len(options) {
+			return 0, fmt.Errorf("%w: question %d has no option %d", ErrInvalidQuizAnswers, i+1, answer)
+		}
+		score += options[answer].Weight
+	}
+	return score, nil
+}
+
+// Level returns level of the highest threshold reached by score
+func (q Quiz) Level(score int) UserLvl {
+	lvl, best := UserLvlDefault, -1
+	for _, t := range q.Thresholds {
+		if score >= t.MinScore && t.MinScore > best {
+			lvl, best = t.Level, t.MinScore
+		}
+	}
+	return lvl
+}
+
+// Validate checks rules which struct tags can not express
+func (q Quiz) Validate() error {
+	levels := make([]UserLvl, 0, len(q.Thresholds))
+	for _, t := range q.Thresholds {
+		if slices.Contains(levels, t.Level) {
+			return fmt.Errorf("%w: duplicate threshold for %s", ErrInvalidQuiz, t.Level)
+		}
+		levels = append(levels, t.Level)
+	}
+
+	// bot matches answer to option by text
+	for _, question := range q.Questions {
+		texts := make([]string, 0, len(question.Options))
+		for _, option := range question.Options {
+			if slices.Contains(texts, option.Text) {
+				return fmt.Errorf("%w: duplicate option %s in question %s", ErrInvalidQuiz, option.Text, question.Text)
+			}
+			texts = append(texts, option.Text)
+		}
+	}
+
+	var maxScore int
+	for _, question := range q.Questions {
+		maxScore += slices.MaxFunc(question.Options, func(a, b QuizOption) int { return a.Weight - b.Weight }).Weight
+	}
+	for _, t := range q.Thresholds {
+		if t.MinScore > maxScore {
+			return fmt.Errorf("%w: %s threshold %d is above max score %d", ErrInvalidQuiz, t.Level, t.MinScore, maxScore)
+		}
+	}
+	return nil
+}
+
+type QuizResult struct {
+	Version int
+	Score   int
+	Level   UserLvl
+}
+
+// QuizAttempt is one completed level test, answers are option indexes by question
+type QuizAttempt struct {
+	ID        int64
+	UserID    int64
+	Version   int
+	Answers   []int
+	Score     int
+	Level     UserLvl
+	CreatedAt time.Time
+}
+
+type QuizOptionStats struct {
+	Text   string `json:"text" example:"3 и более раз в неделю"`
+	Weight int    `json:"weight" example:"3"`
+	Count  int    `json:"count" example:"42"`
+	// Share is part of attempts with this answer, from 0 to 1
+	Share float64 `json:"share" example:"0.35"`
+}
+
+type QuizQuestionStats struct {
+	Text    string            `json:"text" example:"Как часто вы тренируетесь в зале?"`
+	Options []QuizOptionStats `json:"options"`
+}
+
+// QuizStats is answer distribution of every question of quiz version
+type QuizStats struct {
+	Version   int                 `json:"version" example:"2"`
+	Attempts  int                 `json:"attempts" example:"120"`
+	Questions []QuizQuestionStats `json:"questions"`
+}
+
+// LevelStats is number of attempts by resulting level for one day
+type LevelStats struct {
+	Day    time.Time       `json:"day" example:"2025-03-01T00:00:00Z"`
+	Levels map[UserLvl]int `json:"levels"`
+}
+
+// QuizCooldownError tells when user can take the test again
+type QuizCooldownError struct {
+	RetryAt time.Time
+}
+
+func (e *QuizCooldownError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrQuizCooldown, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *QuizCooldownError) Unwrap() error {
+	return ErrQuizCooldown
+}
+
+type CreateQuizDTO struct {
+	Questions  []QuizQuestion  `validate:"required,min=1,max=20,dive"`
+	Thresholds []QuizThreshold `validate:"required,min=1,max=3,dive"`
+	// Activate makes new version active right away
+	Activate bool
+}
+
+var (
+	ErrQuizNotFound        = errors.New("quiz not found")
+	ErrInvalidQuiz         = errors.New("invalid quiz")
+	ErrQuizActive          = errors.New("quiz version is active")
+	ErrQuizInUse           = errors.New("quiz version was taken by users")
+	ErrInvalidQuizAnswers  = errors.New("invalid quiz answers")
+	ErrQuizCooldown        = errors.New("quiz retake is not allowed yet")
+	ErrQuizAttemptNotFound = errors.New("quiz attempt not found")
+)
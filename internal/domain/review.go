@@ -0,0 +1,70 @@
+package domain
+
+import "errors"
+
+type ReviewVerdict string
+
+const (
+	ReviewVerdictPass ReviewVerdict = "pass"
+	// ReviewVerdictWarn means scores are acceptable, but some sentences need attention
+	ReviewVerdictWarn ReviewVerdict = "warn"
+	ReviewVerdictFail ReviewVerdict = "fail"
+)
+
+type ReviewCategory string
+
+const (
+	ReviewCategorySafety ReviewCategory = "safety"
+	ReviewCategoryLevel  ReviewCategory = "level"
+	ReviewCategoryFact   ReviewCategory = "fact"
+)
+
+// ReviewScores are from 1 (dangerous or wrong) to 10 (safe and correct)
+type ReviewScores struct {
+	Safety   int `json:"safety" example:"8"`
+	LevelFit int `json:"level_fit" example:"7"`
+	Accuracy int `json:"accuracy" example:"9"`
+}
+
+func (s ReviewScores) Min() int {
+	return min(s.Safety, s.LevelFit, s.Accuracy)
+}
+
+type FlaggedSentence struct {
+	Sentence string         `json:"sentence" example:"Новичкам стоит начинать с приседаний со штангой 100 кг."`
+	Category ReviewCategory `json:"category" example:"level"`
+	Reason   string         `json:"reason" example:"Небезопасная нагрузка для начинающих"`
+}
+
+type Review struct {
+	Verdict ReviewVerdict     `json:"verdict" example:"warn"`
+	Scores  ReviewScores      `json:"scores"`
+	Flagged []FlaggedSentence `json:"flagged"`
+	Summary string            `json:"summary" example:"Пост в целом корректен, но рекомендуемая нагрузка завышена"`
+}
+
+// ReviewPolicy configures review of generated and saved posts
+type ReviewPolicy struct {
+	Enabled bool
+	// Rubric is system prompt for reviewer model
+	Rubric string
+	// PassScore is minimal score in every category, lower score fails the review
+	PassScore int
+	// Block forbids saving and approving posts which failed the review
+	Block bool
+}
+
+var ErrReviewFailed = errors.New("post failed review")
+
+// ReviewFailedError carries review of blocked post
+type ReviewFailedError struct {
+	Review Review
+}
+
+func (e *ReviewFailedError) Error() string {
+	return ErrReviewFailed.Error()
+}
+
+func (e *ReviewFailedError) Unwrap() error {
+	return ErrReviewFailed
+}
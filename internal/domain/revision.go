@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type PostRevision struct {
+	PostID    int64     `json:"post_id" example:"123"`
+	Version   int       `json:"version" example:"2"`
+	Content   string    `json:"content" example:"Польза протеина в диете"`
+	Audience  UserLvl   `json:"audience" example:"beginner"`
+	Images    []string  `json:"images" example:"image1.jpg,image2.jpg"`
+	Author    string    `json:"author" example:"admin"`
+	CreatedAt time.Time `json:"created_at" example:"2025-02-20T12:00:00Z"`
+}
+
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpInsert DiffOp = "insert"
+	DiffOpDelete DiffOp = "delete"
+)
+
+type DiffLine struct {
+	Op   DiffOp `json:"op" example:"insert"`
+	Text string `json:"text" example:"Пейте больше воды"`
+}
+
+type PostDiff struct {
+	PostID        int64      `json:"post_id" example:"123"`
+	From          int        `json:"from" example:"1"`
+	To            int        `json:"to" example:"2"`
+	Content       []DiffLine `json:"content"`
+	AudienceFrom  UserLvl    `json:"audience_from" example:"default"`
+	AudienceTo    UserLvl    `json:"audience_to" example:"beginner"`
+	ImagesAdded   []string   `json:"images_added" example:"image3.jpg"`
+	ImagesRemoved []string   `json:"images_removed" example:"image1.jpg"`
+}
+
+var ErrRevisionNotFound = errors.New("revision not found")
@@ -0,0 +1,27 @@
+package domain
+
+type RewriteTransform string
+
+const (
+	RewriteAdaptToLevel RewriteTransform = "adapt_to_level"
+	RewriteShortenTo    RewriteTransform = "shorten_to"
+	RewriteTranslateTo  RewriteTransform = "translate_to"
+	RewriteChangeTone   RewriteTransform = "change_tone"
+)
+
+// RewriteDTO describes transform of existing post, only the field of selected transform is used
+type RewriteDTO struct {
+	Transform RewriteTransform `validate:"required,oneof=adapt_to_level shorten_to translate_to change_tone"`
+	Level     UserLvl          `validate:"required_if=Transform adapt_to_level,omitempty,oneof=beginner intermediate advanced default"`
+	Length    int              `validate:"required_if=Transform shorten_to,omitempty,min=50,max=400"`
+	Language  string           `validate:"required_if=Transform translate_to,omitempty,bcp47_language_tag"`
+	Tone      Tone             `validate:"required_if=Transform change_tone,omitempty,oneof=friendly motivational expert humorous"`
+	// Save stores result as draft linked to the source post
+	Save bool
+}
+
+type RewriteResult struct {
+	Post GeneratedPost `json:"post"`
+	// Draft is set when result was saved
+	Draft *Post `json:"draft,omitempty"`
+}
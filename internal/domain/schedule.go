@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Schedule is cron spec of broadcasts for audience, default audience is sent to all subscribers
+type Schedule struct {
+	Audience UserLvl `json:"audience" example:"beginner"`
+	// Spec is cron expression with seconds field
+	Spec      string    `json:"spec" example:"0 0 10 * * *"`
+	Enabled   bool      `json:"enabled" example:"true"`
+	UpdatedBy string    `json:"updated_by,omitempty" example:"admin"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// NextRuns are upcoming run times in server time zone, empty for disabled schedule
+	NextRuns []time.Time `json:"next_runs,omitempty"`
+}
+
+type UpdateScheduleDTO struct {
+	Spec    string
+	Enabled bool
+}
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid schedule")
+)
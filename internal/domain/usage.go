@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type TokenUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens" example:"1200"`
+	CompletionTokens int64 `json:"completion_tokens" example:"300"`
+	TotalTokens      int64 `json:"total_tokens" example:"1500"`
+}
+
+// Usage is model usage of single admin for one day
+type Usage struct {
+	Day time.Time `json:"day" example:"2025-03-01T00:00:00Z"`
+	// Requests is number of generations, repairs of invalid output within one generation are not counted
+	Requests int `json:"requests" example:"12"`
+	TokenUsage
+}
+
+// Quota limits daily usage of each admin, zero value means no limit.
+// Token quota is checked before generation, so concurrent generations may exceed it by their own tokens.
+type Quota struct {
+	Tokens   int64 `json:"tokens" example:"100000"`
+	Requests int   `json:"requests" example:"100"`
+}
+
+// Remaining is left daily quota, null means no limit
+type Remaining struct {
+	Tokens   *int64 `json:"tokens" example:"98500"`
+	Requests *int   `json:"requests" example:"88"`
+}
+
+type UsageReport struct {
+	Admin     string    `json:"admin" example:"admin"`
+	Today     Usage     `json:"today"`
+	Quota     Quota     `json:"quota"`
+	Remaining Remaining `json:"remaining"`
+	History   []Usage   `json:"history"`
+}
+
+var ErrQuotaExceeded = errors.New("daily quota exceeded")
@@ -1,6 +1,11 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
 
 type UserLvl string
 
@@ -11,9 +16,90 @@ const (
 	UserLvlAdvanced     UserLvl = "advanced"
 )
 
+// Audiences are levels which posts are targeted at, default audience means all subscribers
+var Audiences = []UserLvl{UserLvlDefault, UserLvlBeginner, UserLvlIntermediate, UserLvlAdvanced}
+
+func (l UserLvl) Valid() bool {
+	return slices.Contains(Audiences, l)
+}
+
+// DeliveryFrequency limits how often subscriber receives broadcasts
+type DeliveryFrequency string
+
+const (
+	DeliveryFrequencyAll    DeliveryFrequency = "all"
+	DeliveryFrequencyDaily  DeliveryFrequency = "daily"
+	DeliveryFrequencyWeekly DeliveryFrequency = "weekly"
+)
+
+// Interval is minimal time between two deliveries, zero means every broadcast.
+// It is an hour shorter than the period, so broadcasts at the same time of day are not skipped.
+func (f DeliveryFrequency) Interval() time.Duration {
+	switch f {
+	case DeliveryFrequencyDaily:
+		return 23 * time.Hour
+	case DeliveryFrequencyWeekly:
+		return 7*24*time.Hour - time.Hour
+	default:
+		return 0
+	}
+}
+
+func (f DeliveryFrequency) Valid() bool {
+	switch f {
+	case DeliveryFrequencyAll, DeliveryFrequencyDaily, DeliveryFrequencyWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeliveryWindow is range of local hours [Start, End), window with Start after End wraps midnight
+type DeliveryWindow struct {
+	Start int
+	End   int
+}
+
+// DefaultTimeZone is used when time zone can not be guessed from user language
+const DefaultTimeZone = "Europe/Moscow"
+
+var DefaultDeliveryWindow = DeliveryWindow{Start: 9, End: 21}
+
+func (w DeliveryWindow) Contains(hour int) bool {
+	if w.Start < w.End {
+		return hour >= w.Start && hour < w.End
+	}
+	return hour >= w.Start || hour < w.End
+}
+
+func (w DeliveryWindow) Valid() bool {
+	return w.Start >= 0 && w.Start < 24 && w.End >= 0 && w.End < 24 && w.Start != w.End
+}
+
+func (w DeliveryWindow) String() string {
+	return fmt.Sprintf("%d-%d", w.Start, w.End)
+}
+
+func ParseDeliveryWindow(s string) (DeliveryWindow, error) {
+	var w DeliveryWindow
+	if _, err := fmt.Sscanf(s, "%d-%d", &w.Start, &w.End); err != nil || !w.Valid() {
+		return DeliveryWindow{}, ErrInvalidDeliveryWindow
+	}
+	return w, nil
+}
+
 type User struct {
-	ID  int64
-	Lvl UserLvl
+	ID         int64
+	Lvl        UserLvl
+	Subscribed bool
+	Frequency  DeliveryFrequency
+	TimeZone   string
+	Window     DeliveryWindow
 }
 
-var ErrUserNotFound = errors.New("user not found")
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrInvalidDeliveryFrequency = errors.New("invalid delivery frequency")
+	ErrInvalidDeliveryWindow    = errors.New("invalid delivery window")
+	ErrInvalidTimeZone          = errors.New("invalid time zone")
+)
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type WorkoutGoal string
+
+const (
+	WorkoutGoalLoseWeight  WorkoutGoal = "lose_weight"
+	WorkoutGoalBuildMuscle WorkoutGoal = "build_muscle"
+	WorkoutGoalEndurance   WorkoutGoal = "endurance"
+	WorkoutGoalHealth      WorkoutGoal = "health"
+)
+
+type Equipment string
+
+const (
+	EquipmentNone Equipment = "none"
+	EquipmentHome Equipment = "home"
+	EquipmentGym  Equipment = "gym"
+)
+
+// WorkoutParams are answers of /plan questionnaire
+type WorkoutParams struct {
+	Goal        WorkoutGoal
+	DaysPerWeek int
+	Equipment   Equipment
+	// Injuries is free text, empty when user has none
+	Injuries string
+}
+
+type WorkoutPlan struct {
+	ID     int64
+	UserID int64
+	Level  UserLvl
+	Params WorkoutParams
+	// Adjustments are user wishes the plan was regenerated with
+	Adjustments string
+	Content     string
+	CreatedAt   time.Time
+}
+
+const (
+	MinWorkoutDays = 1
+	MaxWorkoutDays = 6
+)
+
+var (
+	ErrWorkoutPlanNotFound    = errors.New("workout plan not found")
+	ErrWorkoutLimitExceeded   = errors.New("daily workout plan limit exceeded")
+	ErrInvalidWorkoutParams   = errors.New("invalid workout params")
+	ErrEmptyWorkoutAdjustment = errors.New("workout adjustment is empty")
+)
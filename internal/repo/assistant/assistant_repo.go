@@ -0,0 +1,73 @@
+package assistant
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type assistantRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) AssistantRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &assistantRepo{db: db, qb: qb}
+}
+
+func (r *assistantRepo) SaveMessages(ctx context.Context, userID int64, messages ...domain.AssistantMessage) error {
+	q := r.qb.Insert("assistant_messages").Columns("user_id", "role", "content")
+	for _, msg := range messages {
+		q = q.Values(userID, msg.Role, msg.Content)
+	}
+	query, args := q.MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save messages: %w", err)
+	}
+	return nil
+}
+
+func (r *assistantRepo) History(ctx context.Context, userID int64, since time.Time, limit int) ([]domain.AssistantMessage, error) {
+	query, args := r.qb.
+		Select("role", "content", "created_at").
+		From("assistant_messages").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.GtOrEq{"created_at": since}).
+		OrderBy("message_id DESC").
+		Limit(uint64(limit)).
+		MustSql()
+
+	var messages []Message
+	if err := r.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	slices.Reverse(messages)
+
+	res := make([]domain.AssistantMessage, 0, len(messages))
+	for _, msg := range messages {
+		res = append(res, msg.ToDomain())
+	}
+	return res, nil
+}
+
+func (r *assistantRepo) CountQuestions(ctx context.Context, userID int64, since time.Time) (int, error) {
+	query, args := r.qb.
+		Select("COUNT(*)").
+		From("assistant_messages").
+		Where(sq.Eq{"user_id": userID, "role": domain.AssistantRoleUser}).
+		Where(sq.GtOrEq{"created_at": since}).
+		MustSql()
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to count questions: %w", err)
+	}
+	return count, nil
+}
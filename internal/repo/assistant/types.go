@@ -0,0 +1,25 @@
+package assistant
+
+import (
+	"context"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type Message struct {
+	Role      domain.AssistantRole `db:"role"`
+	Content   string               `db:"content"`
+	CreatedAt time.Time            `db:"created_at"`
+}
+
+func (m Message) ToDomain() domain.AssistantMessage {
+	return domain.AssistantMessage{Role: m.Role, Content: m.Content, CreatedAt: m.CreatedAt}
+}
+
+type AssistantRepo interface {
+	SaveMessages(ctx context.Context, userID int64, messages ...domain.AssistantMessage) error
+	// History returns last messages since given time, oldest first
+	History(ctx context.Context, userID int64, since time.Time, limit int) ([]domain.AssistantMessage, error)
+	CountQuestions(ctx context.Context, userID int64, since time.Time) (int, error)
+}
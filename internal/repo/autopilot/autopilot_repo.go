@@ -0,0 +1,36 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type autopilotRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) AutopilotRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &autopilotRepo{db: db, qb: qb}
+}
+
+func (r *autopilotRepo) ReserveThemes(ctx context.Context, audience domain.UserLvl, count, total int) (int, error) {
+	query, args := r.qb.
+		Insert("autopilot_themes").
+		Columns("audience", "theme_index").
+		Values(audience, count%total).
+		Suffix("ON CONFLICT (audience) DO UPDATE SET theme_index = (autopilot_themes.theme_index + ?) % ?, updated_at = NOW()", count, total).
+		Suffix("RETURNING theme_index").
+		MustSql()
+
+	var next int
+	if err := r.db.GetContext(ctx, &next, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to reserve themes: %w", err)
+	}
+	return ((next-count)%total + total) % total, nil
+}
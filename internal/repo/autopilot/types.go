@@ -0,0 +1,13 @@
+package autopilot
+
+import (
+	"context"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type AutopilotRepo interface {
+	// ReserveThemes moves rotation of audience by count themes out of total in one statement
+	// and returns position of the first reserved theme, rotation of new audience starts from zero
+	ReserveThemes(ctx context.Context, audience domain.UserLvl, count, total int) (int, error)
+}
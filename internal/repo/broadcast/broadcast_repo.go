@@ -0,0 +1,107 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type broadcastRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) BroadcastRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &broadcastRepo{db: db, qb: qb}
+}
+
+func (r *broadcastRepo) Start(ctx context.Context, audience domain.UserLvl) (int64, error) {
+	query, args := r.qb.
+		Insert("broadcast_runs").
+		Columns("audience").
+		Values(audience).
+		Suffix("RETURNING run_id").
+		MustSql()
+
+	var id int64
+	if err := r.db.GetContext(ctx, &id, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to start broadcast run: %w", err)
+	}
+	return id, nil
+}
+
+func (r *broadcastRepo) Finish(ctx context.Context, in FinishRunInput) error {
+	q := r.qb.
+		Update("broadcast_runs").
+		Set("recipients", in.Recipients).
+		Set("outcome", in.Outcome).
+		Set("evergreen", in.Evergreen).
+		Set("finished_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"run_id": in.ID})
+	if in.PostID != 0 {
+		q = q.Set("post_id", in.PostID)
+	}
+	if in.Error != "" {
+		q = q.Set("error", in.Error)
+	}
+	query, args := q.MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to finish broadcast run: %w", err)
+	}
+	return nil
+}
+
+func (r *broadcastRepo) Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error) {
+	q := r.qb.
+		Select("r.run_id", "r.audience", "r.post_id", "r.outcome", "r.error", "r.evergreen", "r.recipients", "r.started_at", "r.finished_at").
+		Column("COUNT(d.delivery_id) FILTER (WHERE d.status = ?) AS sent", domain.DeliveryStatusSent).
+		Column("COUNT(d.delivery_id) FILTER (WHERE d.status IN (?, ?)) AS failed", domain.DeliveryStatusFailed, domain.DeliveryStatusExpired).
+		From("broadcast_runs r").
+		LeftJoin("deliveries d ON d.run_id = r.run_id").
+		GroupBy("r.run_id").
+		OrderBy("r.started_at DESC", "r.run_id DESC").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset))
+	if filter.Audience != "" {
+		q = q.Where(sq.Eq{"r.audience": filter.Audience})
+	}
+	if filter.Outcome != "" {
+		q = q.Where(sq.Eq{"r.outcome": filter.Outcome})
+	}
+	query, args := q.MustSql()
+
+	var entities []Run
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get broadcast runs: %w", err)
+	}
+	res := make([]domain.BroadcastRun, 0, len(entities))
+	for _, entity := range entities {
+		res = append(res, entity.ToDomain())
+	}
+	return res, nil
+}
+
+func (r *broadcastRepo) LastSent(ctx context.Context) (map[domain.UserLvl]time.Time, error) {
+	query, args := r.qb.
+		Select("audience", "MAX(finished_at) AS sent_at").
+		From("broadcast_runs").
+		Where(sq.Eq{"outcome": domain.BroadcastOutcomeSent}).
+		GroupBy("audience").
+		MustSql()
+
+	var entities []LastSent
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get last broadcasts: %w", err)
+	}
+	res := make(map[domain.UserLvl]time.Time, len(entities))
+	for _, entity := range entities {
+		res[entity.Audience] = entity.SentAt
+	}
+	return res, nil
+}
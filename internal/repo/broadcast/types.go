@@ -0,0 +1,68 @@
+package broadcast
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type Run struct {
+	ID         int64                   `db:"run_id"`
+	Audience   domain.UserLvl          `db:"audience"`
+	PostID     sql.NullInt64           `db:"post_id"`
+	Outcome    domain.BroadcastOutcome `db:"outcome"`
+	Error      sql.NullString          `db:"error"`
+	Evergreen  bool                    `db:"evergreen"`
+	Recipients int                     `db:"recipients"`
+	Sent       int                     `db:"sent"`
+	Failed     int                     `db:"failed"`
+	StartedAt  time.Time               `db:"started_at"`
+	FinishedAt sql.NullTime            `db:"finished_at"`
+}
+
+func (r Run) ToDomain() domain.BroadcastRun {
+	run := domain.BroadcastRun{
+		ID:         r.ID,
+		Audience:   r.Audience,
+		Outcome:    r.Outcome,
+		Error:      r.Error.String,
+		Evergreen:  r.Evergreen,
+		Recipients: r.Recipients,
+		Sent:       r.Sent,
+		Failed:     r.Failed,
+		StartedAt:  r.StartedAt,
+	}
+	if r.PostID.Valid {
+		run.PostID = &r.PostID.Int64
+	}
+	if r.FinishedAt.Valid {
+		run.FinishedAt = &r.FinishedAt.Time
+	}
+	return run
+}
+
+type LastSent struct {
+	Audience domain.UserLvl `db:"audience"`
+	SentAt   time.Time      `db:"sent_at"`
+}
+
+type FinishRunInput struct {
+	ID         int64
+	PostID     int64
+	Recipients int
+	Outcome    domain.BroadcastOutcome
+	Evergreen  bool
+	Error      string
+}
+
+type BroadcastRepo interface {
+	// Start saves running broadcast of audience and returns its id
+	Start(ctx context.Context, audience domain.UserLvl) (int64, error)
+	Finish(ctx context.Context, in FinishRunInput) error
+	// Runs returns runs with counts of sent and failed deliveries, newest first
+	Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)
+	// LastSent returns finish time of the last successful run by audience
+	LastSent(ctx context.Context) (map[domain.UserLvl]time.Time, error)
+}
@@ -0,0 +1,151 @@
+package delivery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type deliveryRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) DeliveryRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &deliveryRepo{db: db, qb: qb}
+}
+
+func (r *deliveryRepo) Enqueue(ctx context.Context, runID, postID int64, userIDs []int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	// deliveries of broadcast which was not recorded in history have no run
+	run := sql.NullInt64{Int64: runID, Valid: runID != 0}
+	// one array parameter instead of a row of parameters per user, so audience size is not limited
+	query, args := r.qb.
+		Insert("deliveries").
+		Columns("user_id", "post_id", "run_id").
+		Select(r.qb.Select().Column("unnest(?::BIGINT[])", pq.Array(userIDs)).Column("?", postID).Column("?::BIGINT", run)).
+		MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to enqueue deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *deliveryRepo) Pending(ctx context.Context, afterID int64, limit int) ([]domain.Delivery, error) {
+	query, args := pendingQuery(r.qb, afterID, limit).MustSql()
+
+	var entities []Delivery
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get pending deliveries: %w", err)
+	}
+	res := make([]domain.Delivery, 0, len(entities))
+	for _, entity := range entities {
+		res = append(res, entity.ToDomain())
+	}
+	return res, nil
+}
+
+func (r *deliveryRepo) MarkSent(ctx context.Context, id int64) error {
+	query, args := markSentQuery(r.qb, id).MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark delivery sent: %w", err)
+	}
+	return nil
+}
+
+func (r *deliveryRepo) MarkFailed(ctx context.Context, id int64, reason string) error {
+	query, args := r.qb.
+		Update("deliveries").
+		Set("status", domain.DeliveryStatusFailed).
+		Set("error", reason).
+		Where(sq.Eq{"delivery_id": id}).
+		MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark delivery failed: %w", err)
+	}
+	return nil
+}
+
+func (r *deliveryRepo) Expire(ctx context.Context, before time.Time) (int64, error) {
+	query, args := r.qb.
+		Update("deliveries").
+		Set("status", domain.DeliveryStatusExpired).
+		Where(sq.Eq{"status": domain.DeliveryStatusPending}).
+		Where(sq.Lt{"created_at": before}).
+		MustSql()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire deliveries: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (r *deliveryRepo) Received(ctx context.Context, postID int64, userIDs []int64, since time.Time) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	query, args := receivedQuery(r.qb, postID, userIDs, since).MustSql()
+
+	var received []int64
+	if err := r.db.SelectContext(ctx, &received, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get received deliveries: %w", err)
+	}
+	return received, nil
+}
+
+// markSentQuery remembers send time in user too, frequency of user counts from posts actually sent.
+// Expired deliveries never reach user, so they do not count.
+func markSentQuery(qb sq.StatementBuilderType, id int64) sq.UpdateBuilder {
+	sent := sq.
+		Update("deliveries").
+		Set("status", domain.DeliveryStatusSent).
+		Set("sent_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"delivery_id": id}).
+		Suffix("RETURNING user_id")
+
+	return qb.
+		Update("users").
+		PrefixExpr(sq.Expr("WITH sent AS (?)", sent)).
+		Set("delivered_at", sq.Expr("NOW()")).
+		Where("user_id IN (SELECT user_id FROM sent)")
+}
+
+// pendingQuery pages by delivery id, ids grow with creation time, so pages go from the oldest deliveries.
+// Deliveries of unsubscribed users stay pending until they expire.
+func pendingQuery(qb sq.StatementBuilderType, afterID int64, limit int) sq.SelectBuilder {
+	return qb.
+		Select("d.delivery_id", "d.user_id", "d.post_id", "u.timezone", "u.window_start", "u.window_end", "d.created_at").
+		From("deliveries d").
+		Join("users u ON u.user_id = d.user_id").
+		Where(sq.Eq{"d.status": domain.DeliveryStatusPending, "u.subscribed": true}).
+		Where(sq.Gt{"d.delivery_id": afterID}).
+		OrderBy("d.delivery_id").
+		Limit(uint64(limit))
+}
+
+// receivedQuery dates sent delivery by send time, delivery waiting for user window is received later than it was queued.
+// Pending delivery counts as received whenever it was queued, the user gets it anyway.
+func receivedQuery(qb sq.StatementBuilderType, postID int64, userIDs []int64, since time.Time) sq.SelectBuilder {
+	return qb.
+		Select("DISTINCT user_id").
+		From("deliveries").
+		Where(sq.Eq{"post_id": postID}).
+		Where("user_id = ANY(?)", pq.Array(userIDs)).
+		Where(sq.Or{
+			sq.Eq{"status": domain.DeliveryStatusPending},
+			sq.And{sq.Eq{"status": domain.DeliveryStatusSent}, sq.GtOrEq{"COALESCE(sent_at, created_at)": since}},
+		})
+}
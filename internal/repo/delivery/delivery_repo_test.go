@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query, args := pendingQuery(qb, 10, 500).MustSql()
+	assert.Equal(t, "SELECT d.delivery_id, d.user_id, d.post_id, u.timezone, u.window_start, u.window_end, d.created_at "+
+		"FROM deliveries d JOIN users u ON u.user_id = d.user_id "+
+		"WHERE d.status = $1 AND u.subscribed = $2 AND d.delivery_id > $3 ORDER BY d.delivery_id LIMIT 500", query)
+	assert.Equal(t, []any{domain.DeliveryStatusPending, true, int64(10)}, args)
+}
+
+func TestReceivedQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	query, args := receivedQuery(qb, 7, []int64{1, 2}, since).MustSql()
+	assert.Equal(t, "SELECT DISTINCT user_id FROM deliveries WHERE post_id = $1 AND user_id = ANY($2) "+
+		"AND (status = $3 OR (status = $4 AND COALESCE(sent_at, created_at) >= $5))", query)
+	assert.Equal(t, []any{int64(7), pq.Array([]int64{1, 2}), domain.DeliveryStatusPending, domain.DeliveryStatusSent, since}, args)
+}
+
+func TestMarkSentQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query, args := markSentQuery(qb, 7).MustSql()
+	assert.Equal(t, "WITH sent AS (UPDATE deliveries SET status = $1, sent_at = NOW() WHERE delivery_id = $2 RETURNING user_id) "+
+		"UPDATE users SET delivered_at = NOW() WHERE user_id IN (SELECT user_id FROM sent)", query)
+	assert.Equal(t, []any{domain.DeliveryStatusSent, int64(7)}, args)
+}
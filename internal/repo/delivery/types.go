@@ -0,0 +1,43 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type Delivery struct {
+	ID          int64     `db:"delivery_id"`
+	UserID      int64     `db:"user_id"`
+	PostID      int64     `db:"post_id"`
+	TimeZone    string    `db:"timezone"`
+	WindowStart int       `db:"window_start"`
+	WindowEnd   int       `db:"window_end"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (d Delivery) ToDomain() domain.Delivery {
+	return domain.Delivery{
+		ID:        d.ID,
+		UserID:    d.UserID,
+		PostID:    d.PostID,
+		TimeZone:  d.TimeZone,
+		Window:    domain.DeliveryWindow{Start: d.WindowStart, End: d.WindowEnd},
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+type DeliveryRepo interface {
+	// Enqueue adds pending deliveries of post for users, deliveries are linked to broadcast run unless runID is zero
+	Enqueue(ctx context.Context, runID, postID int64, userIDs []int64) error
+	// Pending returns page of pending deliveries of subscribed users with their time zone and window,
+	// oldest first. Page starts after delivery afterID, zero starts from the beginning.
+	Pending(ctx context.Context, afterID int64, limit int) ([]domain.Delivery, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+	// Expire marks deliveries pending since before as expired
+	Expire(ctx context.Context, before time.Time) (int64, error)
+	// Received returns users from userIDs which got post since given time or still wait for it
+	Received(ctx context.Context, postID int64, userIDs []int64, since time.Time) ([]int64, error)
+}
@@ -0,0 +1,132 @@
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type planRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) PlanRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &planRepo{db: db, qb: qb}
+}
+
+func (r *planRepo) SaveJob(ctx context.Context, in SaveJobInput) (domain.PlanJob, error) {
+	params, err := json.Marshal(in.Params)
+	if err != nil {
+		return domain.PlanJob{}, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	query, args := r.qb.
+		Insert("plan_jobs").
+		Columns("job_id", "params", "total", "created_by").
+		Values(in.ID, params, len(in.Params.Slots), sql.NullString{String: in.CreatedBy, Valid: in.CreatedBy != ""}).
+		Suffix(jobReturning).
+		MustSql()
+
+	var job Job
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		return domain.PlanJob{}, fmt.Errorf("failed to save job: %w", err)
+	}
+	return job.ToDomain()
+}
+
+func (r *planRepo) JobByID(ctx context.Context, id string) (domain.PlanJob, error) {
+	query, args := r.qb.Select(jobColumns...).From("plan_jobs").Where(sq.Eq{"job_id": id}).MustSql()
+
+	var job Job
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PlanJob{}, domain.ErrJobNotFound
+		}
+		return domain.PlanJob{}, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job.ToDomain()
+}
+
+// ClaimJob marks the oldest pending job as running, so it is never processed twice
+func (r *planRepo) ClaimJob(ctx context.Context) (domain.PlanJob, error) {
+	sub, subArgs := sq.
+		Select("job_id").
+		From("plan_jobs").
+		Where(sq.Eq{"status": domain.JobStatusPending}).
+		OrderBy("created_at").
+		Limit(1).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		MustSql()
+
+	query, args := r.qb.
+		Update("plan_jobs").
+		Set("status", domain.JobStatusRunning).
+		Where("job_id = ("+sub+")", subArgs...).
+		Suffix(jobReturning).
+		MustSql()
+
+	var job Job
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PlanJob{}, domain.ErrNoJobs
+		}
+		return domain.PlanJob{}, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return job.ToDomain()
+}
+
+func (r *planRepo) UpdateProgress(ctx context.Context, id string, completed, failed int) error {
+	query, args := r.qb.
+		Update("plan_jobs").
+		Set("completed", completed).
+		Set("failed", failed).
+		Where(sq.Eq{"job_id": id}).
+		MustSql()
+	return r.execOrNotFound(ctx, query, args)
+}
+
+func (r *planRepo) FinishJob(ctx context.Context, id string, status domain.JobStatus, errMsg string) error {
+	query, args := r.qb.
+		Update("plan_jobs").
+		Set("status", status).
+		Set("error", sql.NullString{String: errMsg, Valid: errMsg != ""}).
+		Set("finished_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"job_id": id}).
+		MustSql()
+	return r.execOrNotFound(ctx, query, args)
+}
+
+// ResetRunning returns jobs interrupted by restart back to the queue, progress is kept.
+// It must be called only by the single plan worker, otherwise jobs of a live worker are processed twice.
+func (r *planRepo) ResetRunning(ctx context.Context) error {
+	query, args := r.qb.
+		Update("plan_jobs").
+		Set("status", domain.JobStatusPending).
+		Where(sq.Eq{"status": domain.JobStatusRunning}).
+		MustSql()
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *planRepo) execOrNotFound(ctx context.Context, query string, args []any) error {
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrJobNotFound
+	}
+	return nil
+}
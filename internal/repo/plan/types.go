@@ -0,0 +1,66 @@
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type SaveJobInput struct {
+	ID        string
+	Params    domain.PlanParams
+	CreatedBy string
+}
+
+type Job struct {
+	ID         string           `db:"job_id"`
+	Status     domain.JobStatus `db:"status"`
+	Params     []byte           `db:"params"`
+	Total      int              `db:"total"`
+	Completed  int              `db:"completed"`
+	Failed     int              `db:"failed"`
+	Error      sql.NullString   `db:"error"`
+	CreatedBy  sql.NullString   `db:"created_by"`
+	CreatedAt  time.Time        `db:"created_at"`
+	FinishedAt sql.NullTime     `db:"finished_at"`
+}
+
+var jobColumns = []string{"job_id", "status", "params", "total", "completed", "failed", "error", "created_by", "created_at", "finished_at"}
+
+var jobReturning = "RETURNING " + strings.Join(jobColumns, ", ")
+
+func (j Job) ToDomain() (domain.PlanJob, error) {
+	job := domain.PlanJob{
+		ID:        j.ID,
+		Status:    j.Status,
+		Total:     j.Total,
+		Completed: j.Completed,
+		Failed:    j.Failed,
+		Error:     j.Error.String,
+		CreatedBy: j.CreatedBy.String,
+		CreatedAt: j.CreatedAt,
+	}
+	if j.Total > 0 {
+		job.Progress = (j.Completed + j.Failed) * 100 / j.Total
+	}
+	if j.FinishedAt.Valid {
+		job.FinishedAt = &j.FinishedAt.Time
+	}
+	if err := json.Unmarshal(j.Params, &job.Params); err != nil {
+		return domain.PlanJob{}, err
+	}
+	return job, nil
+}
+
+type PlanRepo interface {
+	SaveJob(ctx context.Context, in SaveJobInput) (domain.PlanJob, error)
+	JobByID(ctx context.Context, id string) (domain.PlanJob, error)
+	ClaimJob(ctx context.Context) (domain.PlanJob, error)
+	UpdateProgress(ctx context.Context, id string, completed, failed int) error
+	FinishJob(ctx context.Context, id string, status domain.JobStatus, errMsg string) error
+	ResetRunning(ctx context.Context) error
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
@@ -24,10 +25,11 @@ func New(db *sqlx.DB) PostRepo {
 
 func (r *postRepo) LatestByAudience(ctx context.Context, audience domain.UserLvl) (domain.Post, error) {
 	query, args := r.qb.
-		Select("post_id", "content", "audience", "images", "created_at", "posted").
+		Select(postColumns...).
 		From("posts").
-		Where(sq.Eq{"audience": audience, "posted": false}).
-		OrderBy("created_at DESC").
+		Where(sq.Eq{"audience": audience, "posted": false, "status": domain.PostStatusApproved}).
+		Where(sq.Or{sq.Eq{"scheduled_for": nil}, sq.Expr("scheduled_for <= NOW()")}).
+		OrderBy("scheduled_for ASC NULLS LAST", "created_at DESC").
 		Limit(1).
 		MustSql()
 
@@ -41,31 +43,215 @@ func (r *postRepo) LatestByAudience(ctx context.Context, audience domain.UserLvl
 	return post.ToDomain(), nil
 }
 
+func (r *postRepo) LeastRecentEvergreen(ctx context.Context, audience domain.UserLvl, sentBefore time.Time) (domain.Post, error) {
+	query, args := r.qb.
+		Select(postColumns...).
+		From("posts").
+		Where(sq.Eq{"audience": audience, "evergreen": true, "status": domain.PostStatusApproved}).
+		Where(sq.Or{sq.Eq{"last_sent_at": nil}, sq.LtOrEq{"last_sent_at": sentBefore}}).
+		Where(sq.Or{sq.Eq{"scheduled_for": nil}, sq.Expr("scheduled_for <= NOW()")}).
+		OrderBy("last_sent_at ASC NULLS FIRST", "post_id").
+		Limit(1).
+		MustSql()
+
+	var post Post
+	if err := r.db.GetContext(ctx, &post, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Post{}, domain.ErrNoPosts
+		}
+		return domain.Post{}, fmt.Errorf("failed to get evergreen post: %w", err)
+	}
+	return post.ToDomain(), nil
+}
+
 func (r *postRepo) MarkAsPosted(ctx context.Context, id int64) error {
-	query, args := r.qb.Update("posts").Set("posted", true).Where(sq.Eq{"post_id": id}).MustSql()
+	query, args := r.qb.
+		Update("posts").
+		Set("posted", true).
+		Set("last_sent_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"post_id": id}).
+		MustSql()
 	return r.execOrNotFound(ctx, query, args)
 }
 
+func (r *postRepo) SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error) {
+	query, args := r.qb.
+		Update("posts").
+		Set("evergreen", evergreen).
+		Where(sq.Eq{"post_id": id}).
+		Suffix(postReturning).
+		MustSql()
+
+	var post Post
+	if err := r.db.GetContext(ctx, &post, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Post{}, domain.ErrPostNotFound
+		}
+		return domain.Post{}, fmt.Errorf("failed to set evergreen: %w", err)
+	}
+	return post.ToDomain(), nil
+}
+
 func (r *postRepo) Save(ctx context.Context, in SavePostInput) (domain.Post, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	status := in.Status
+	if status == "" {
+		status = domain.PostStatusApproved
+	}
+	review, err := marshalReview(in.Review)
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("failed to marshal review: %w", err)
+	}
 	query, args := r.qb.
 		Insert("posts").
-		Columns("content", "audience", "images").
-		Values(in.Content, in.Audience, pq.Array(in.Images)).
-		Suffix("RETURNING post_id, content, audience, images").
+		Columns("content", "audience", "images", "status", "scheduled_for", "job_id", "prompt_id", "prompt_version", "review", "source_post_id").
+		Values(
+			in.Content, in.Audience, pq.Array(in.Images), status, in.ScheduledFor,
+			sql.NullString{String: in.JobID, Valid: in.JobID != ""},
+			sql.NullInt64{Int64: in.PromptID, Valid: in.PromptID != 0},
+			sql.NullInt32{Int32: int32(in.PromptVersion), Valid: in.PromptVersion != 0},
+			review,
+			sql.NullInt64{Int64: in.SourceID, Valid: in.SourceID != 0},
+		).
+		Suffix(postReturning).
 		MustSql()
 
 	post := Post{}
-	if err := r.db.GetContext(ctx, &post, query, args...); err != nil {
+	if err := tx.GetContext(ctx, &post, query, args...); err != nil {
 		return domain.Post{}, fmt.Errorf("failed to save post: %w", err)
 	}
+	if err := r.saveRevision(ctx, tx, post, in.Author); err != nil {
+		return domain.Post{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Post{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return post.ToDomain(), nil
+}
+
+func (r *postRepo) Update(ctx context.Context, id int64, in UpdatePostInput) (domain.Post, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	review, err := marshalReview(in.Review)
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("failed to marshal review: %w", err)
+	}
+	query, args := r.qb.
+		Update("posts").
+		Set("content", in.Content).
+		Set("audience", in.Audience).
+		Set("images", pq.Array(in.Images)).
+		Set("review", review).
+		Where(sq.Eq{"post_id": id}).
+		Suffix(postReturning).
+		MustSql()
+
+	post := Post{}
+	if err := tx.GetContext(ctx, &post, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Post{}, domain.ErrPostNotFound
+		}
+		return domain.Post{}, fmt.Errorf("failed to update post: %w", err)
+	}
+	if err := r.saveRevision(ctx, tx, post, in.Author); err != nil {
+		return domain.Post{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Post{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return post.ToDomain(), nil
+}
+
+func (r *postRepo) PostByID(ctx context.Context, id int64) (domain.Post, error) {
+	query, args := r.qb.
+		Select(postColumns...).
+		From("posts").
+		Where(sq.Eq{"post_id": id}).
+		MustSql()
+
+	var post Post
+	if err := r.db.GetContext(ctx, &post, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Post{}, domain.ErrPostNotFound
+		}
+		return domain.Post{}, fmt.Errorf("failed to get post: %w", err)
+	}
 	return post.ToDomain(), nil
 }
 
+func (r *postRepo) Revisions(ctx context.Context, postID int64) ([]domain.PostRevision, error) {
+	query, args := r.qb.
+		Select("post_id", "version", "content", "audience", "images", "author", "created_at").
+		From("post_revisions").
+		Where(sq.Eq{"post_id": postID}).
+		OrderBy("version DESC").
+		MustSql()
+
+	var revisions []Revision
+	if err := r.db.SelectContext(ctx, &revisions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	return mapRevisionsToDomain(revisions), nil
+}
+
+func (r *postRepo) Revision(ctx context.Context, postID int64, version int) (domain.PostRevision, error) {
+	query, args := r.qb.
+		Select("post_id", "version", "content", "audience", "images", "author", "created_at").
+		From("post_revisions").
+		Where(sq.Eq{"post_id": postID, "version": version}).
+		MustSql()
+
+	var revision Revision
+	if err := r.db.GetContext(ctx, &revision, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PostRevision{}, domain.ErrRevisionNotFound
+		}
+		return domain.PostRevision{}, fmt.Errorf("failed to get revision: %w", err)
+	}
+	return revision.ToDomain(), nil
+}
+
+// saveRevision must be called after the post row is locked by insert or update in the same tx
+func (r *postRepo) saveRevision(ctx context.Context, tx *sqlx.Tx, post Post, author string) error {
+	query, args := r.qb.
+		Select("COALESCE(MAX(version), 0) + 1").
+		From("post_revisions").
+		Where(sq.Eq{"post_id": post.ID}).
+		MustSql()
+
+	var version int
+	if err := tx.GetContext(ctx, &version, query, args...); err != nil {
+		return fmt.Errorf("failed to get next revision: %w", err)
+	}
+
+	query, args = r.qb.
+		Insert("post_revisions").
+		Columns("post_id", "version", "content", "audience", "images", "author").
+		Values(post.ID, version, post.Content, post.Audience, post.Images, sql.NullString{String: author, Valid: author != ""}).
+		MustSql()
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save revision: %w", err)
+	}
+	return nil
+}
+
 func (r *postRepo) Remove(ctx context.Context, id int64) (domain.Post, error) {
 	query, args := r.qb.
 		Delete("posts").
 		Where(sq.Eq{"post_id": id}).
-		Suffix("RETURNING post_id, content, audience, images").
+		Suffix(postReturning).
 		MustSql()
 
 	var post Post
@@ -96,9 +282,9 @@ func (r *postRepo) execOrNotFound(ctx context.Context, query string, args []any)
 
 func (r *postRepo) List(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error) {
 	query, args := r.qb.
-		Select("post_id", "content", "audience", "images").
+		Select(postColumns...).
 		From("posts").
-		Where(sq.Eq{"audience": audience, "posted": !incoming}).
+		Where(sq.Eq{"audience": audience, "posted": !incoming, "status": domain.PostStatusApproved}).
 		OrderBy("created_at DESC").
 		MustSql()
 
@@ -111,3 +297,119 @@ func (r *postRepo) List(ctx context.Context, audience domain.UserLvl, incoming b
 	}
 	return mapPostsToDomain(posts), nil
 }
+
+func (r *postRepo) CountQueued(ctx context.Context) (map[domain.UserLvl]int, error) {
+	// same conditions as LatestByAudience, so depth is what scheduler can send
+	counts, err := r.countByAudience(ctx, sq.And{
+		sq.Eq{"posted": false, "status": domain.PostStatusApproved},
+		sq.Or{sq.Eq{"scheduled_for": nil}, sq.Expr("scheduled_for <= NOW()")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count queued posts: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *postRepo) CountDrafts(ctx context.Context) (map[domain.UserLvl]int, error) {
+	counts, err := r.countByAudience(ctx, draftsToReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count drafts: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *postRepo) countByAudience(ctx context.Context, where sq.Sqlizer) (map[domain.UserLvl]int, error) {
+	query, args := countByAudienceQuery(r.qb, where).MustSql()
+
+	var counts []QueueCount
+	if err := r.db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, err
+	}
+	res := make(map[domain.UserLvl]int, len(counts))
+	for _, count := range counts {
+		res[count.Audience] = count.Posts
+	}
+	return res, nil
+}
+
+func (r *postRepo) Drafts(ctx context.Context, jobID string) ([]domain.Post, error) {
+	q := r.qb.
+		Select(postColumns...).
+		From("posts").
+		Where(sq.Eq{"status": domain.PostStatusDraft}).
+		OrderBy("scheduled_for ASC NULLS LAST", "created_at DESC")
+	if jobID != "" {
+		q = q.Where(sq.Eq{"job_id": jobID})
+	}
+	query, args := q.MustSql()
+
+	var posts []Post
+	if err := r.db.SelectContext(ctx, &posts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get drafts: %w", err)
+	}
+	return mapPostsToDomain(posts), nil
+}
+
+func (r *postRepo) Approve(ctx context.Context, id int64, review *domain.Review) (domain.Post, error) {
+	q := r.qb.
+		Update("posts").
+		Set("status", domain.PostStatusApproved).
+		Where(sq.Eq{"post_id": id}).
+		Suffix(postReturning)
+	if review != nil {
+		raw, err := marshalReview(review)
+		if err != nil {
+			return domain.Post{}, fmt.Errorf("failed to marshal review: %w", err)
+		}
+		q = q.Set("review", raw)
+	}
+	query, args := q.MustSql()
+
+	var post Post
+	if err := r.db.GetContext(ctx, &post, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Post{}, domain.ErrPostNotFound
+		}
+		return domain.Post{}, fmt.Errorf("failed to approve post: %w", err)
+	}
+	return post.ToDomain(), nil
+}
+
+func (r *postRepo) ApproveJob(ctx context.Context, jobID string, skipFailed bool) (int64, error) {
+	query, args := approveJobQuery(r.qb, jobID, skipFailed).MustSql()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to approve job posts: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// reviewNotFailed is false for posts without review too, review->>'verdict' of them is null
+var reviewNotFailed = sq.NotEq{"review->>'verdict'": domain.ReviewVerdictFail}
+
+// draftsToReview are drafts which can still be approved, drafts failed review are left for admin to remove
+var draftsToReview = sq.And{
+	sq.Eq{"status": domain.PostStatusDraft},
+	sq.Or{sq.Eq{"review": nil}, reviewNotFailed},
+}
+
+func countByAudienceQuery(qb sq.StatementBuilderType, where sq.Sqlizer) sq.SelectBuilder {
+	return qb.
+		Select("audience", "COUNT(*) AS posts").
+		From("posts").
+		Where(where).
+		GroupBy("audience")
+}
+
+func approveJobQuery(qb sq.StatementBuilderType, jobID string, skipFailed bool) sq.UpdateBuilder {
+	q := qb.
+		Update("posts").
+		Set("status", domain.PostStatusApproved).
+		Where(sq.Eq{"job_id": jobID, "status": domain.PostStatusDraft})
+	if skipFailed {
+		// draft without review was not checked, e.g. reviewer was down, it is approved one by one after review
+		q = q.Where(sq.NotEq{"review": nil}).Where(reviewNotFailed)
+	}
+	return q
+}
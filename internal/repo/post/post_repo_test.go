@@ -0,0 +1,33 @@
+package post
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveJobQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	t.Run("skip failed and not reviewed", func(t *testing.T) {
+		query, args := approveJobQuery(qb, "job", true).MustSql()
+		assert.Equal(t, "UPDATE posts SET status = $1 WHERE job_id = $2 AND status = $3 AND review IS NOT NULL AND review->>'verdict' <> $4", query)
+		assert.Equal(t, []any{domain.PostStatusApproved, "job", domain.PostStatusDraft, domain.ReviewVerdictFail}, args)
+	})
+
+	t.Run("all drafts", func(t *testing.T) {
+		query, _ := approveJobQuery(qb, "job", false).MustSql()
+		assert.Equal(t, "UPDATE posts SET status = $1 WHERE job_id = $2 AND status = $3", query)
+	})
+}
+
+func TestCountDraftsQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query, args := countByAudienceQuery(qb, draftsToReview).MustSql()
+	assert.Equal(t, "SELECT audience, COUNT(*) AS posts FROM posts "+
+		"WHERE (status = $1 AND (review IS NULL OR review->>'verdict' <> $2)) GROUP BY audience", query)
+	assert.Equal(t, []any{domain.PostStatusDraft, domain.ReviewVerdictFail}, args)
+}
@@ -2,6 +2,9 @@ package post
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
@@ -9,27 +12,84 @@ import (
 )
 
 type SavePostInput struct {
+	Content       string
+	Audience      domain.UserLvl
+	Images        []string
+	Author        string
+	Status        domain.PostStatus
+	ScheduledFor  *time.Time
+	JobID         string
+	PromptID      int64
+	PromptVersion int
+	Review        *domain.Review
+	SourceID      int64
+}
+
+type UpdatePostInput struct {
 	Content  string
 	Audience domain.UserLvl
 	Images   []string
+	Author   string
+	// Review replaces stored review, nil clears it
+	Review *domain.Review
 }
 
 type Post struct {
-	ID        int64          `db:"post_id"`
-	Content   string         `db:"content"`
-	Audience  domain.UserLvl `db:"audience"`
-	Images    pq.StringArray `db:"images"`
-	CreatedAt time.Time      `db:"created_at"`
-	Posted    bool           `db:"posted"`
+	ID            int64             `db:"post_id"`
+	Content       string            `db:"content"`
+	Audience      domain.UserLvl    `db:"audience"`
+	Images        pq.StringArray    `db:"images"`
+	CreatedAt     time.Time         `db:"created_at"`
+	Posted        bool              `db:"posted"`
+	Status        domain.PostStatus `db:"status"`
+	ScheduledFor  sql.NullTime      `db:"scheduled_for"`
+	JobID         sql.NullString    `db:"job_id"`
+	PromptID      sql.NullInt64     `db:"prompt_id"`
+	PromptVersion sql.NullInt32     `db:"prompt_version"`
+	Review        []byte            `db:"review"`
+	SourceID      sql.NullInt64     `db:"source_post_id"`
+	Evergreen     bool              `db:"evergreen"`
+	LastSentAt    sql.NullTime      `db:"last_sent_at"`
 }
 
+var postColumns = []string{"post_id", "content", "audience", "images", "created_at", "posted", "status", "scheduled_for", "job_id", "prompt_id", "prompt_version", "review", "source_post_id", "evergreen", "last_sent_at"}
+
+var postReturning = "RETURNING " + strings.Join(postColumns, ", ")
+
 func (p Post) ToDomain() domain.Post {
-	return domain.Post{
-		ID:       p.ID,
-		Content:  p.Content,
-		Audience: p.Audience,
-		Images:   p.Images,
+	post := domain.Post{
+		ID:            p.ID,
+		Content:       p.Content,
+		Audience:      p.Audience,
+		Images:        p.Images,
+		Status:        p.Status,
+		JobID:         p.JobID.String,
+		PromptID:      p.PromptID.Int64,
+		PromptVersion: int(p.PromptVersion.Int32),
+		SourceID:      p.SourceID.Int64,
+		Evergreen:     p.Evergreen,
 	}
+	if p.ScheduledFor.Valid {
+		post.ScheduledFor = &p.ScheduledFor.Time
+	}
+	if p.LastSentAt.Valid {
+		post.LastSentAt = &p.LastSentAt.Time
+	}
+	// review is written by marshalReview only, broken json is treated as missing review
+	if len(p.Review) > 0 {
+		var review domain.Review
+		if err := json.Unmarshal(p.Review, &review); err == nil {
+			post.Review = &review
+		}
+	}
+	return post
+}
+
+func marshalReview(review *domain.Review) ([]byte, error) {
+	if review == nil {
+		return nil, nil
+	}
+	return json.Marshal(review)
 }
 
 func mapPostsToDomain(posts []Post) []domain.Post {
@@ -40,10 +100,63 @@ func mapPostsToDomain(posts []Post) []domain.Post {
 	return res
 }
 
+type Revision struct {
+	PostID    int64          `db:"post_id"`
+	Version   int            `db:"version"`
+	Content   string         `db:"content"`
+	Audience  domain.UserLvl `db:"audience"`
+	Images    pq.StringArray `db:"images"`
+	Author    sql.NullString `db:"author"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (r Revision) ToDomain() domain.PostRevision {
+	return domain.PostRevision{
+		PostID:    r.PostID,
+		Version:   r.Version,
+		Content:   r.Content,
+		Audience:  r.Audience,
+		Images:    r.Images,
+		Author:    r.Author.String,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func mapRevisionsToDomain(revisions []Revision) []domain.PostRevision {
+	res := make([]domain.PostRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		res = append(res, revision.ToDomain())
+	}
+	return res
+}
+
+type QueueCount struct {
+	Audience domain.UserLvl `db:"audience"`
+	Posts    int            `db:"posts"`
+}
+
 type PostRepo interface {
 	LatestByAudience(ctx context.Context, audience domain.UserLvl) (domain.Post, error)
+	// LeastRecentEvergreen returns evergreen post of audience which was sent the longest ago and not after sentBefore
+	LeastRecentEvergreen(ctx context.Context, audience domain.UserLvl, sentBefore time.Time) (domain.Post, error)
+	// MarkAsPosted removes post from queue and records send time
 	MarkAsPosted(ctx context.Context, id int64) error
+	SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error)
 	Save(ctx context.Context, in SavePostInput) (domain.Post, error)
 	Remove(ctx context.Context, id int64) (domain.Post, error)
 	List(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error)
+	PostByID(ctx context.Context, id int64) (domain.Post, error)
+	Update(ctx context.Context, id int64, in UpdatePostInput) (domain.Post, error)
+	Revisions(ctx context.Context, postID int64) ([]domain.PostRevision, error)
+	Revision(ctx context.Context, postID int64, version int) (domain.PostRevision, error)
+	// CountQueued returns number of approved posts which were not sent and can be picked now by audience,
+	// posts scheduled for later are not counted
+	CountQueued(ctx context.Context) (map[domain.UserLvl]int, error)
+	// CountDrafts returns number of drafts waiting for review by audience, drafts failed review are not counted
+	CountDrafts(ctx context.Context) (map[domain.UserLvl]int, error)
+	Drafts(ctx context.Context, jobID string) ([]domain.Post, error)
+	// Approve moves draft to queue, review replaces stored one when it is not nil
+	Approve(ctx context.Context, id int64, review *domain.Review) (domain.Post, error)
+	// ApproveJob approves job drafts, drafts which failed review are kept when skipFailed is set
+	ApproveJob(ctx context.Context, jobID string, skipFailed bool) (int64, error)
 }
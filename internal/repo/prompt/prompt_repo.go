@@ -0,0 +1,224 @@
+package prompt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+type promptRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) PromptRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &promptRepo{db: db, qb: qb}
+}
+
+func (r *promptRepo) selectPrompts() sq.SelectBuilder {
+	return r.qb.
+		Select(promptColumns...).
+		From("prompts p").
+		Join("prompt_versions v ON v.prompt_id = p.prompt_id AND v.version = p.active_version")
+}
+
+func (r *promptRepo) Save(ctx context.Context, in SavePromptInput) (domain.Prompt, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Prompt{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args := r.qb.
+		Insert("prompts").
+		Columns("name", "description").
+		Values(in.Name, in.Description).
+		Suffix("RETURNING prompt_id").
+		MustSql()
+
+	var id int64
+	if err := tx.GetContext(ctx, &id, query, args...); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.Prompt{}, domain.ErrPromptAlreadyExists
+		}
+		return domain.Prompt{}, fmt.Errorf("failed to save prompt: %w", err)
+	}
+	if err := r.saveVersion(ctx, tx, id, 1, in.Template, in.Author); err != nil {
+		return domain.Prompt{}, err
+	}
+
+	prompt, err := r.promptByID(ctx, tx, id)
+	if err != nil {
+		return domain.Prompt{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Prompt{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return prompt, nil
+}
+
+func (r *promptRepo) List(ctx context.Context) ([]domain.Prompt, error) {
+	query, args := r.selectPrompts().OrderBy("p.name").MustSql()
+
+	var prompts []Prompt
+	if err := r.db.SelectContext(ctx, &prompts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get prompts: %w", err)
+	}
+	return mapPromptsToDomain(prompts), nil
+}
+
+func (r *promptRepo) PromptByID(ctx context.Context, id int64) (domain.Prompt, error) {
+	return r.promptByID(ctx, r.db, id)
+}
+
+func (r *promptRepo) promptByID(ctx context.Context, q sqlx.QueryerContext, id int64) (domain.Prompt, error) {
+	query, args := r.selectPrompts().Where(sq.Eq{"p.prompt_id": id}).MustSql()
+
+	var prompt Prompt
+	if err := sqlx.GetContext(ctx, q, &prompt, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Prompt{}, domain.ErrPromptNotFound
+		}
+		return domain.Prompt{}, fmt.Errorf("failed to get prompt: %w", err)
+	}
+	return prompt.ToDomain(), nil
+}
+
+func (r *promptRepo) Update(ctx context.Context, id int64, in UpdatePromptInput) (domain.Prompt, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Prompt{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	update := r.qb.Update("prompts").Set("updated_at", sq.Expr("NOW()")).Where(sq.Eq{"prompt_id": id})
+	if in.Description != nil {
+		update = update.Set("description", *in.Description)
+	}
+	if in.Template != nil {
+		update = update.Set("active_version", sq.Expr("(SELECT MAX(version) + 1 FROM prompt_versions WHERE prompt_id = ?)", id))
+	}
+	query, args := update.Suffix("RETURNING active_version").MustSql()
+
+	var version int
+	if err := tx.GetContext(ctx, &version, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Prompt{}, domain.ErrPromptNotFound
+		}
+		return domain.Prompt{}, fmt.Errorf("failed to update prompt: %w", err)
+	}
+	if in.Template != nil {
+		if err := r.saveVersion(ctx, tx, id, version, *in.Template, in.Author); err != nil {
+			return domain.Prompt{}, err
+		}
+	}
+
+	prompt, err := r.promptByID(ctx, tx, id)
+	if err != nil {
+		return domain.Prompt{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Prompt{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return prompt, nil
+}
+
+// saveVersion must be called after the prompt row is locked by insert or update in the same tx
+func (r *promptRepo) saveVersion(ctx context.Context, tx *sqlx.Tx, id int64, version int, template, author string) error {
+	query, args := r.qb.
+		Insert("prompt_versions").
+		Columns("prompt_id", "version", "template", "author").
+		Values(id, version, template, sql.NullString{String: author, Valid: author != ""}).
+		MustSql()
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save prompt version: %w", err)
+	}
+	return nil
+}
+
+func (r *promptRepo) Remove(ctx context.Context, id int64) error {
+	query, args := r.qb.Delete("prompts").Where(sq.Eq{"prompt_id": id}).MustSql()
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to remove prompt: %w", err)
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrPromptNotFound
+	}
+	return nil
+}
+
+func (r *promptRepo) Versions(ctx context.Context, id int64) ([]domain.PromptVersion, error) {
+	query, args := r.qb.
+		Select("prompt_id", "version", "template", "author", "created_at").
+		From("prompt_versions").
+		Where(sq.Eq{"prompt_id": id}).
+		OrderBy("version DESC").
+		MustSql()
+
+	var versions []Version
+	if err := r.db.SelectContext(ctx, &versions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get prompt versions: %w", err)
+	}
+	return mapVersionsToDomain(versions), nil
+}
+
+func (r *promptRepo) Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error) {
+	q := r.qb.
+		Select("v.prompt_id", "v.version", "v.template", "v.author", "v.created_at").
+		From("prompt_versions v").
+		Where(sq.Eq{"v.prompt_id": id})
+	if version == 0 {
+		q = q.Join("prompts p ON p.prompt_id = v.prompt_id AND p.active_version = v.version")
+	} else {
+		q = q.Where(sq.Eq{"v.version": version})
+	}
+	query, args := q.MustSql()
+
+	var v Version
+	if err := r.db.GetContext(ctx, &v, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PromptVersion{}, domain.ErrPromptVersionNotFound
+		}
+		return domain.PromptVersion{}, fmt.Errorf("failed to get prompt version: %w", err)
+	}
+	return v.ToDomain(), nil
+}
+
+func (r *promptRepo) Activate(ctx context.Context, id int64, version int) (domain.Prompt, error) {
+	query, args := r.qb.
+		Update("prompts").
+		Set("active_version", version).
+		Set("updated_at", sq.Expr("NOW()")).
+		Where(sq.Eq{"prompt_id": id}).
+		Where(sq.Expr("EXISTS (SELECT 1 FROM prompt_versions WHERE prompt_id = ? AND version = ?)", id, version)).
+		MustSql()
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return domain.Prompt{}, fmt.Errorf("failed to activate prompt version: %w", err)
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return domain.Prompt{}, err
+	}
+	if aff == 0 {
+		return domain.Prompt{}, domain.ErrPromptVersionNotFound
+	}
+	return r.PromptByID(ctx, id)
+}
@@ -0,0 +1,93 @@
+package prompt
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type SavePromptInput struct {
+	Name        string
+	Description string
+	Template    string
+	Author      string
+}
+
+type UpdatePromptInput struct {
+	Description *string
+	Template    *string
+	Author      string
+}
+
+type Prompt struct {
+	ID            int64     `db:"prompt_id"`
+	Name          string    `db:"name"`
+	Description   string    `db:"description"`
+	ActiveVersion int       `db:"active_version"`
+	Template      string    `db:"template"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// promptColumns selects prompt with template of active version joined as v
+var promptColumns = []string{"p.prompt_id", "p.name", "p.description", "p.active_version", "v.template", "p.created_at", "p.updated_at"}
+
+func (p Prompt) ToDomain() domain.Prompt {
+	return domain.Prompt{
+		ID:            p.ID,
+		Name:          p.Name,
+		Description:   p.Description,
+		ActiveVersion: p.ActiveVersion,
+		Template:      p.Template,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+func mapPromptsToDomain(prompts []Prompt) []domain.Prompt {
+	res := make([]domain.Prompt, 0, len(prompts))
+	for _, prompt := range prompts {
+		res = append(res, prompt.ToDomain())
+	}
+	return res
+}
+
+type Version struct {
+	PromptID  int64          `db:"prompt_id"`
+	Version   int            `db:"version"`
+	Template  string         `db:"template"`
+	Author    sql.NullString `db:"author"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (v Version) ToDomain() domain.PromptVersion {
+	return domain.PromptVersion{
+		PromptID:  v.PromptID,
+		Version:   v.Version,
+		Template:  v.Template,
+		Author:    v.Author.String,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func mapVersionsToDomain(versions []Version) []domain.PromptVersion {
+	res := make([]domain.PromptVersion, 0, len(versions))
+	for _, version := range versions {
+		res = append(res, version.ToDomain())
+	}
+	return res
+}
+
+type PromptRepo interface {
+	Save(ctx context.Context, in SavePromptInput) (domain.Prompt, error)
+	List(ctx context.Context) ([]domain.Prompt, error)
+	PromptByID(ctx context.Context, id int64) (domain.Prompt, error)
+	Update(ctx context.Context, id int64, in UpdatePromptInput) (domain.Prompt, error)
+	Remove(ctx context.Context, id int64) error
+	Versions(ctx context.Context, id int64) ([]domain.PromptVersion, error)
+	// Version returns active version when version is 0
+	Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error)
+	Activate(ctx context.Context, id int64, version int) (domain.Prompt, error)
+}
@@ -0,0 +1,258 @@
+package quiz
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const foreignKeyViolation = "23503"
+
+type quizRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) QuizRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &quizRepo{db: db, qb: qb}
+}
+
+func (r *quizRepo) Save(ctx context.Context, in SaveQuizInput) (domain.Quiz, error) {
+	questions, err := json.Marshal(in.Questions)
+	if err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to marshal questions: %w", err)
+	}
+	thresholds, err := json.Marshal(in.Thresholds)
+	if err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to marshal thresholds: %w", err)
+	}
+
+	query, args := r.qb.
+		Insert("quizzes").
+		Columns("questions", "thresholds", "author").
+		Values(questions, thresholds, sql.NullString{String: in.Author, Valid: in.Author != ""}).
+		Suffix("RETURNING version").
+		MustSql()
+
+	var version int
+	if err := r.db.GetContext(ctx, &version, query, args...); err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to save quiz: %w", err)
+	}
+	return r.QuizByVersion(ctx, version)
+}
+
+func (r *quizRepo) List(ctx context.Context) ([]domain.Quiz, error) {
+	query, args := r.qb.Select(quizColumns...).From("quizzes").OrderBy("version DESC").MustSql()
+
+	var entities []Quiz
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get quizzes: %w", err)
+	}
+
+	res := make([]domain.Quiz, 0, len(entities))
+	for _, entity := range entities {
+		quiz, err := entity.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, quiz)
+	}
+	return res, nil
+}
+
+func (r *quizRepo) QuizByVersion(ctx context.Context, version int) (domain.Quiz, error) {
+	return r.getQuiz(ctx, r.db, sq.Eq{"version": version})
+}
+
+func (r *quizRepo) Active(ctx context.Context) (domain.Quiz, error) {
+	return r.getQuiz(ctx, r.db, sq.Eq{"active": true})
+}
+
+func (r *quizRepo) getQuiz(ctx context.Context, q sqlx.QueryerContext, where sq.Sqlizer) (domain.Quiz, error) {
+	query, args := r.qb.Select(quizColumns...).From("quizzes").Where(where).MustSql()
+
+	var entity Quiz
+	if err := sqlx.GetContext(ctx, q, &entity, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Quiz{}, domain.ErrQuizNotFound
+		}
+		return domain.Quiz{}, fmt.Errorf("failed to get quiz: %w", err)
+	}
+	return entity.ToDomain()
+}
+
+func (r *quizRepo) Activate(ctx context.Context, version int) (domain.Quiz, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args := r.qb.Update("quizzes").Set("active", false).Where(sq.Eq{"active": true}).MustSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to deactivate quiz: %w", err)
+	}
+
+	query, args = r.qb.Update("quizzes").Set("active", true).Where(sq.Eq{"version": version}).MustSql()
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to activate quiz: %w", err)
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return domain.Quiz{}, err
+	}
+	if aff == 0 {
+		return domain.Quiz{}, domain.ErrQuizNotFound
+	}
+
+	quiz, err := r.getQuiz(ctx, tx, sq.Eq{"version": version})
+	if err != nil {
+		return domain.Quiz{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return quiz, nil
+}
+
+func (r *quizRepo) Remove(ctx context.Context, version int) error {
+	query, args := r.qb.Delete("quizzes").Where(sq.Eq{"version": version}).MustSql()
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return domain.ErrQuizInUse
+		}
+		return fmt.Errorf("failed to remove quiz: %w", err)
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrQuizNotFound
+	}
+	return nil
+}
+
+func (r *quizRepo) SaveResult(ctx context.Context, in SaveResultInput) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args := r.qb.
+		Update("users").
+		Set("lvl", in.Level).
+		Set("quiz_version", in.Version).
+		Where(sq.Eq{"user_id": in.UserID}).
+		MustSql()
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save quiz result: %w", err)
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	answers := make(pq.Int64Array, 0, len(in.Answers))
+	for _, answer := range in.Answers {
+		answers = append(answers, int64(answer))
+	}
+	query, args = r.qb.
+		Insert("quiz_attempts").
+		Columns("user_id", "version", "answers", "score", "lvl").
+		Values(in.UserID, in.Version, answers, in.Score, in.Level).
+		MustSql()
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save quiz attempt: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *quizRepo) LastAttempt(ctx context.Context, userID int64) (domain.QuizAttempt, error) {
+	query, args := r.qb.
+		Select(attemptColumns...).
+		From("quiz_attempts").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at DESC").
+		Limit(1).
+		MustSql()
+
+	var attempt Attempt
+	if err := r.db.GetContext(ctx, &attempt, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.QuizAttempt{}, domain.ErrQuizAttemptNotFound
+		}
+		return domain.QuizAttempt{}, fmt.Errorf("failed to get quiz attempt: %w", err)
+	}
+	return attempt.ToDomain(), nil
+}
+
+func (r *quizRepo) AnswerCounts(ctx context.Context, version int) ([]AnswerCount, error) {
+	query, args := r.qb.
+		Select("a.question - 1 AS question", "a.option", "COUNT(*) AS count").
+		From("quiz_attempts, unnest(answers) WITH ORDINALITY AS a(option, question)").
+		Where(sq.Eq{"version": version}).
+		GroupBy("a.question", "a.option").
+		OrderBy("a.question", "a.option").
+		MustSql()
+
+	var counts []AnswerCount
+	if err := r.db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to count answers: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *quizRepo) LevelStats(ctx context.Context, from time.Time, version int) ([]domain.LevelStats, error) {
+	q := r.qb.
+		Select("date_trunc('day', created_at AT TIME ZONE 'UTC') AS day", "lvl", "COUNT(*) AS count").
+		From("quiz_attempts").
+		Where(sq.GtOrEq{"created_at": from}).
+		GroupBy("day", "lvl").
+		OrderBy("day")
+	if version != 0 {
+		q = q.Where(sq.Eq{"version": version})
+	}
+	query, args := q.MustSql()
+
+	var rows []struct {
+		Day   time.Time      `db:"day"`
+		Level domain.UserLvl `db:"lvl"`
+		Count int            `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get level stats: %w", err)
+	}
+
+	res := make([]domain.LevelStats, 0)
+	for _, row := range rows {
+		day := time.Date(row.Day.Year(), row.Day.Month(), row.Day.Day(), 0, 0, 0, 0, time.UTC)
+		if n := len(res); n == 0 || !res[n-1].Day.Equal(day) {
+			res = append(res, domain.LevelStats{Day: day, Levels: make(map[domain.UserLvl]int)})
+		}
+		res[len(res)-1].Levels[row.Level] = row.Count
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package quiz
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/lib/pq"
+)
+
+type SaveQuizInput struct {
+	Questions  []domain.QuizQuestion
+	Thresholds []domain.QuizThreshold
+	Author     string
+}
+
+type SaveResultInput struct {
+	UserID  int64
+	Version int
+	Answers []int
+	Score   int
+	Level   domain.UserLvl
+}
+
+// AnswerCount is number of attempts where option was chosen, indexes start from 0
+type AnswerCount struct {
+	Question int `db:"question"`
+	Option   int `db:"option"`
+	Count    int `db:"count"`
+}
+
+type Attempt struct {
+	ID        int64          `db:"attempt_id"`
+	UserID    int64          `db:"user_id"`
+	Version   int            `db:"version"`
+	Answers   pq.Int64Array  `db:"answers"`
+	Score     int            `db:"score"`
+	Level     domain.UserLvl `db:"lvl"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+var attemptColumns = []string{"attempt_id", "user_id", "version", "answers", "score", "lvl", "created_at"}
+
+func (a Attempt) ToDomain() domain.QuizAttempt {
+	answers := make([]int, 0, len(a.Answers))
+	for _, answer := range a.Answers {
+		answers = append(answers, int(answer))
+	}
+	return domain.QuizAttempt{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Version:   a.Version,
+		Answers:   answers,
+		Score:     a.Score,
+		Level:     a.Level,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+type Quiz struct {
+	Version    int            `db:"version"`
+	Questions  []byte         `db:"questions"`
+	Thresholds []byte         `db:"thresholds"`
+	Active     bool           `db:"active"`
+	Author     sql.NullString `db:"author"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+var quizColumns = []string{"version", "questions", "thresholds", "active", "author", "created_at"}
+
+func (q Quiz) ToDomain() (domain.Quiz, error) {
+	quiz := domain.Quiz{
+		Version:   q.Version,
+		Active:    q.Active,
+		Author:    q.Author.String,
+		CreatedAt: q.CreatedAt,
+	}
+	if err := json.Unmarshal(q.Questions, &quiz.Questions); err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to unmarshal questions: %w", err)
+	}
+	if err := json.Unmarshal(q.Thresholds, &quiz.Thresholds); err != nil {
+		return domain.Quiz{}, fmt.Errorf("failed to unmarshal thresholds: %w", err)
+	}
+	return quiz, nil
+}
+
+type QuizRepo interface {
+	Save(ctx context.Context, in SaveQuizInput) (domain.Quiz, error)
+	List(ctx context.Context) ([]domain.Quiz, error)
+	QuizByVersion(ctx context.Context, version int) (domain.Quiz, error)
+	Active(ctx context.Context) (domain.Quiz, error)
+	Activate(ctx context.Context, version int) (domain.Quiz, error)
+	Remove(ctx context.Context, version int) error
+	// SaveResult saves attempt, updates user level and remembers version of the test user took
+	SaveResult(ctx context.Context, in SaveResultInput) error
+	LastAttempt(ctx context.Context, userID int64) (domain.QuizAttempt, error)
+	AnswerCounts(ctx context.Context, version int) ([]AnswerCount, error)
+	// LevelStats counts attempts by day and level since from, version 0 means all versions
+	LevelStats(ctx context.Context, from time.Time, version int) ([]domain.LevelStats, error)
+}
@@ -0,0 +1,83 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type scheduleRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) ScheduleRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &scheduleRepo{db: db, qb: qb}
+}
+
+func (r *scheduleRepo) List(ctx context.Context) ([]domain.Schedule, error) {
+	query, args := r.qb.Select(scheduleColumns...).From("schedules").OrderBy("audience").MustSql()
+
+	var entities []Schedule
+	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+	res := make([]domain.Schedule, 0, len(entities))
+	for _, entity := range entities {
+		res = append(res, entity.ToDomain())
+	}
+	return res, nil
+}
+
+func (r *scheduleRepo) ByAudience(ctx context.Context, audience domain.UserLvl) (domain.Schedule, error) {
+	query, args := r.qb.Select(scheduleColumns...).From("schedules").Where(sq.Eq{"audience": audience}).MustSql()
+
+	var entity Schedule
+	if err := r.db.GetContext(ctx, &entity, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Schedule{}, domain.ErrScheduleNotFound
+		}
+		return domain.Schedule{}, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	return entity.ToDomain(), nil
+}
+
+func (r *scheduleRepo) Save(ctx context.Context, in SaveScheduleInput) (domain.Schedule, error) {
+	query, args := r.qb.
+		Insert("schedules").
+		Columns("audience", "spec", "enabled", "updated_by").
+		Values(in.Audience, in.Spec, in.Enabled, in.UpdatedBy).
+		Suffix("ON CONFLICT (audience) DO UPDATE SET spec = EXCLUDED.spec, enabled = EXCLUDED.enabled, " +
+			"updated_by = EXCLUDED.updated_by, updated_at = NOW()").
+		Suffix("RETURNING " + strings.Join(scheduleColumns, ", ")).
+		MustSql()
+
+	var entity Schedule
+	if err := r.db.GetContext(ctx, &entity, query, args...); err != nil {
+		return domain.Schedule{}, fmt.Errorf("failed to save schedule: %w", err)
+	}
+	return entity.ToDomain(), nil
+}
+
+func (r *scheduleRepo) SaveMissing(ctx context.Context, specs map[domain.UserLvl]string) error {
+	if len(specs) == 0 {
+		return nil
+	}
+	q := r.qb.Insert("schedules").Columns("audience", "spec")
+	for audience, spec := range specs {
+		q = q.Values(audience, spec)
+	}
+	query, args := q.Suffix("ON CONFLICT (audience) DO NOTHING").MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save default schedules: %w", err)
+	}
+	return nil
+}
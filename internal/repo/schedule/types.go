@@ -0,0 +1,45 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type Schedule struct {
+	Audience  domain.UserLvl `db:"audience"`
+	Spec      string         `db:"spec"`
+	Enabled   bool           `db:"enabled"`
+	UpdatedBy sql.NullString `db:"updated_by"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+var scheduleColumns = []string{"audience", "spec", "enabled", "updated_by", "updated_at"}
+
+func (s Schedule) ToDomain() domain.Schedule {
+	return domain.Schedule{
+		Audience:  s.Audience,
+		Spec:      s.Spec,
+		Enabled:   s.Enabled,
+		UpdatedBy: s.UpdatedBy.String,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+type SaveScheduleInput struct {
+	Audience  domain.UserLvl
+	Spec      string
+	Enabled   bool
+	UpdatedBy string
+}
+
+type ScheduleRepo interface {
+	List(ctx context.Context) ([]domain.Schedule, error)
+	ByAudience(ctx context.Context, audience domain.UserLvl) (domain.Schedule, error)
+	// Save creates or replaces schedule of audience
+	Save(ctx context.Context, in SaveScheduleInput) (domain.Schedule, error)
+	// SaveMissing creates schedules of audiences which have none, existing schedules are kept
+	SaveMissing(ctx context.Context, specs map[domain.UserLvl]string) error
+}
@@ -0,0 +1,48 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type Usage struct {
+	Day              time.Time `db:"day"`
+	Requests         int       `db:"requests"`
+	PromptTokens     int64     `db:"prompt_tokens"`
+	CompletionTokens int64     `db:"completion_tokens"`
+	TotalTokens      int64     `db:"total_tokens"`
+}
+
+var usageColumns = []string{"day", "requests", "prompt_tokens", "completion_tokens", "total_tokens"}
+
+func (u Usage) ToDomain() domain.Usage {
+	return domain.Usage{
+		Day:      u.Day,
+		Requests: u.Requests,
+		TokenUsage: domain.TokenUsage{
+			PromptTokens:     u.PromptTokens,
+			CompletionTokens: u.CompletionTokens,
+			TotalTokens:      u.TotalTokens,
+		},
+	}
+}
+
+func mapUsageToDomain(usage []Usage) []domain.Usage {
+	res := make([]domain.Usage, 0, len(usage))
+	for _, u := range usage {
+		res = append(res, u.ToDomain())
+	}
+	return res
+}
+
+type UsageRepo interface {
+	// Reserve counts one request of admin for the day, it reports false without counting when quota is used
+	Reserve(ctx context.Context, admin string, day time.Time, quota domain.Quota) (bool, error)
+	// Add increments admin tokens for the day, requests are counted by Reserve
+	Add(ctx context.Context, admin string, day time.Time, tokens domain.TokenUsage) error
+	// Usage returns empty usage when admin has no requests that day
+	Usage(ctx context.Context, admin string, day time.Time) (domain.Usage, error)
+	History(ctx context.Context, admin string, from time.Time) ([]domain.Usage, error)
+}
@@ -0,0 +1,104 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type usageRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) UsageRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &usageRepo{db: db, qb: qb}
+}
+
+func (r *usageRepo) Reserve(ctx context.Context, admin string, day time.Time, quota domain.Quota) (bool, error) {
+	query, args := reserveQuery(r.qb, admin, day, quota).MustSql()
+
+	var requests int
+	if err := r.db.GetContext(ctx, &requests, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve usage: %w", err)
+	}
+	return true, nil
+}
+
+func (r *usageRepo) Add(ctx context.Context, admin string, day time.Time, tokens domain.TokenUsage) error {
+	query, args := r.qb.
+		Insert("ai_usage").
+		Columns("admin", "day", "prompt_tokens", "completion_tokens", "total_tokens").
+		Values(admin, day, tokens.PromptTokens, tokens.CompletionTokens, tokens.TotalTokens).
+		Suffix(`ON CONFLICT (admin, day) DO UPDATE SET
+			prompt_tokens = ai_usage.prompt_tokens + EXCLUDED.prompt_tokens,
+			completion_tokens = ai_usage.completion_tokens + EXCLUDED.completion_tokens,
+			total_tokens = ai_usage.total_tokens + EXCLUDED.total_tokens`).
+		MustSql()
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add usage: %w", err)
+	}
+	return nil
+}
+
+func (r *usageRepo) Usage(ctx context.Context, admin string, day time.Time) (domain.Usage, error) {
+	query, args := r.qb.
+		Select(usageColumns...).
+		From("ai_usage").
+		Where(sq.Eq{"admin": admin, "day": day}).
+		MustSql()
+
+	var usage Usage
+	if err := r.db.GetContext(ctx, &usage, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Usage{Day: day}, nil
+		}
+		return domain.Usage{}, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return usage.ToDomain(), nil
+}
+
+func (r *usageRepo) History(ctx context.Context, admin string, from time.Time) ([]domain.Usage, error) {
+	query, args := r.qb.
+		Select(usageColumns...).
+		From("ai_usage").
+		Where(sq.Eq{"admin": admin}).
+		Where(sq.GtOrEq{"day": from}).
+		OrderBy("day DESC").
+		MustSql()
+
+	var usage []Usage
+	if err := r.db.SelectContext(ctx, &usage, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get usage history: %w", err)
+	}
+	return mapUsageToDomain(usage), nil
+}
+
+// reserveQuery checks quota and counts request in one statement, so concurrent requests can not take the same
+// last request of quota. Row is not updated and nothing is returned when quota is used.
+func reserveQuery(qb sq.StatementBuilderType, admin string, day time.Time, quota domain.Quota) sq.InsertBuilder {
+	within := sq.And{}
+	if quota.Requests > 0 {
+		within = append(within, sq.Lt{"ai_usage.requests": quota.Requests})
+	}
+	if quota.Tokens > 0 {
+		within = append(within, sq.Lt{"ai_usage.total_tokens": quota.Tokens})
+	}
+
+	return qb.
+		Insert("ai_usage").
+		Columns("admin", "day", "requests").
+		Values(admin, day, 1).
+		SuffixExpr(sq.ConcatExpr("ON CONFLICT (admin, day) DO UPDATE SET requests = ai_usage.requests + 1 WHERE ", within, " RETURNING requests"))
+}
@@ -0,0 +1,47 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		quota     domain.Quota
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:  "requests and tokens",
+			quota: domain.Quota{Requests: 10, Tokens: 1000},
+			wantQuery: "INSERT INTO ai_usage (admin,day,requests) VALUES ($1,$2,$3) " +
+				"ON CONFLICT (admin, day) DO UPDATE SET requests = ai_usage.requests + 1 " +
+				"WHERE (ai_usage.requests < $4 AND ai_usage.total_tokens < $5) RETURNING requests",
+			wantArgs: []any{"admin", day, 1, 10, int64(1000)},
+		},
+		{
+			name:  "no quota",
+			quota: domain.Quota{},
+			wantQuery: "INSERT INTO ai_usage (admin,day,requests) VALUES ($1,$2,$3) " +
+				"ON CONFLICT (admin, day) DO UPDATE SET requests = ai_usage.requests + 1 " +
+				"WHERE (1=1) RETURNING requests",
+			wantArgs: []any{"admin", day, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args := reserveQuery(qb, "admin", day, tc.quota).MustSql()
+			assert.Equal(t, tc.wantQuery, query)
+			assert.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
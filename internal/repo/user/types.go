@@ -7,13 +7,26 @@ import (
 )
 
 type User struct {
-	ID         int64          `db:"user_id"`
-	Lvl        domain.UserLvl `db:"lvl"`
-	Subscribed bool           `db:"subscribed"`
+	ID          int64                    `db:"user_id"`
+	Lvl         domain.UserLvl           `db:"lvl"`
+	Subscribed  bool                     `db:"subscribed"`
+	Frequency   domain.DeliveryFrequency `db:"frequency"`
+	TimeZone    string                   `db:"timezone"`
+	WindowStart int                      `db:"window_start"`
+	WindowEnd   int                      `db:"window_end"`
 }
 
+var userColumns = []string{"user_id", "lvl", "subscribed", "frequency", "timezone", "window_start", "window_end"}
+
 func (u User) ToDomain() domain.User {
-	return domain.User{ID: u.ID, Lvl: u.Lvl}
+	return domain.User{
+		ID:         u.ID,
+		Lvl:        u.Lvl,
+		Subscribed: u.Subscribed,
+		Frequency:  u.Frequency,
+		TimeZone:   u.TimeZone,
+		Window:     domain.DeliveryWindow{Start: u.WindowStart, End: u.WindowEnd},
+	}
 }
 
 func mapUsersToDomain(users []User) []domain.User {
@@ -25,9 +38,14 @@ func mapUsersToDomain(users []User) []domain.User {
 }
 
 type UserRepo interface {
-	SaveUser(ctx context.Context, id int64, lvl domain.UserLvl) error
+	SaveUser(ctx context.Context, id int64, lvl domain.UserLvl, timeZone string) error
 	UserExists(ctx context.Context, id int64) (bool, error)
 	UpdateSubscribed(ctx context.Context, id int64, subscribed bool) error
 	UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserLvl) error
+	UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error
+	UpdateTimeZone(ctx context.Context, id int64, timeZone string) error
+	UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error
+	// Subscribers returns subscribers whose delivery frequency allows to send them a post now
 	Subscribers(ctx context.Context, lvl domain.UserLvl, all bool) ([]domain.User, error)
+	UserByID(ctx context.Context, id int64) (domain.User, error)
 }
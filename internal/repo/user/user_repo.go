@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
@@ -20,8 +21,8 @@ func New(db *sqlx.DB) UserRepo {
 	return &userRepo{db: db, qb: qb}
 }
 
-func (r *userRepo) SaveUser(ctx context.Context, id int64, lvl domain.UserLvl) error {
-	query, args := r.qb.Insert("users").Columns("user_id", "lvl").Values(id, lvl).MustSql()
+func (r *userRepo) SaveUser(ctx context.Context, id int64, lvl domain.UserLvl, timeZone string) error {
+	query, args := r.qb.Insert("users").Columns("user_id", "lvl", "timezone").Values(id, lvl, timeZone).MustSql()
 	_, err := r.db.ExecContext(ctx, query, args...)
 	return err
 }
@@ -70,13 +71,37 @@ func (r *userRepo) UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserL
 	return nil
 }
 
+func (r *userRepo) UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error {
+	return r.update(ctx, id, map[string]any{"frequency": frequency})
+}
+
+func (r *userRepo) UpdateTimeZone(ctx context.Context, id int64, timeZone string) error {
+	return r.update(ctx, id, map[string]any{"timezone": timeZone})
+}
+
+func (r *userRepo) UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error {
+	return r.update(ctx, id, map[string]any{"window_start": window.Start, "window_end": window.End})
+}
+
+func (r *userRepo) update(ctx context.Context, id int64, values map[string]any) error {
+	query, args := r.qb.Update("users").SetMap(values).Where(sq.Eq{"user_id": id}).MustSql()
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
 func (r *userRepo) Subscribers(ctx context.Context, lvl domain.UserLvl, all bool) ([]domain.User, error) {
 	var entities []User
-	q := r.qb.Select("user_id", "lvl").From("users").Where(sq.Eq{"subscribed": true})
-	if !all {
-		q = q.Where(sq.Eq{"lvl": lvl})
-	}
-	query, args := q.MustSql()
+	query, args := subscribersQuery(r.qb, lvl, all, time.Now()).MustSql()
 
 	if err := r.db.SelectContext(ctx, &entities, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -86,3 +111,35 @@ func (r *userRepo) Subscribers(ctx context.Context, lvl domain.UserLvl, all bool
 	}
 	return mapUsersToDomain(entities), nil
 }
+
+func (r *userRepo) UserByID(ctx context.Context, id int64) (domain.User, error) {
+	var entity User
+	query, args := r.qb.Select(userColumns...).From("users").Where(sq.Eq{"user_id": id}).MustSql()
+	if err := r.db.GetContext(ctx, &entity, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.ErrUserNotFound
+		}
+		return domain.User{}, err
+	}
+	return entity.ToDomain(), nil
+}
+
+// subscribersQuery counts delivery from send time, users with limited frequency who still wait for
+// a queued post are skipped, so they do not get two posts at once
+func subscribersQuery(qb sq.StatementBuilderType, lvl domain.UserLvl, all bool, now time.Time) sq.SelectBuilder {
+	q := qb.Select(userColumns...).From("users").Where(sq.Eq{"subscribed": true}).Where(sq.Or{
+		sq.Eq{"frequency": domain.DeliveryFrequencyAll},
+		sq.And{
+			sq.Expr("NOT EXISTS (SELECT 1 FROM deliveries d WHERE d.user_id = users.user_id AND d.status = ?)", domain.DeliveryStatusPending),
+			sq.Or{
+				sq.Eq{"delivered_at": nil},
+				sq.And{sq.Eq{"frequency": domain.DeliveryFrequencyDaily}, sq.Lt{"delivered_at": now.Add(-domain.DeliveryFrequencyDaily.Interval())}},
+				sq.And{sq.Eq{"frequency": domain.DeliveryFrequencyWeekly}, sq.Lt{"delivered_at": now.Add(-domain.DeliveryFrequencyWeekly.Interval())}},
+			},
+		},
+	})
+	if !all {
+		q = q.Where(sq.Eq{"lvl": lvl})
+	}
+	return q
+}
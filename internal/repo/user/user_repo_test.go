@@ -0,0 +1,26 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribersQuery(t *testing.T) {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	query, args := subscribersQuery(qb, domain.UserLvlBeginner, false, now).MustSql()
+	assert.Equal(t, "SELECT user_id, lvl, subscribed, frequency, timezone, window_start, window_end FROM users WHERE subscribed = $1 AND (frequency = $2 OR "+
+		"(NOT EXISTS (SELECT 1 FROM deliveries d WHERE d.user_id = users.user_id AND d.status = $3) AND "+
+		"(delivered_at IS NULL OR (frequency = $4 AND delivered_at < $5) OR (frequency = $6 AND delivered_at < $7)))) AND lvl = $8", query)
+	assert.Equal(t, []any{
+		true, domain.DeliveryFrequencyAll, domain.DeliveryStatusPending,
+		domain.DeliveryFrequencyDaily, now.Add(-domain.DeliveryFrequencyDaily.Interval()),
+		domain.DeliveryFrequencyWeekly, now.Add(-domain.DeliveryFrequencyWeekly.Interval()),
+		domain.UserLvlBeginner,
+	}, args)
+}
@@ -0,0 +1,54 @@
+package workout
+
+import (
+	"context"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type SavePlanInput struct {
+	UserID      int64
+	Level       domain.UserLvl
+	Params      domain.WorkoutParams
+	Adjustments string
+	Content     string
+}
+
+type Plan struct {
+	ID          int64              `db:"plan_id"`
+	UserID      int64              `db:"user_id"`
+	Level       domain.UserLvl     `db:"lvl"`
+	Goal        domain.WorkoutGoal `db:"goal"`
+	DaysPerWeek int                `db:"days_per_week"`
+	Equipment   domain.Equipment   `db:"equipment"`
+	Injuries    string             `db:"injuries"`
+	Adjustments string             `db:"adjustments"`
+	Content     string             `db:"content"`
+	CreatedAt   time.Time          `db:"created_at"`
+}
+
+var planColumns = []string{"plan_id", "user_id", "lvl", "goal", "days_per_week", "equipment", "injuries", "adjustments", "content", "created_at"}
+
+func (p Plan) ToDomain() domain.WorkoutPlan {
+	return domain.WorkoutPlan{
+		ID:     p.ID,
+		UserID: p.UserID,
+		Level:  p.Level,
+		Params: domain.WorkoutParams{
+			Goal:        p.Goal,
+			DaysPerWeek: p.DaysPerWeek,
+			Equipment:   p.Equipment,
+			Injuries:    p.Injuries,
+		},
+		Adjustments: p.Adjustments,
+		Content:     p.Content,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+type WorkoutRepo interface {
+	Save(ctx context.Context, in SavePlanInput) (domain.WorkoutPlan, error)
+	Latest(ctx context.Context, userID int64) (domain.WorkoutPlan, error)
+	CountSince(ctx context.Context, userID int64, since time.Time) (int, error)
+}
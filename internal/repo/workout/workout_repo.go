@@ -0,0 +1,73 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type workoutRepo struct {
+	qb sq.StatementBuilderType
+	db *sqlx.DB
+}
+
+func New(db *sqlx.DB) WorkoutRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &workoutRepo{db: db, qb: qb}
+}
+
+func (r *workoutRepo) Save(ctx context.Context, in SavePlanInput) (domain.WorkoutPlan, error) {
+	query, args := r.qb.
+		Insert("workout_plans").
+		Columns("user_id", "lvl", "goal", "days_per_week", "equipment", "injuries", "adjustments", "content").
+		Values(in.UserID, in.Level, in.Params.Goal, in.Params.DaysPerWeek, in.Params.Equipment, in.Params.Injuries, in.Adjustments, in.Content).
+		Suffix("RETURNING " + strings.Join(planColumns, ", ")).
+		MustSql()
+
+	var plan Plan
+	if err := r.db.GetContext(ctx, &plan, query, args...); err != nil {
+		return domain.WorkoutPlan{}, fmt.Errorf("failed to save workout plan: %w", err)
+	}
+	return plan.ToDomain(), nil
+}
+
+func (r *workoutRepo) Latest(ctx context.Context, userID int64) (domain.WorkoutPlan, error) {
+	query, args := r.qb.
+		Select(planColumns...).
+		From("workout_plans").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("plan_id DESC").
+		Limit(1).
+		MustSql()
+
+	var plan Plan
+	if err := r.db.GetContext(ctx, &plan, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WorkoutPlan{}, domain.ErrWorkoutPlanNotFound
+		}
+		return domain.WorkoutPlan{}, fmt.Errorf("failed to get workout plan: %w", err)
+	}
+	return plan.ToDomain(), nil
+}
+
+func (r *workoutRepo) CountSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	query, args := r.qb.
+		Select("COUNT(*)").
+		From("workout_plans").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.GtOrEq{"created_at": since}).
+		MustSql()
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to count workout plans: %w", err)
+	}
+	return count, nil
+}
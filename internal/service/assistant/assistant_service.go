@@ -0,0 +1,141 @@
+package assistant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+)
+
+type AssistantRepo interface {
+	SaveMessages(ctx context.Context, userID int64, messages ...domain.AssistantMessage) error
+	History(ctx context.Context, userID int64, since time.Time, limit int) ([]domain.AssistantMessage, error)
+	CountQuestions(ctx context.Context, userID int64, since time.Time) (int, error)
+}
+
+type UserRepo interface {
+	UserByID(ctx context.Context, id int64) (domain.User, error)
+}
+
+type AiGenerator interface {
+	GenerateContent(ctx context.Context, req ai.Request) (ai.Response, error)
+}
+
+type Options struct {
+	// DailyLimit is number of questions per user a day, zero means no limit
+	DailyLimit int
+	// HistorySize is number of previous questions with answers passed to the model
+	HistorySize int
+	// MemoryTTL is how long previous messages are remembered
+	MemoryTTL time.Duration
+	// Prompt is system prompt of assistant
+	Prompt string
+}
+
+type service struct {
+	logger        *slog.Logger
+	assistantRepo AssistantRepo
+	userRepo      UserRepo
+	ai            AiGenerator
+	opts          Options
+}
+
+const maxQuestionLength = 1000
+
+var levelPrompts = map[domain.UserLvl]string{
+	domain.UserLvlDefault:      "уровень подготовки неизвестен, отвечай как новичку",
+	domain.UserLvlBeginner:     "новичок: простые слова, безопасные нагрузки, без сложных терминов",
+	domain.UserLvlIntermediate: "средний уровень, регулярно тренируется больше года",
+	domain.UserLvlAdvanced:     "опытный атлет, можно использовать профессиональные термины",
+}
+
+func New(logger *slog.Logger, assistantRepo AssistantRepo, userRepo UserRepo, ai AiGenerator, opts Options) *service {
+	return &service{logger, assistantRepo, userRepo, ai, opts}
+}
+
+// today is the limit day, days are counted in UTC
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// Ask answers user question taking into account user level and recent conversation
+func (s *service) Ask(ctx context.Context, userID int64, question string) (domain.AssistantAnswer, error) {
+	const op = "assistant.Ask"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return domain.AssistantAnswer{}, domain.ErrEmptyQuestion
+	}
+	if runes := []rune(question); len(runes) > maxQuestionLength {
+		question = string(runes[:maxQuestionLength])
+	}
+
+	asked, err := s.assistantRepo.CountQuestions(ctx, userID, today())
+	if err != nil {
+		logger.Error("failed to count questions", "error", err)
+		return domain.AssistantAnswer{}, err
+	}
+	if s.opts.DailyLimit > 0 && asked >= s.opts.DailyLimit {
+		return domain.AssistantAnswer{}, domain.ErrAskLimitExceeded
+	}
+
+	lvl := domain.UserLvlDefault
+	user, err := s.userRepo.UserByID(ctx, userID)
+	if err == nil {
+		lvl = user.Lvl
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		logger.Error("failed to get user", "error", err)
+		return domain.AssistantAnswer{}, err
+	}
+
+	history, err := s.assistantRepo.History(ctx, userID, time.Now().Add(-s.opts.MemoryTTL), s.opts.HistorySize*2)
+	if err != nil {
+		logger.Error("failed to get history", "error", err)
+		return domain.AssistantAnswer{}, err
+	}
+
+	resp, err := s.ai.GenerateContent(ctx, ai.Request{Prompt: buildAskPrompt(lvl, history, question), System: s.opts.Prompt})
+	if err != nil {
+		logger.Error("failed to generate answer", "error", err)
+		return domain.AssistantAnswer{}, err
+	}
+	answer := strings.TrimSpace(resp.Text)
+
+	// answer is already paid for, failed save only loses memory of this turn
+	if err := s.assistantRepo.SaveMessages(ctx, userID,
+		domain.AssistantMessage{Role: domain.AssistantRoleUser, Content: question},
+		domain.AssistantMessage{Role: domain.AssistantRoleAssistant, Content: answer},
+	); err != nil {
+		logger.Error("failed to save messages", "error", err)
+	}
+
+	remaining := -1
+	if s.opts.DailyLimit > 0 {
+		remaining = max(s.opts.DailyLimit-asked-1, 0)
+	}
+	return domain.AssistantAnswer{Text: answer, Remaining: remaining}, nil
+}
+
+func buildAskPrompt(lvl domain.UserLvl, history []domain.AssistantMessage, question string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Уровень подготовки собеседника: %s\n\n", levelPrompts[lvl])
+	if len(history) > 0 {
+		sb.WriteString("Предыдущие сообщения:\n")
+		for _, msg := range history {
+			if msg.Role == domain.AssistantRoleUser {
+				fmt.Fprintf(&sb, "Пользователь: %s\n", msg.Content)
+			} else {
+				fmt.Fprintf(&sb, "Ассистент: %s\n", msg.Content)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	fmt.Fprintf(&sb, "Вопрос: %s", question)
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package assistant_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/assistant"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/assistant/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssistantService_Ask(t *testing.T) {
+	type MockBehavior func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator)
+
+	opts := assistant.Options{DailyLimit: 3, HistorySize: 2, MemoryTTL: time.Hour, Prompt: "assistant prompt"}
+	history := []domain.AssistantMessage{
+		{Role: domain.AssistantRoleUser, Content: "How often to train?"},
+		{Role: domain.AssistantRoleAssistant, Content: "Three times a week."},
+	}
+
+	testCases := []struct {
+		name         string
+		question     string
+		opts         assistant.Options
+		mockBehavior MockBehavior
+		want         domain.AssistantAnswer
+		wantErr      error
+	}{
+		{
+			name:     "success",
+			question: "  What to eat after training?  ",
+			opts:     opts,
+			mockBehavior: func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountQuestions(mock.Anything, int64(1), mock.Anything).Return(1, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{ID: 1, Lvl: domain.UserLvlBeginner}, nil).Once()
+				repo.EXPECT().History(mock.Anything, int64(1), mock.Anything, 4).Return(history, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return req.System == "assistant prompt" &&
+						strings.Contains(req.Prompt, "новичок") &&
+						strings.Contains(req.Prompt, "Ассистент: Three times a week.") &&
+						strings.HasSuffix(req.Prompt, "Вопрос: What to eat after training?")
+				})).Return(ai.Response{Text: " Protein and carbs. "}, nil).Once()
+				repo.EXPECT().SaveMessages(mock.Anything, int64(1),
+					domain.AssistantMessage{Role: domain.AssistantRoleUser, Content: "What to eat after training?"},
+					domain.AssistantMessage{Role: domain.AssistantRoleAssistant, Content: "Protein and carbs."},
+				).Return(nil).Once()
+			},
+			want: domain.AssistantAnswer{Text: "Protein and carbs.", Remaining: 1},
+		},
+		{
+			name:     "no limit and unknown user",
+			question: "Is running good?",
+			opts:     assistant.Options{HistorySize: 2, MemoryTTL: time.Hour},
+			mockBehavior: func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountQuestions(mock.Anything, int64(1), mock.Anything).Return(50, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{}, domain.ErrUserNotFound).Once()
+				repo.EXPECT().History(mock.Anything, int64(1), mock.Anything, 4).Return(nil, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "уровень подготовки неизвестен") && !strings.Contains(req.Prompt, "Предыдущие сообщения")
+				})).Return(ai.Response{Text: "Yes."}, nil).Once()
+				repo.EXPECT().SaveMessages(mock.Anything, int64(1), mock.Anything, mock.Anything).Return(assert.AnError).Once()
+			},
+			want: domain.AssistantAnswer{Text: "Yes.", Remaining: -1},
+		},
+		{
+			name:         "empty question",
+			question:     "   ",
+			opts:         opts,
+			mockBehavior: func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {},
+			wantErr:      domain.ErrEmptyQuestion,
+		},
+		{
+			name:     "limit exceeded",
+			question: "One more?",
+			opts:     opts,
+			mockBehavior: func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountQuestions(mock.Anything, int64(1), mock.Anything).Return(3, nil).Once()
+			},
+			wantErr: domain.ErrAskLimitExceeded,
+		},
+		{
+			name:     "ai error",
+			question: "What to eat?",
+			opts:     opts,
+			mockBehavior: func(repo *mocks.AssistantRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountQuestions(mock.Anything, int64(1), mock.Anything).Return(0, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{ID: 1, Lvl: domain.UserLvlAdvanced}, nil).Once()
+				repo.EXPECT().History(mock.Anything, int64(1), mock.Anything, 4).Return(nil, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, ai.ErrUnavailable).Once()
+			},
+			wantErr: ai.ErrUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewAssistantRepo(t)
+			users := mocks.NewUserRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, users, gen)
+
+			svc := assistant.New(testutils.NewTestLogger(), repo, users, gen, tc.opts)
+			got, err := svc.Ask(context.Background(), 1, tc.question)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
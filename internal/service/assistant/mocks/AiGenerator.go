@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	ai "github.com/SergeyBogomolovv/fitflow/pkg/ai"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AiGenerator is an autogenerated mock type for the AiGenerator type
+type AiGenerator struct {
+	mock.Mock
+}
+
+type AiGenerator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AiGenerator) EXPECT() *AiGenerator_Expecter {
+	return &AiGenerator_Expecter{mock: &_m.Mock}
+}
+
+// GenerateContent provides a mock function with given fields: ctx, req
+func (_m *AiGenerator) GenerateContent(ctx context.Context, req ai.Request) (ai.Response, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateContent")
+	}
+
+	var r0 ai.Response
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ai.Request) (ai.Response, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ai.Request) ai.Response); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(ai.Response)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ai.Request) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AiGenerator_GenerateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateContent'
+type AiGenerator_GenerateContent_Call struct {
+	*mock.Call
+}
+
+// GenerateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req ai.Request
+func (_e *AiGenerator_Expecter) GenerateContent(ctx interface{}, req interface{}) *AiGenerator_GenerateContent_Call {
+	return &AiGenerator_GenerateContent_Call{Call: _e.mock.On("GenerateContent", ctx, req)}
+}
+
+func (_c *AiGenerator_GenerateContent_Call) Run(run func(ctx context.Context, req ai.Request)) *AiGenerator_GenerateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ai.Request))
+	})
+	return _c
+}
+
+func (_c *AiGenerator_GenerateContent_Call) Return(_a0 ai.Response, _a1 error) *AiGenerator_GenerateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AiGenerator_GenerateContent_Call) RunAndReturn(run func(context.Context, ai.Request) (ai.Response, error)) *AiGenerator_GenerateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAiGenerator creates a new instance of AiGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAiGenerator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AiGenerator {
+	mock := &AiGenerator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,220 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// AssistantRepo is an autogenerated mock type for the AssistantRepo type
+type AssistantRepo struct {
+	mock.Mock
+}
+
+type AssistantRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AssistantRepo) EXPECT() *AssistantRepo_Expecter {
+	return &AssistantRepo_Expecter{mock: &_m.Mock}
+}
+
+// CountQuestions provides a mock function with given fields: ctx, userID, since
+func (_m *AssistantRepo) CountQuestions(ctx context.Context, userID int64, since time.Time) (int, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountQuestions")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (int, error)); ok {
+		return rf(ctx, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) int); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssistantRepo_CountQuestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQuestions'
+type AssistantRepo_CountQuestions_Call struct {
+	*mock.Call
+}
+
+// CountQuestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - since time.Time
+func (_e *AssistantRepo_Expecter) CountQuestions(ctx interface{}, userID interface{}, since interface{}) *AssistantRepo_CountQuestions_Call {
+	return &AssistantRepo_CountQuestions_Call{Call: _e.mock.On("CountQuestions", ctx, userID, since)}
+}
+
+func (_c *AssistantRepo_CountQuestions_Call) Run(run func(ctx context.Context, userID int64, since time.Time)) *AssistantRepo_CountQuestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AssistantRepo_CountQuestions_Call) Return(_a0 int, _a1 error) *AssistantRepo_CountQuestions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AssistantRepo_CountQuestions_Call) RunAndReturn(run func(context.Context, int64, time.Time) (int, error)) *AssistantRepo_CountQuestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function with given fields: ctx, userID, since, limit
+func (_m *AssistantRepo) History(ctx context.Context, userID int64, since time.Time, limit int) ([]domain.AssistantMessage, error) {
+	ret := _m.Called(ctx, userID, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []domain.AssistantMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, int) ([]domain.AssistantMessage, error)); ok {
+		return rf(ctx, userID, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, int) []domain.AssistantMessage); ok {
+		r0 = rf(ctx, userID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AssistantMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, int) error); ok {
+		r1 = rf(ctx, userID, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssistantRepo_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type AssistantRepo_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - since time.Time
+//   - limit int
+func (_e *AssistantRepo_Expecter) History(ctx interface{}, userID interface{}, since interface{}, limit interface{}) *AssistantRepo_History_Call {
+	return &AssistantRepo_History_Call{Call: _e.mock.On("History", ctx, userID, since, limit)}
+}
+
+func (_c *AssistantRepo_History_Call) Run(run func(ctx context.Context, userID int64, since time.Time, limit int)) *AssistantRepo_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AssistantRepo_History_Call) Return(_a0 []domain.AssistantMessage, _a1 error) *AssistantRepo_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AssistantRepo_History_Call) RunAndReturn(run func(context.Context, int64, time.Time, int) ([]domain.AssistantMessage, error)) *AssistantRepo_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveMessages provides a mock function with given fields: ctx, userID, messages
+func (_m *AssistantRepo) SaveMessages(ctx context.Context, userID int64, messages ...domain.AssistantMessage) error {
+	_va := make([]interface{}, len(messages))
+	for _i := range messages {
+		_va[_i] = messages[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMessages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...domain.AssistantMessage) error); ok {
+		r0 = rf(ctx, userID, messages...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AssistantRepo_SaveMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMessages'
+type AssistantRepo_SaveMessages_Call struct {
+	*mock.Call
+}
+
+// SaveMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - messages ...domain.AssistantMessage
+func (_e *AssistantRepo_Expecter) SaveMessages(ctx interface{}, userID interface{}, messages ...interface{}) *AssistantRepo_SaveMessages_Call {
+	return &AssistantRepo_SaveMessages_Call{Call: _e.mock.On("SaveMessages",
+		append([]interface{}{ctx, userID}, messages...)...)}
+}
+
+func (_c *AssistantRepo_SaveMessages_Call) Run(run func(ctx context.Context, userID int64, messages ...domain.AssistantMessage)) *AssistantRepo_SaveMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]domain.AssistantMessage, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(domain.AssistantMessage)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *AssistantRepo_SaveMessages_Call) Return(_a0 error) *AssistantRepo_SaveMessages_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AssistantRepo_SaveMessages_Call) RunAndReturn(run func(context.Context, int64, ...domain.AssistantMessage) error) *AssistantRepo_SaveMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAssistantRepo creates a new instance of AssistantRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAssistantRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AssistantRepo {
+	mock := &AssistantRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -51,10 +51,10 @@ func (s *service) Login(ctx context.Context, login, password string) (string, er
 	return token, nil
 }
 
-func (s *service) AuthFunc(tokenString string) (context.Context, error) {
+func (s *service) AuthFunc(ctx context.Context, tokenString string) (context.Context, error) {
 	aud, err := auth.VerifyJWT(tokenString, s.jwtSecret)
 	if err != nil {
 		return nil, err
 	}
-	return context.WithValue(context.Background(), auth.AdminLoginKey{}, aud), nil
+	return context.WithValue(ctx, auth.AdminLoginKey{}, aud), nil
 }
@@ -0,0 +1,185 @@
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+)
+
+type PostRepo interface {
+	CountQueued(ctx context.Context) (map[domain.UserLvl]int, error)
+	CountDrafts(ctx context.Context) (map[domain.UserLvl]int, error)
+	Save(ctx context.Context, in postRepo.SavePostInput) (domain.Post, error)
+}
+
+type AutopilotRepo interface {
+	ReserveThemes(ctx context.Context, audience domain.UserLvl, count, total int) (int, error)
+}
+
+type Generator interface {
+	GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg notify.Message) error
+}
+
+type Options struct {
+	// MinQueued is number of approved posts in queue, audiences with fewer posts are refilled
+	MinQueued int
+	// Batch limits drafts generated for audience at once
+	Batch     int
+	Audiences []domain.UserLvl
+	// Themes are used in turn, rotation of every audience continues after restart
+	Themes   []string
+	Tone     domain.Tone
+	Length   domain.Length
+	Language string
+}
+
+// Author is recorded as author of autopilot drafts and as admin of its AI usage
+const Author = "autopilot"
+
+const eventAutopilotDrafts = "autopilot_drafts"
+
+type service struct {
+	logger        *slog.Logger
+	postRepo      PostRepo
+	autopilotRepo AutopilotRepo
+	gen           Generator
+	notifier      Notifier
+	opts          Options
+}
+
+func New(logger *slog.Logger, postRepo PostRepo, autopilotRepo AutopilotRepo, gen Generator, notifier Notifier, opts Options) *service {
+	return &service{logger, postRepo, autopilotRepo, gen, notifier, opts}
+}
+
+// Refill generates drafts for audiences with fewer than MinQueued approved posts.
+// Drafts waiting for review are counted as queue, so unreviewed drafts do not pile up.
+func (s *service) Refill(ctx context.Context) ([]domain.AutopilotRefill, error) {
+	const op = "autopilot.Refill"
+	logger := s.logger.With(slog.String("op", op))
+
+	if len(s.opts.Themes) == 0 {
+		return nil, nil
+	}
+	queued, err := s.postRepo.CountQueued(ctx)
+	if err != nil {
+		logger.Error("failed to count queued posts", "error", err)
+		return nil, err
+	}
+	drafts, err := s.postRepo.CountDrafts(ctx)
+	if err != nil {
+		logger.Error("failed to count drafts", "error", err)
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, auth.AdminLoginKey{}, Author)
+	res := make([]domain.AutopilotRefill, 0)
+	for _, audience := range s.opts.Audiences {
+		missing := min(s.opts.MinQueued-queued[audience]-drafts[audience], s.opts.Batch)
+		if missing <= 0 {
+			continue
+		}
+		refill, err := s.refill(ctx, audience, missing)
+		if err != nil {
+			return res, err
+		}
+		if len(refill.DraftIDs) == 0 {
+			continue
+		}
+		refill.Queued = queued[audience]
+		res = append(res, refill)
+		s.notify(ctx, logger, refill)
+	}
+	return res, nil
+}
+
+// refill generates count drafts for audience, it stops at the first failed generation.
+// Themes are reserved before generation, so themes of failed drafts are skipped in rotation.
+func (s *service) refill(ctx context.Context, audience domain.UserLvl, count int) (domain.AutopilotRefill, error) {
+	logger := s.logger.With(slog.String("op", "autopilot.refill"), slog.String("audience", string(audience)))
+
+	start, err := s.autopilotRepo.ReserveThemes(ctx, audience, count, len(s.opts.Themes))
+	if err != nil {
+		logger.Error("failed to reserve themes", "error", err)
+		return domain.AutopilotRefill{}, err
+	}
+
+	refill := domain.AutopilotRefill{Audience: audience, DraftIDs: []int64{}, Themes: []string{}}
+	for i := range count {
+		theme := s.opts.Themes[(start+i)%len(s.opts.Themes)]
+		post, err := s.generateDraft(ctx, audience, theme)
+		if err != nil {
+			logger.Warn("failed to generate draft", "theme", theme, "skipped", count-i, "error", err)
+			break
+		}
+		refill.DraftIDs = append(refill.DraftIDs, post.ID)
+		refill.Themes = append(refill.Themes, theme)
+	}
+
+	if len(refill.DraftIDs) > 0 {
+		logger.Info("autopilot drafts generated", "count", len(refill.DraftIDs))
+	}
+	return refill, nil
+}
+
+func (s *service) generateDraft(ctx context.Context, audience domain.UserLvl, theme string) (domain.Post, error) {
+	generated, err := s.gen.GenerateContent(ctx, domain.GenerateParams{
+		Theme:    theme,
+		Audience: audience,
+		Tone:     s.opts.Tone,
+		Length:   s.opts.Length,
+		Language: s.opts.Language,
+		// batch can be longer than themes list, cached post would be saved as duplicate draft
+		NoCache: true,
+	})
+	if err != nil {
+		return domain.Post{}, err
+	}
+
+	// autopilot posts are never approved automatically, admin reviews them as any other draft
+	return s.postRepo.Save(ctx, postRepo.SavePostInput{
+		Content:       generated.Content,
+		Audience:      audience,
+		Images:        []string{},
+		Author:        Author,
+		Status:        domain.PostStatusDraft,
+		PromptID:      generated.PromptID,
+		PromptVersion: generated.PromptVersion,
+		Review:        generated.Review,
+	})
+}
+
+func (s *service) notify(ctx context.Context, logger *slog.Logger, refill domain.AutopilotRefill) {
+	text := fmt.Sprintf("📝 Автопилот подготовил черновики для аудитории %s: %d. В очереди одобренных постов: %d. Черновики ожидают проверки.",
+		refill.Audience, len(refill.DraftIDs), refill.Queued)
+	if err := s.notifier.Notify(ctx, notify.Message{Event: eventAutopilotDrafts, Text: text, Data: refill}); err != nil {
+		logger.Error("failed to notify about autopilot drafts", "audience", refill.Audience, "error", err)
+	}
+}
+
+// Run refills queues every interval until ctx is done
+func (s *service) Run(ctx context.Context, interval time.Duration) {
+	const op = "autopilot.Run"
+	logger := s.logger.With(slog.String("op", op))
+	logger.Info("starting autopilot", "interval", interval, "min_queued", s.opts.MinQueued)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.Refill(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
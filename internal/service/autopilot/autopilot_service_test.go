@@ -0,0 +1,155 @@
+package autopilot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/autopilot"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/autopilot/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAutopilotService_Refill(t *testing.T) {
+	type MockBehavior func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier)
+
+	opts := autopilot.Options{
+		MinQueued: 3,
+		Batch:     5,
+		Audiences: []domain.UserLvl{domain.UserLvlBeginner, domain.UserLvlIntermediate},
+		Themes:    []string{"Сон", "Растяжка", "Протеин"},
+		Tone:      domain.ToneFriendly,
+		Length:    domain.LengthShort,
+		Language:  "ru",
+	}
+	generated := func(theme string) any {
+		return mock.MatchedBy(func(params domain.GenerateParams) bool {
+			return params.Theme == theme && params.Audience == domain.UserLvlBeginner && params.Tone == domain.ToneFriendly && params.NoCache
+		})
+	}
+	asAutopilot := mock.MatchedBy(func(ctx context.Context) bool {
+		return auth.AdminLogin(ctx) == autopilot.Author
+	})
+	draft := func(content string) postRepo.SavePostInput {
+		return postRepo.SavePostInput{
+			Content:  content,
+			Audience: domain.UserLvlBeginner,
+			Images:   []string{},
+			Author:   autopilot.Author,
+			Status:   domain.PostStatusDraft,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		opts         autopilot.Options
+		mockBehavior MockBehavior
+		want         []domain.AutopilotRefill
+		wantErr      error
+	}{
+		{
+			name: "success",
+			opts: opts,
+			mockBehavior: func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{
+					domain.UserLvlBeginner:     1,
+					domain.UserLvlIntermediate: 1,
+				}, nil).Once()
+				// intermediate drafts are waiting for review already
+				posts.EXPECT().CountDrafts(mock.Anything).Return(map[domain.UserLvl]int{domain.UserLvlIntermediate: 2}, nil).Once()
+				repo.EXPECT().ReserveThemes(mock.Anything, domain.UserLvlBeginner, 2, 3).Return(1, nil).Once()
+				gen.EXPECT().GenerateContent(asAutopilot, generated("Растяжка")).Return(domain.GeneratedPost{Content: "stretching"}, nil).Once()
+				posts.EXPECT().Save(mock.Anything, draft("stretching")).Return(domain.Post{ID: 10}, nil).Once()
+				gen.EXPECT().GenerateContent(asAutopilot, generated("Протеин")).Return(domain.GeneratedPost{Content: "protein"}, nil).Once()
+				posts.EXPECT().Save(mock.Anything, draft("protein")).Return(domain.Post{ID: 11}, nil).Once()
+				notifier.EXPECT().Notify(mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+					return msg.Event == "autopilot_drafts" && msg.Text ==
+						"📝 Автопилот подготовил черновики для аудитории beginner: 2. В очереди одобренных постов: 1. Черновики ожидают проверки."
+				})).Return(assert.AnError).Once()
+			},
+			want: []domain.AutopilotRefill{{
+				Audience: domain.UserLvlBeginner,
+				Queued:   1,
+				DraftIDs: []int64{10, 11},
+				Themes:   []string{"Растяжка", "Протеин"},
+			}},
+		},
+		{
+			name: "generation fails",
+			opts: autopilot.Options{MinQueued: 3, Batch: 2, Audiences: []domain.UserLvl{domain.UserLvlBeginner}, Themes: opts.Themes},
+			mockBehavior: func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{}, nil).Once()
+				posts.EXPECT().CountDrafts(mock.Anything).Return(map[domain.UserLvl]int{}, nil).Once()
+				repo.EXPECT().ReserveThemes(mock.Anything, domain.UserLvlBeginner, 2, 3).Return(0, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{Content: "sleep"}, nil).Once()
+				posts.EXPECT().Save(mock.Anything, mock.Anything).Return(domain.Post{ID: 10}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, ai.ErrUnavailable).Once()
+				notifier.EXPECT().Notify(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: []domain.AutopilotRefill{{
+				Audience: domain.UserLvlBeginner,
+				DraftIDs: []int64{10},
+				Themes:   []string{"Сон"},
+			}},
+		},
+		{
+			name: "batch longer than themes",
+			opts: autopilot.Options{MinQueued: 3, Batch: 3, Audiences: []domain.UserLvl{domain.UserLvlBeginner}, Themes: []string{"Сон"}, Tone: domain.ToneFriendly},
+			mockBehavior: func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{}, nil).Once()
+				posts.EXPECT().CountDrafts(mock.Anything).Return(map[domain.UserLvl]int{}, nil).Once()
+				repo.EXPECT().ReserveThemes(mock.Anything, domain.UserLvlBeginner, 3, 1).Return(0, nil).Once()
+				// every draft of the same theme is generated anew
+				for i, content := range []string{"sleep 1", "sleep 2", "sleep 3"} {
+					gen.EXPECT().GenerateContent(asAutopilot, generated("Сон")).Return(domain.GeneratedPost{Content: content}, nil).Once()
+					posts.EXPECT().Save(mock.Anything, draft(content)).Return(domain.Post{ID: int64(10 + i)}, nil).Once()
+				}
+				notifier.EXPECT().Notify(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: []domain.AutopilotRefill{{
+				Audience: domain.UserLvlBeginner,
+				DraftIDs: []int64{10, 11, 12},
+				Themes:   []string{"Сон", "Сон", "Сон"},
+			}},
+		},
+		{
+			name: "without themes",
+			opts: autopilot.Options{MinQueued: 3, Batch: 2, Audiences: opts.Audiences},
+			mockBehavior: func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier) {
+			},
+		},
+		{
+			name: "count error",
+			opts: opts,
+			mockBehavior: func(posts *mocks.PostRepo, repo *mocks.AutopilotRepo, gen *mocks.Generator, notifier *mocks.Notifier) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			posts := mocks.NewPostRepo(t)
+			repo := mocks.NewAutopilotRepo(t)
+			gen := mocks.NewGenerator(t)
+			notifier := mocks.NewNotifier(t)
+			tc.mockBehavior(posts, repo, gen, notifier)
+
+			svc := autopilot.New(testutils.NewTestLogger(), posts, repo, gen, notifier, tc.opts)
+			got, err := svc.Refill(context.Background())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
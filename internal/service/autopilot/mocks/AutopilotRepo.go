@@ -0,0 +1,96 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AutopilotRepo is an autogenerated mock type for the AutopilotRepo type
+type AutopilotRepo struct {
+	mock.Mock
+}
+
+type AutopilotRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AutopilotRepo) EXPECT() *AutopilotRepo_Expecter {
+	return &AutopilotRepo_Expecter{mock: &_m.Mock}
+}
+
+// ReserveThemes provides a mock function with given fields: ctx, audience, count, total
+func (_m *AutopilotRepo) ReserveThemes(ctx context.Context, audience domain.UserLvl, count int, total int) (int, error) {
+	ret := _m.Called(ctx, audience, count, total)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveThemes")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, int, int) (int, error)); ok {
+		return rf(ctx, audience, count, total)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, int, int) int); ok {
+		r0 = rf(ctx, audience, count, total)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl, int, int) error); ok {
+		r1 = rf(ctx, audience, count, total)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AutopilotRepo_ReserveThemes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveThemes'
+type AutopilotRepo_ReserveThemes_Call struct {
+	*mock.Call
+}
+
+// ReserveThemes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+//   - count int
+//   - total int
+func (_e *AutopilotRepo_Expecter) ReserveThemes(ctx interface{}, audience interface{}, count interface{}, total interface{}) *AutopilotRepo_ReserveThemes_Call {
+	return &AutopilotRepo_ReserveThemes_Call{Call: _e.mock.On("ReserveThemes", ctx, audience, count, total)}
+}
+
+func (_c *AutopilotRepo_ReserveThemes_Call) Run(run func(ctx context.Context, audience domain.UserLvl, count int, total int)) *AutopilotRepo_ReserveThemes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *AutopilotRepo_ReserveThemes_Call) Return(_a0 int, _a1 error) *AutopilotRepo_ReserveThemes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AutopilotRepo_ReserveThemes_Call) RunAndReturn(run func(context.Context, domain.UserLvl, int, int) (int, error)) *AutopilotRepo_ReserveThemes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAutopilotRepo creates a new instance of AutopilotRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAutopilotRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AutopilotRepo {
+	mock := &AutopilotRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	post "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+)
+
+// PostRepo is an autogenerated mock type for the PostRepo type
+type PostRepo struct {
+	mock.Mock
+}
+
+type PostRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PostRepo) EXPECT() *PostRepo_Expecter {
+	return &PostRepo_Expecter{mock: &_m.Mock}
+}
+
+// CountDrafts provides a mock function with given fields: ctx
+func (_m *PostRepo) CountDrafts(ctx context.Context) (map[domain.UserLvl]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDrafts")
+	}
+
+	var r0 map[domain.UserLvl]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[domain.UserLvl]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[domain.UserLvl]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.UserLvl]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_CountDrafts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDrafts'
+type PostRepo_CountDrafts_Call struct {
+	*mock.Call
+}
+
+// CountDrafts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PostRepo_Expecter) CountDrafts(ctx interface{}) *PostRepo_CountDrafts_Call {
+	return &PostRepo_CountDrafts_Call{Call: _e.mock.On("CountDrafts", ctx)}
+}
+
+func (_c *PostRepo_CountDrafts_Call) Run(run func(ctx context.Context)) *PostRepo_CountDrafts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PostRepo_CountDrafts_Call) Return(_a0 map[domain.UserLvl]int, _a1 error) *PostRepo_CountDrafts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_CountDrafts_Call) RunAndReturn(run func(context.Context) (map[domain.UserLvl]int, error)) *PostRepo_CountDrafts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountQueued provides a mock function with given fields: ctx
+func (_m *PostRepo) CountQueued(ctx context.Context) (map[domain.UserLvl]int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountQueued")
+	}
+
+	var r0 map[domain.UserLvl]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[domain.UserLvl]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[domain.UserLvl]int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.UserLvl]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_CountQueued_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountQueued'
+type PostRepo_CountQueued_Call struct {
+	*mock.Call
+}
+
+// CountQueued is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PostRepo_Expecter) CountQueued(ctx interface{}) *PostRepo_CountQueued_Call {
+	return &PostRepo_CountQueued_Call{Call: _e.mock.On("CountQueued", ctx)}
+}
+
+func (_c *PostRepo_CountQueued_Call) Run(run func(ctx context.Context)) *PostRepo_CountQueued_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PostRepo_CountQueued_Call) Return(_a0 map[domain.UserLvl]int, _a1 error) *PostRepo_CountQueued_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_CountQueued_Call) RunAndReturn(run func(context.Context) (map[domain.UserLvl]int, error)) *PostRepo_CountQueued_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *PostRepo) Save(ctx context.Context, in post.SavePostInput) (domain.Post, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, post.SavePostInput) (domain.Post, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, post.SavePostInput) domain.Post); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, post.SavePostInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type PostRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in post.SavePostInput
+func (_e *PostRepo_Expecter) Save(ctx interface{}, in interface{}) *PostRepo_Save_Call {
+	return &PostRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *PostRepo_Save_Call) Run(run func(ctx context.Context, in post.SavePostInput)) *PostRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(post.SavePostInput))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Save_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Save_Call) RunAndReturn(run func(context.Context, post.SavePostInput) (domain.Post, error)) *PostRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPostRepo creates a new instance of PostRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostRepo {
+	mock := &PostRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
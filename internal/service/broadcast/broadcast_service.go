@@ -0,0 +1,107 @@
+package broadcast
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	broadcastRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/broadcast"
+)
+
+type BroadcastRepo interface {
+	Start(ctx context.Context, audience domain.UserLvl) (int64, error)
+	Finish(ctx context.Context, in broadcastRepo.FinishRunInput) error
+	Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)
+	LastSent(ctx context.Context) (map[domain.UserLvl]time.Time, error)
+}
+
+type service struct {
+	logger        *slog.Logger
+	broadcastRepo BroadcastRepo
+}
+
+func New(logger *slog.Logger, broadcastRepo BroadcastRepo) *service {
+	return &service{logger, broadcastRepo}
+}
+
+// StartRun records start of scheduled broadcast to audience
+func (s *service) StartRun(ctx context.Context, audience domain.UserLvl) (int64, error) {
+	const op = "broadcast.StartRun"
+	logger := s.logger.With(slog.String("op", op), slog.String("audience", string(audience)))
+
+	id, err := s.broadcastRepo.Start(ctx, audience)
+	if err != nil {
+		logger.Error("failed to start broadcast run", "error", err)
+		return 0, err
+	}
+	return id, nil
+}
+
+// FinishRun records result of broadcast run
+func (s *service) FinishRun(ctx context.Context, id int64, res domain.BroadcastResult) error {
+	const op = "broadcast.FinishRun"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("run_id", id))
+
+	in := broadcastRepo.FinishRunInput{
+		ID:         id,
+		PostID:     res.PostID,
+		Recipients: res.Recipients,
+		Outcome:    res.Outcome,
+		Evergreen:  res.Evergreen,
+	}
+	if res.Err != nil {
+		in.Error = res.Err.Error()
+	}
+	if err := s.broadcastRepo.Finish(ctx, in); err != nil {
+		logger.Error("failed to finish broadcast run", "error", err)
+		return err
+	}
+
+	switch res.Outcome {
+	case domain.BroadcastOutcomeFailed:
+		logger.Error("broadcast failed", "error", res.Err)
+	case domain.BroadcastOutcomeNoPosts:
+		logger.Warn("broadcast skipped, no posts in queue")
+	default:
+		logger.Info("broadcast finished", "outcome", res.Outcome, "post_id", res.PostID, "recipients", res.Recipients, "evergreen", res.Evergreen)
+	}
+	return nil
+}
+
+func (s *service) Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error) {
+	const op = "broadcast.Runs"
+	logger := s.logger.With(slog.String("op", op))
+
+	runs, err := s.broadcastRepo.Runs(ctx, filter)
+	if err != nil {
+		logger.Error("failed to get broadcast runs", "error", err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+// LastBroadcasts returns the last successful broadcast of every audience
+func (s *service) LastBroadcasts(ctx context.Context) ([]domain.LastBroadcast, error) {
+	const op = "broadcast.LastBroadcasts"
+	logger := s.logger.With(slog.String("op", op))
+
+	sent, err := s.broadcastRepo.LastSent(ctx)
+	if err != nil {
+		logger.Error("failed to get last broadcasts", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]domain.LastBroadcast, 0, len(domain.Audiences))
+	for _, audience := range domain.Audiences {
+		last := domain.LastBroadcast{Audience: audience}
+		if sentAt, ok := sent[audience]; ok {
+			since := int64(now.Sub(sentAt).Seconds())
+			last.SentAt = &sentAt
+			last.SinceSeconds = &since
+		}
+		res = append(res, last)
+	}
+	return res, nil
+}
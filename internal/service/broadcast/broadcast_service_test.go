@@ -0,0 +1,115 @@
+package broadcast_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	broadcastRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/broadcast"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/broadcast"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/broadcast/mocks"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBroadcastService_FinishRun(t *testing.T) {
+	type MockBehavior func(repo *mocks.BroadcastRepo)
+
+	testCases := []struct {
+		name         string
+		res          domain.BroadcastResult
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "sent",
+			res:  domain.BroadcastResult{PostID: 10, Recipients: 3, Outcome: domain.BroadcastOutcomeSent},
+			mockBehavior: func(repo *mocks.BroadcastRepo) {
+				repo.EXPECT().Finish(mock.Anything, broadcastRepo.FinishRunInput{
+					ID:         1,
+					PostID:     10,
+					Recipients: 3,
+					Outcome:    domain.BroadcastOutcomeSent,
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "failed with error",
+			res:  domain.BroadcastResult{PostID: 10, Outcome: domain.BroadcastOutcomeFailed, Err: errors.New("connection refused")},
+			mockBehavior: func(repo *mocks.BroadcastRepo) {
+				repo.EXPECT().Finish(mock.Anything, broadcastRepo.FinishRunInput{
+					ID:      1,
+					PostID:  10,
+					Outcome: domain.BroadcastOutcomeFailed,
+					Error:   "connection refused",
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "evergreen",
+			res:  domain.BroadcastResult{PostID: 5, Recipients: 2, Outcome: domain.BroadcastOutcomeSent, Evergreen: true},
+			mockBehavior: func(repo *mocks.BroadcastRepo) {
+				repo.EXPECT().Finish(mock.Anything, broadcastRepo.FinishRunInput{
+					ID:         1,
+					PostID:     5,
+					Recipients: 2,
+					Outcome:    domain.BroadcastOutcomeSent,
+					Evergreen:  true,
+				}).Return(nil).Once()
+			},
+		},
+		{
+			name: "repo error",
+			res:  domain.BroadcastResult{Outcome: domain.BroadcastOutcomeNoPosts},
+			mockBehavior: func(repo *mocks.BroadcastRepo) {
+				repo.EXPECT().Finish(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewBroadcastRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := broadcast.New(testutils.NewTestLogger(), repo)
+			err := svc.FinishRun(context.Background(), 1, tc.res)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestBroadcastService_LastBroadcasts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		sentAt := time.Now().Add(-2 * time.Hour)
+		repo := mocks.NewBroadcastRepo(t)
+		repo.EXPECT().LastSent(mock.Anything).Return(map[domain.UserLvl]time.Time{domain.UserLvlBeginner: sentAt}, nil).Once()
+
+		svc := broadcast.New(testutils.NewTestLogger(), repo)
+		got, err := svc.LastBroadcasts(context.Background())
+		assert.NoError(t, err)
+		assert.Len(t, got, len(domain.Audiences))
+		for _, last := range got {
+			if last.Audience != domain.UserLvlBeginner {
+				assert.Nil(t, last.SentAt)
+				assert.Nil(t, last.SinceSeconds)
+				continue
+			}
+			assert.Equal(t, sentAt, *last.SentAt)
+			assert.InDelta(t, 7200, *last.SinceSeconds, 5)
+		}
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := mocks.NewBroadcastRepo(t)
+		repo.EXPECT().LastSent(mock.Anything).Return(nil, assert.AnError).Once()
+
+		svc := broadcast.New(testutils.NewTestLogger(), repo)
+		_, err := svc.LastBroadcasts(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
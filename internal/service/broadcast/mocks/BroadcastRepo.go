@@ -0,0 +1,263 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	broadcast "github.com/SergeyBogomolovv/fitflow/internal/repo/broadcast"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// BroadcastRepo is an autogenerated mock type for the BroadcastRepo type
+type BroadcastRepo struct {
+	mock.Mock
+}
+
+type BroadcastRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BroadcastRepo) EXPECT() *BroadcastRepo_Expecter {
+	return &BroadcastRepo_Expecter{mock: &_m.Mock}
+}
+
+// Finish provides a mock function with given fields: ctx, in
+func (_m *BroadcastRepo) Finish(ctx context.Context, in broadcast.FinishRunInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Finish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, broadcast.FinishRunInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BroadcastRepo_Finish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Finish'
+type BroadcastRepo_Finish_Call struct {
+	*mock.Call
+}
+
+// Finish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in broadcast.FinishRunInput
+func (_e *BroadcastRepo_Expecter) Finish(ctx interface{}, in interface{}) *BroadcastRepo_Finish_Call {
+	return &BroadcastRepo_Finish_Call{Call: _e.mock.On("Finish", ctx, in)}
+}
+
+func (_c *BroadcastRepo_Finish_Call) Run(run func(ctx context.Context, in broadcast.FinishRunInput)) *BroadcastRepo_Finish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(broadcast.FinishRunInput))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_Finish_Call) Return(_a0 error) *BroadcastRepo_Finish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BroadcastRepo_Finish_Call) RunAndReturn(run func(context.Context, broadcast.FinishRunInput) error) *BroadcastRepo_Finish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastSent provides a mock function with given fields: ctx
+func (_m *BroadcastRepo) LastSent(ctx context.Context) (map[domain.UserLvl]time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastSent")
+	}
+
+	var r0 map[domain.UserLvl]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[domain.UserLvl]time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[domain.UserLvl]time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[domain.UserLvl]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_LastSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastSent'
+type BroadcastRepo_LastSent_Call struct {
+	*mock.Call
+}
+
+// LastSent is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BroadcastRepo_Expecter) LastSent(ctx interface{}) *BroadcastRepo_LastSent_Call {
+	return &BroadcastRepo_LastSent_Call{Call: _e.mock.On("LastSent", ctx)}
+}
+
+func (_c *BroadcastRepo_LastSent_Call) Run(run func(ctx context.Context)) *BroadcastRepo_LastSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_LastSent_Call) Return(_a0 map[domain.UserLvl]time.Time, _a1 error) *BroadcastRepo_LastSent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_LastSent_Call) RunAndReturn(run func(context.Context) (map[domain.UserLvl]time.Time, error)) *BroadcastRepo_LastSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Runs provides a mock function with given fields: ctx, filter
+func (_m *BroadcastRepo) Runs(ctx context.Context, filter domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Runs")
+	}
+
+	var r0 []domain.BroadcastRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BroadcastRunsFilter) []domain.BroadcastRun); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BroadcastRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BroadcastRunsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_Runs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Runs'
+type BroadcastRepo_Runs_Call struct {
+	*mock.Call
+}
+
+// Runs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.BroadcastRunsFilter
+func (_e *BroadcastRepo_Expecter) Runs(ctx interface{}, filter interface{}) *BroadcastRepo_Runs_Call {
+	return &BroadcastRepo_Runs_Call{Call: _e.mock.On("Runs", ctx, filter)}
+}
+
+func (_c *BroadcastRepo_Runs_Call) Run(run func(ctx context.Context, filter domain.BroadcastRunsFilter)) *BroadcastRepo_Runs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BroadcastRunsFilter))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_Runs_Call) Return(_a0 []domain.BroadcastRun, _a1 error) *BroadcastRepo_Runs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_Runs_Call) RunAndReturn(run func(context.Context, domain.BroadcastRunsFilter) ([]domain.BroadcastRun, error)) *BroadcastRepo_Runs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, audience
+func (_m *BroadcastRepo) Start(ctx context.Context, audience domain.UserLvl) (int64, error) {
+	ret := _m.Called(ctx, audience)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl) (int64, error)); ok {
+		return rf(ctx, audience)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl) int64); ok {
+		r0 = rf(ctx, audience)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl) error); ok {
+		r1 = rf(ctx, audience)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BroadcastRepo_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type BroadcastRepo_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+func (_e *BroadcastRepo_Expecter) Start(ctx interface{}, audience interface{}) *BroadcastRepo_Start_Call {
+	return &BroadcastRepo_Start_Call{Call: _e.mock.On("Start", ctx, audience)}
+}
+
+func (_c *BroadcastRepo_Start_Call) Run(run func(ctx context.Context, audience domain.UserLvl)) *BroadcastRepo_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl))
+	})
+	return _c
+}
+
+func (_c *BroadcastRepo_Start_Call) Return(_a0 int64, _a1 error) *BroadcastRepo_Start_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BroadcastRepo_Start_Call) RunAndReturn(run func(context.Context, domain.UserLvl) (int64, error)) *BroadcastRepo_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBroadcastRepo creates a new instance of BroadcastRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBroadcastRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BroadcastRepo {
+	mock := &BroadcastRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,9 +6,17 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"mime/multipart"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/SergeyBogomolovv/fitflow/pkg/cache"
+	"github.com/SergeyBogomolovv/fitflow/pkg/diff"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
 )
@@ -17,6 +25,23 @@ type PostRepo interface {
 	Save(ctx context.Context, in postRepo.SavePostInput) (domain.Post, error)
 	Remove(ctx context.Context, id int64) (domain.Post, error)
 	List(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error)
+	PostByID(ctx context.Context, id int64) (domain.Post, error)
+	Update(ctx context.Context, id int64, in postRepo.UpdatePostInput) (domain.Post, error)
+	Revisions(ctx context.Context, postID int64) ([]domain.PostRevision, error)
+	Revision(ctx context.Context, postID int64, version int) (domain.PostRevision, error)
+	Drafts(ctx context.Context, jobID string) ([]domain.Post, error)
+	Approve(ctx context.Context, id int64, review *domain.Review) (domain.Post, error)
+	SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error)
+}
+
+type PromptRepo interface {
+	Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error)
+}
+
+type UsageTracker interface {
+	// Reserve counts generation before model is called, so concurrent generations can not overrun quota
+	Reserve(ctx context.Context, admin string) error
+	Record(ctx context.Context, admin string, tokens domain.TokenUsage) error
 }
 
 type S3Client interface {
@@ -25,69 +50,383 @@ type S3Client interface {
 }
 
 type AiGenerator interface {
-	GenerateContent(ctx context.Context, prompt string) (string, error)
+	GenerateContent(ctx context.Context, req ai.Request) (ai.Response, error)
+	StreamContent(ctx context.Context, req ai.Request, onChunk func(chunk string) error) (ai.Response, error)
 }
 
 type postService struct {
-	logger   *slog.Logger
-	postRepo PostRepo
-	ai       AiGenerator
-	s3       S3Client
+	logger     *slog.Logger
+	postRepo   PostRepo
+	promptRepo PromptRepo
+	usage      UsageTracker
+	ai         AiGenerator
+	s3         S3Client
+	cache      *cache.TTL[domain.GenerateParams, domain.GeneratedPost]
+	policy     domain.ReviewPolicy
+}
+
+type Options struct {
+	// CacheTTL is how long generated posts are cached, zero disables cache
+	CacheTTL time.Duration
+	Review   domain.ReviewPolicy
 }
 
 const ImagesFolder = "images"
 
-func New(logger *slog.Logger, repo PostRepo, ai AiGenerator, s3 S3Client) *postService {
-	return &postService{logger, repo, ai, s3}
+func New(logger *slog.Logger, repo PostRepo, promptRepo PromptRepo, usage UsageTracker, ai AiGenerator, s3 S3Client, opts Options) *postService {
+	generated := cache.NewTTL[domain.GenerateParams, domain.GeneratedPost](opts.CacheTTL)
+	return &postService{logger, repo, promptRepo, usage, ai, s3, generated, opts.Review}
+}
+
+// GenerateContent asks model for structured post and validates it against post constraints.
+// Identical requests are served from cache unless NoCache is set, model calls are accounted against admin daily quota.
+// When review is enabled, post is scored by the reviewer and returned with the verdict.
+func (s *postService) GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error) {
+	const op = "content.GenerateContent"
+	logger := s.logger.With(slog.String("op", op), slog.String("theme", params.Theme))
+
+	params, system, err := s.resolvePrompt(ctx, logger, params)
+	if err != nil {
+		return domain.GeneratedPost{}, err
+	}
+
+	if post, ok := s.cached(params); ok {
+		logger.Debug("generated content served from cache")
+		return post, nil
+	}
+
+	if admin := auth.AdminLogin(ctx); admin != "" {
+		if err := s.usage.Reserve(ctx, admin); err != nil {
+			return domain.GeneratedPost{}, err
+		}
+	}
+
+	post, err := s.generate(ctx, logger, buildPrompt(params), system, params.Length.Limit())
+	if err != nil {
+		return domain.GeneratedPost{}, err
+	}
+	post.PromptID = params.PromptID
+	post.PromptVersion = params.PromptVersion
+	s.attachReview(ctx, logger, &post, params.Audience)
+
+	if !params.NoCache {
+		s.cache.Set(params, post)
+	}
+	return post, nil
+}
+
+func (s *postService) cached(params domain.GenerateParams) (domain.GeneratedPost, bool) {
+	if params.NoCache {
+		return domain.GeneratedPost{}, false
+	}
+	return s.cache.Get(params)
+}
+
+// StreamContent generates ready to post text and passes it to onChunk as model produces it.
+// Streamed text can not be sent back to the model, so invalid post is only repaired locally.
+func (s *postService) StreamContent(ctx context.Context, params domain.GenerateParams, onChunk func(chunk string) error) (domain.GeneratedPost, error) {
+	const op = "content.StreamContent"
+	logger := s.logger.With(slog.String("op", op), slog.String("theme", params.Theme))
+
+	params, system, err := s.resolvePrompt(ctx, logger, params)
+	if err != nil {
+		return domain.GeneratedPost{}, err
+	}
+
+	if post, ok := s.cached(params); ok {
+		logger.Debug("generated content served from cache")
+		if err := onChunk(post.Content); err != nil {
+			return domain.GeneratedPost{}, err
+		}
+		return post, nil
+	}
+
+	if admin := auth.AdminLogin(ctx); admin != "" {
+		if err := s.usage.Reserve(ctx, admin); err != nil {
+			return domain.GeneratedPost{}, err
+		}
+	}
+
+	req := ai.Request{Prompt: buildStreamPrompt(params), System: system}
+	resp, err := s.ai.StreamContent(ctx, req, onChunk)
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.Info("content stream cancelled", "reason", ctx.Err())
+			return domain.GeneratedPost{}, ctx.Err()
+		}
+		logger.Error("failed to stream content", "error", err)
+		return domain.GeneratedPost{}, err
+	}
+	s.recordUsage(ctx, resp.Usage)
+
+	limit := params.Length.Limit()
+	post := fitGenerated(parsePlain(resp.Text), limit)
+	if err := validateGenerated(post, limit); err != nil {
+		logger.Warn("streamed content rejected", "reason", err)
+		return domain.GeneratedPost{}, domain.ErrInvalidGeneration
+	}
+	post.PromptID = params.PromptID
+	post.PromptVersion = params.PromptVersion
+	s.attachReview(ctx, logger, &post, params.Audience)
+
+	if !params.NoCache {
+		s.cache.Set(params, post)
+	}
+	return post, nil
+}
+
+// RewritePost transforms existing post with the model, result is saved as draft linked to the source when requested.
+// Draft has no images, they are kept by the source post and removed together with it.
+func (s *postService) RewritePost(ctx context.Context, id int64, in domain.RewriteDTO) (domain.RewriteResult, error) {
+	const op = "content.RewritePost"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id), slog.String("transform", string(in.Transform)))
+
+	source, err := s.postRepo.PostByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to get post", "error", err)
+		}
+		return domain.RewriteResult{}, err
+	}
+
+	if admin := auth.AdminLogin(ctx); admin != "" {
+		if err := s.usage.Reserve(ctx, admin); err != nil {
+			return domain.RewriteResult{}, err
+		}
+	}
+
+	audience := source.Audience
+	if in.Transform == domain.RewriteAdaptToLevel {
+		audience = in.Level
+	}
+	post, err := s.generate(ctx, logger, buildRewritePrompt(source.Content, audience, in), "", rewriteLimit(in))
+	if err != nil {
+		return domain.RewriteResult{}, err
+	}
+	s.attachReview(ctx, logger, &post, audience)
+
+	res := domain.RewriteResult{Post: post}
+	if !in.Save {
+		return res, nil
+	}
+
+	draft, err := s.postRepo.Save(ctx, postRepo.SavePostInput{
+		Content:  post.Content,
+		Audience: audience,
+		Images:   []string{},
+		Author:   auth.AdminLogin(ctx),
+		Status:   domain.PostStatusDraft,
+		Review:   post.Review,
+		SourceID: source.ID,
+	})
+	if err != nil {
+		logger.Error("failed to save draft", "error", err)
+		return domain.RewriteResult{}, err
+	}
+	res.Draft = &draft
+
+	logger.Info("rewritten draft saved", "draft_id", draft.ID)
+	return res, nil
+}
+
+// resolvePrompt renders library template into system prompt, empty system means default prompt
+func (s *postService) resolvePrompt(ctx context.Context, logger *slog.Logger, params domain.GenerateParams) (domain.GenerateParams, string, error) {
+	if params.PromptID == 0 {
+		return params, "", nil
+	}
+
+	version, err := s.promptRepo.Version(ctx, params.PromptID, params.PromptVersion)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptVersionNotFound) {
+			return params, "", err
+		}
+		logger.Error("failed to get prompt", "error", err)
+		return params, "", err
+	}
+	system, err := domain.RenderPrompt(version.Template, domain.PromptVars{
+		Theme:    params.Theme,
+		Level:    params.Audience,
+		Language: params.Language,
+		Tone:     params.Tone,
+		Length:   params.Length,
+	})
+	if err != nil {
+		logger.Error("failed to render prompt", "error", err, "prompt", params.PromptID)
+		return params, "", err
+	}
+	// resolved version is part of cache key, so changing active template invalidates cached posts
+	params.PromptVersion = version.Version
+	return params, system, nil
+}
+
+// generate sends invalid responses back to the model with the reason, the last one is repaired locally
+func (s *postService) generate(ctx context.Context, logger *slog.Logger, prompt, system string, limit int) (domain.GeneratedPost, error) {
+	req := ai.Request{Prompt: prompt, System: system, JSON: true}
+
+	var last *domain.GeneratedPost
+	for attempt := 1; attempt <= generateAttempts; attempt++ {
+		resp, err := s.ai.GenerateContent(ctx, req)
+		if err != nil {
+			logger.Error("failed to generate content", "error", err)
+			return domain.GeneratedPost{}, err
+		}
+		s.recordUsage(ctx, resp.Usage)
+
+		post, err := parseGenerated(resp.Text)
+		if err == nil {
+			last = &post
+			err = validateGenerated(post, limit)
+		}
+		if err == nil {
+			return post, nil
+		}
+
+		logger.Warn("generated content rejected", "attempt", attempt, "reason", err)
+		req.Prompt = repairPrompt(prompt, resp.Text, err)
+	}
+
+	if last != nil {
+		post := fitGenerated(*last, limit)
+		if err := validateGenerated(post, limit); err == nil {
+			return post, nil
+		}
+	}
+	return domain.GeneratedPost{}, domain.ErrInvalidGeneration
 }
 
-func (s *postService) GenerateContent(ctx context.Context, theme string) (string, error) {
-	return s.ai.GenerateContent(ctx, theme)
+// recordUsage does not fail generation, the model has already been paid for
+func (s *postService) recordUsage(ctx context.Context, usage ai.Usage) {
+	admin := auth.AdminLogin(ctx)
+	if admin == "" {
+		return
+	}
+	s.usage.Record(ctx, admin, domain.TokenUsage{
+		PromptTokens:     int64(usage.PromptTokens),
+		CompletionTokens: int64(usage.CompletionTokens),
+		TotalTokens:      int64(usage.TotalTokens),
+	})
 }
 
 func (s *postService) CreatePost(ctx context.Context, in domain.CreatePostDTO) (domain.Post, error) {
 	const op = "content.CreatePost"
 	logger := s.logger.With(slog.String("op", op))
-	input := postRepo.SavePostInput{
+
+	// review goes first, so blocked post does not leave uploaded images
+	review, err := s.checkReview(ctx, logger, in.Content, in.Audience)
+	if err != nil {
+		return domain.Post{}, err
+	}
+
+	images, err := s.uploadImages(ctx, in.Images)
+	if err != nil {
+		logger.Error("failed to upload images", "error", err)
+		return domain.Post{}, err
+	}
+
+	post, err := s.postRepo.Save(ctx, postRepo.SavePostInput{
 		Content:  in.Content,
-		Images:   make([]string, 0, len(in.Images)),
+		Images:   images,
 		Audience: in.Audience,
+		Author:   auth.AdminLogin(ctx),
+		Review:   review,
+	})
+	if err != nil {
+		logger.Error("failed to save post", "error", err)
+		return domain.Post{}, err
+	}
+	return post, nil
+}
+
+func (s *postService) UpdatePost(ctx context.Context, id int64, in domain.UpdatePostDTO) (domain.Post, error) {
+	const op = "content.UpdatePost"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	post, err := s.postRepo.PostByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to get post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+
+	input := postRepo.UpdatePostInput{
+		Content:  post.Content,
+		Audience: post.Audience,
+		Images:   post.Images,
+		Author:   auth.AdminLogin(ctx),
+		Review:   post.Review,
+	}
+	if in.Content != "" {
+		input.Content = in.Content
+	}
+	if in.Audience != "" {
+		input.Audience = in.Audience
+	}
+	// level fit depends on audience, so review is outdated when either changed
+	if input.Content != post.Content || input.Audience != post.Audience {
+		input.Review, err = s.checkReview(ctx, logger, input.Content, input.Audience)
+		if err != nil {
+			return domain.Post{}, err
+		}
+	}
+	// old images are kept in s3, previous revisions still reference them
+	if len(in.Images) > 0 {
+		images, err := s.uploadImages(ctx, in.Images)
+		if err != nil {
+			logger.Error("failed to upload images", "error", err)
+			return domain.Post{}, err
+		}
+		input.Images = images
 	}
 
-	eg, uploadCtx := errgroup.WithContext(ctx)
-	for _, imageHeader := range in.Images {
+	post, err = s.postRepo.Update(ctx, id, input)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to update post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+	return post, nil
+}
+
+func (s *postService) uploadImages(ctx context.Context, files []*multipart.FileHeader) ([]string, error) {
+	var mu sync.Mutex
+	images := make([]string, 0, len(files))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, imageHeader := range files {
 		eg.Go(func() error {
 			image, err := imageHeader.Open()
 			if err != nil {
-				logger.Error("failed to open image", "error", err)
 				return err
 			}
 			defer image.Close()
-			key, err := s.s3.Upload(uploadCtx, fmt.Sprintf("%s/%s.jpg", ImagesFolder, uuid.NewString()), image)
+			key, err := s.s3.Upload(ctx, fmt.Sprintf("%s/%s.jpg", ImagesFolder, uuid.NewString()), image)
 			if err != nil {
-				logger.Error("failed to upload image", "error", err)
 				return err
 			}
-			input.Images = append(input.Images, key)
+			mu.Lock()
+			images = append(images, key)
+			mu.Unlock()
 			return nil
 		})
 	}
 	if err := eg.Wait(); err != nil {
-		return domain.Post{}, err
+		return nil, err
 	}
-
-	post, err := s.postRepo.Save(ctx, input)
-	if err != nil {
-		logger.Error("failed to save post", "error", err)
-		return domain.Post{}, err
-	}
-	return post, nil
+	return images, nil
 }
 
 func (s *postService) RemovePost(ctx context.Context, id int64) error {
 	const op = "content.RemovePost"
 	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
 
+	revisions, err := s.postRepo.Revisions(ctx, id)
+	if err != nil {
+		logger.Error("failed to get revisions", "err", err)
+		return err
+	}
+
 	post, err := s.postRepo.Remove(ctx, id)
 	if err != nil {
 		if !errors.Is(err, domain.ErrPostNotFound) {
@@ -96,8 +435,15 @@ func (s *postService) RemovePost(ctx context.Context, id int64) error {
 		return err
 	}
 
+	images := post.Images
+	for _, revision := range revisions {
+		images = append(images, revision.Images...)
+	}
+	slices.Sort(images)
+	images = slices.Compact(images)
+
 	eg, ctx := errgroup.WithContext(ctx)
-	for _, url := range post.Images {
+	for _, url := range images {
 		eg.Go(func() error {
 			err := s.s3.Delete(ctx, url)
 			if err != nil {
@@ -110,6 +456,165 @@ func (s *postService) RemovePost(ctx context.Context, id int64) error {
 	return eg.Wait()
 }
 
+// SetEvergreen marks post as evergreen, such posts are sent again when queue of audience is empty
+func (s *postService) SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error) {
+	const op = "content.SetEvergreen"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	post, err := s.postRepo.SetEvergreen(ctx, id, evergreen)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to set evergreen", "err", err)
+		}
+		return domain.Post{}, err
+	}
+	return post, nil
+}
+
 func (s *postService) Posts(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error) {
 	return s.postRepo.List(ctx, audience, incoming)
 }
+
+func (s *postService) Drafts(ctx context.Context) ([]domain.Post, error) {
+	return s.postRepo.Drafts(ctx, "")
+}
+
+func (s *postService) ApprovePost(ctx context.Context, id int64) (domain.Post, error) {
+	const op = "content.ApprovePost"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	var review *domain.Review
+	if s.policy.Block {
+		draft, err := s.postRepo.PostByID(ctx, id)
+		if err != nil {
+			if !errors.Is(err, domain.ErrPostNotFound) {
+				logger.Error("failed to get post", "error", err)
+			}
+			return domain.Post{}, err
+		}
+		if draft.Review != nil && draft.Review.Verdict == domain.ReviewVerdictFail {
+			return domain.Post{}, &domain.ReviewFailedError{Review: *draft.Review}
+		}
+		if draft.Review == nil {
+			// reviewer failed when draft was generated, draft is reviewed now or not approved at all
+			review, err = s.checkReview(ctx, logger, draft.Content, draft.Audience)
+			if err != nil {
+				return domain.Post{}, err
+			}
+		}
+	}
+
+	post, err := s.postRepo.Approve(ctx, id, review)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to approve post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+
+	logger.Info("post approved")
+	return post, nil
+}
+
+func (s *postService) PostRevisions(ctx context.Context, id int64) ([]domain.PostRevision, error) {
+	const op = "content.PostRevisions"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	revisions, err := s.postRepo.Revisions(ctx, id)
+	if err != nil {
+		logger.Error("failed to get revisions", "error", err)
+		return nil, err
+	}
+	// every post has at least the initial revision
+	if len(revisions) == 0 {
+		return nil, domain.ErrPostNotFound
+	}
+	return revisions, nil
+}
+
+func (s *postService) DiffRevisions(ctx context.Context, id int64, from, to int) (domain.PostDiff, error) {
+	const op = "content.DiffRevisions"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	revFrom, err := s.postRepo.Revision(ctx, id, from)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRevisionNotFound) {
+			logger.Error("failed to get revision", "error", err, "version", from)
+		}
+		return domain.PostDiff{}, err
+	}
+	revTo, err := s.postRepo.Revision(ctx, id, to)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRevisionNotFound) {
+			logger.Error("failed to get revision", "error", err, "version", to)
+		}
+		return domain.PostDiff{}, err
+	}
+
+	lines := diff.Lines(revFrom.Content, revTo.Content)
+	res := domain.PostDiff{
+		PostID:        id,
+		From:          from,
+		To:            to,
+		Content:       make([]domain.DiffLine, 0, len(lines)),
+		AudienceFrom:  revFrom.Audience,
+		AudienceTo:    revTo.Audience,
+		ImagesAdded:   make([]string, 0),
+		ImagesRemoved: make([]string, 0),
+	}
+	for _, line := range lines {
+		res.Content = append(res.Content, domain.DiffLine{Op: diffOps[line.Op], Text: line.Text})
+	}
+	for _, image := range revTo.Images {
+		if !slices.Contains(revFrom.Images, image) {
+			res.ImagesAdded = append(res.ImagesAdded, image)
+		}
+	}
+	for _, image := range revFrom.Images {
+		if !slices.Contains(revTo.Images, image) {
+			res.ImagesRemoved = append(res.ImagesRemoved, image)
+		}
+	}
+	return res, nil
+}
+
+var diffOps = map[diff.Op]domain.DiffOp{
+	diff.Equal:  domain.DiffOpEqual,
+	diff.Insert: domain.DiffOpInsert,
+	diff.Delete: domain.DiffOpDelete,
+}
+
+func (s *postService) RollbackPost(ctx context.Context, id int64, version int) (domain.Post, error) {
+	const op = "content.RollbackPost"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id), slog.Int("version", version))
+
+	revision, err := s.postRepo.Revision(ctx, id, version)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRevisionNotFound) {
+			logger.Error("failed to get revision", "error", err)
+		}
+		return domain.Post{}, err
+	}
+
+	review, err := s.checkReview(ctx, logger, revision.Content, revision.Audience)
+	if err != nil {
+		return domain.Post{}, err
+	}
+
+	post, err := s.postRepo.Update(ctx, id, postRepo.UpdatePostInput{
+		Content:  revision.Content,
+		Audience: revision.Audience,
+		Images:   revision.Images,
+		Author:   auth.AdminLogin(ctx),
+		Review:   review,
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to rollback post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+
+	logger.Info("post rolled back")
+	return post, nil
+}
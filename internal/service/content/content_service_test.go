@@ -3,12 +3,16 @@ package content_test
 import (
 	"context"
 	"mime/multipart"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
 	"github.com/SergeyBogomolovv/fitflow/internal/service/content"
 	"github.com/SergeyBogomolovv/fitflow/internal/service/content/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
 	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -102,7 +106,7 @@ func TestContentService_CreatePost(t *testing.T) {
 			s3 := mocks.NewS3Client(t)
 			tc.mockBehavior(repo, s3, tc.in)
 
-			svc := content.New(testutils.NewTestLogger(), repo, nil, s3)
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, s3, content.Options{})
 			got, err := svc.CreatePost(context.Background(), tc.in)
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -127,15 +131,31 @@ func TestContentService_RemovePost(t *testing.T) {
 			name: "success",
 			id:   1,
 			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().Revisions(mock.Anything, id).Return([]domain.PostRevision{{Images: []string{"test.jpg"}}}, nil).Once()
 				repo.EXPECT().Remove(mock.Anything, id).Return(domain.Post{Images: []string{"test.jpg"}}, nil).Once()
 				s3.EXPECT().Delete(mock.Anything, "test.jpg").Return(nil).Once()
 			},
 			want: nil,
 		},
+		{
+			name: "removes images of old revisions",
+			id:   1,
+			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().Revisions(mock.Anything, id).Return([]domain.PostRevision{
+					{Version: 2, Images: []string{"new.jpg"}},
+					{Version: 1, Images: []string{"old.jpg"}},
+				}, nil).Once()
+				repo.EXPECT().Remove(mock.Anything, id).Return(domain.Post{Images: []string{"new.jpg"}}, nil).Once()
+				s3.EXPECT().Delete(mock.Anything, "new.jpg").Return(nil).Once()
+				s3.EXPECT().Delete(mock.Anything, "old.jpg").Return(nil).Once()
+			},
+			want: nil,
+		},
 		{
 			name: "post not found",
 			id:   1,
 			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().Revisions(mock.Anything, id).Return([]domain.PostRevision{}, nil).Once()
 				repo.EXPECT().Remove(mock.Anything, id).Return(domain.Post{}, domain.ErrPostNotFound).Once()
 			},
 			want: domain.ErrPostNotFound,
@@ -144,6 +164,7 @@ func TestContentService_RemovePost(t *testing.T) {
 			name: "failed to delete image",
 			id:   1,
 			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().Revisions(mock.Anything, id).Return([]domain.PostRevision{{Images: []string{"test.jpg"}}}, nil).Once()
 				repo.EXPECT().Remove(mock.Anything, id).Return(domain.Post{Images: []string{"test.jpg"}}, nil).Once()
 				s3.EXPECT().Delete(mock.Anything, "test.jpg").Return(assert.AnError).Once()
 			},
@@ -157,9 +178,1003 @@ func TestContentService_RemovePost(t *testing.T) {
 			s3 := mocks.NewS3Client(t)
 			tc.mockBehavior(repo, s3, tc.id)
 
-			svc := content.New(testutils.NewTestLogger(), repo, nil, s3)
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, s3, content.Options{})
 			got := svc.RemovePost(context.Background(), tc.id)
 			assert.ErrorIs(t, got, tc.want)
 		})
 	}
 }
+
+func TestContentService_SetEvergreen(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().SetEvergreen(mock.Anything, int64(1), true).Return(domain.Post{ID: 1, Evergreen: true}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Evergreen: true},
+		},
+		{
+			name: "post not found",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().SetEvergreen(mock.Anything, int64(1), true).Return(domain.Post{}, domain.ErrPostNotFound).Once()
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, nil, content.Options{})
+			got, err := svc.SetEvergreen(context.Background(), 1, true)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_UpdatePost(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64)
+
+	current := domain.Post{ID: 1, Content: "old content", Audience: domain.UserLvlBeginner, Images: []string{"old.jpg"}}
+
+	testCases := []struct {
+		name         string
+		ctx          context.Context
+		in           domain.UpdatePostDTO
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name: "update content",
+			ctx:  context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin"),
+			in:   domain.UpdatePostDTO{Content: "new content"},
+			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().PostByID(mock.Anything, id).Return(current, nil).Once()
+				repo.EXPECT().Update(mock.Anything, id, postRepo.UpdatePostInput{
+					Content:  "new content",
+					Audience: current.Audience,
+					Images:   current.Images,
+					Author:   "admin",
+				}).Return(domain.Post{ID: id, Content: "new content"}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Content: "new content"},
+		},
+		{
+			name: "replace images",
+			ctx:  context.Background(),
+			in: domain.UpdatePostDTO{Images: []*multipart.FileHeader{
+				testutils.CreateTestFile(t, "test.jpg", "test content"),
+			}},
+			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().PostByID(mock.Anything, id).Return(current, nil).Once()
+				s3.EXPECT().Upload(mock.Anything, mock.Anything, mock.Anything).Return("new.jpg", nil).Once()
+				repo.EXPECT().Update(mock.Anything, id, postRepo.UpdatePostInput{
+					Content:  current.Content,
+					Audience: current.Audience,
+					Images:   []string{"new.jpg"},
+				}).Return(domain.Post{ID: id}, nil).Once()
+			},
+			want: domain.Post{ID: 1},
+		},
+		{
+			name: "post not found",
+			ctx:  context.Background(),
+			in:   domain.UpdatePostDTO{Content: "new content"},
+			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().PostByID(mock.Anything, id).Return(domain.Post{}, domain.ErrPostNotFound).Once()
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name: "failed to update",
+			ctx:  context.Background(),
+			in:   domain.UpdatePostDTO{Audience: domain.UserLvlAdvanced},
+			mockBehavior: func(repo *mocks.PostRepo, s3 *mocks.S3Client, id int64) {
+				repo.EXPECT().PostByID(mock.Anything, id).Return(current, nil).Once()
+				repo.EXPECT().Update(mock.Anything, id, mock.Anything).Return(domain.Post{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			s3 := mocks.NewS3Client(t)
+			tc.mockBehavior(repo, s3, current.ID)
+
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, s3, content.Options{})
+			got, err := svc.UpdatePost(tc.ctx, current.ID, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_DiffRevisions(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.PostDiff
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().Revision(mock.Anything, int64(1), 1).Return(domain.PostRevision{
+					Version:  1,
+					Content:  "first\nsecond",
+					Audience: domain.UserLvlDefault,
+					Images:   []string{"a.jpg", "b.jpg"},
+				}, nil).Once()
+				repo.EXPECT().Revision(mock.Anything, int64(1), 2).Return(domain.PostRevision{
+					Version:  2,
+					Content:  "first\nthird",
+					Audience: domain.UserLvlBeginner,
+					Images:   []string{"b.jpg", "c.jpg"},
+				}, nil).Once()
+			},
+			want: domain.PostDiff{
+				PostID: 1,
+				From:   1,
+				To:     2,
+				Content: []domain.DiffLine{
+					{Op: domain.DiffOpEqual, Text: "first"},
+					{Op: domain.DiffOpDelete, Text: "second"},
+					{Op: domain.DiffOpInsert, Text: "third"},
+				},
+				AudienceFrom:  domain.UserLvlDefault,
+				AudienceTo:    domain.UserLvlBeginner,
+				ImagesAdded:   []string{"c.jpg"},
+				ImagesRemoved: []string{"a.jpg"},
+			},
+		},
+		{
+			name: "revision not found",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().Revision(mock.Anything, int64(1), 1).Return(domain.PostRevision{Version: 1}, nil).Once()
+				repo.EXPECT().Revision(mock.Anything, int64(1), 2).Return(domain.PostRevision{}, domain.ErrRevisionNotFound).Once()
+			},
+			wantErr: domain.ErrRevisionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, nil, content.Options{})
+			got, err := svc.DiffRevisions(context.Background(), 1, 1, 2)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_RollbackPost(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo)
+
+	revision := domain.PostRevision{
+		PostID:   1,
+		Version:  1,
+		Content:  "old content",
+		Audience: domain.UserLvlBeginner,
+		Images:   []string{"old.jpg"},
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().Revision(mock.Anything, revision.PostID, revision.Version).Return(revision, nil).Once()
+				repo.EXPECT().Update(mock.Anything, revision.PostID, postRepo.UpdatePostInput{
+					Content:  revision.Content,
+					Audience: revision.Audience,
+					Images:   revision.Images,
+					Author:   "admin",
+				}).Return(domain.Post{ID: 1, Content: revision.Content}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Content: revision.Content},
+		},
+		{
+			name: "revision not found",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().Revision(mock.Anything, revision.PostID, revision.Version).Return(domain.PostRevision{}, domain.ErrRevisionNotFound).Once()
+			},
+			wantErr: domain.ErrRevisionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, nil, nil, content.Options{})
+			ctx := context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin")
+			got, err := svc.RollbackPost(ctx, revision.PostID, revision.Version)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_GenerateContent(t *testing.T) {
+	type MockBehavior func(gen *mocks.AiGenerator)
+
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.GeneratedPost
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return req.JSON && strings.Contains(req.Prompt, "protein")
+				})).Return(ai.Response{Text: "```json\n" + `{"title":"Protein","body":"Eat it.","hashtags":["food","#food"," gym life"],"image_prompt":"shake"}` + "\n```"}, nil).Once()
+			},
+			want: domain.GeneratedPost{
+				Title:       "Protein",
+				Body:        "Eat it.",
+				Hashtags:    []string{"#food", "#gym_life"},
+				ImagePrompt: "shake",
+				Content:     "*Protein*\n\nEat it.\n\n#food #gym_life",
+			},
+		},
+		{
+			name: "retry after invalid json",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: "not a json"}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "not a json")
+				})).Return(ai.Response{Text: `{"title":"Protein","body":"Eat it."}`}, nil).Once()
+			},
+			want: domain.GeneratedPost{
+				Title:    "Protein",
+				Body:     "Eat it.",
+				Hashtags: []string{},
+				Content:  "*Protein*\n\nEat it.",
+			},
+		},
+		{
+			name: "repair too long post",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				body := strings.Repeat("Long sentence here. ", 10)
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).
+					Return(ai.Response{Text: `{"title":"Protein","body":"` + body + `","hashtags":["food"]}`}, nil).Times(3)
+			},
+			want: domain.GeneratedPost{
+				Title:    "Protein",
+				Body:     strings.TrimSpace(strings.Repeat("Long sentence here. ", 6)),
+				Hashtags: []string{},
+				Content:  "*Protein*\n\n" + strings.TrimSpace(strings.Repeat("Long sentence here. ", 6)),
+			},
+		},
+		{
+			name: "invalid after all attempts",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: `{"title":"Protein"}`}, nil).Times(3)
+			},
+			wantErr: domain.ErrInvalidGeneration,
+		},
+		{
+			name: "ai error",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(gen)
+
+			svc := content.New(testutils.NewTestLogger(), nil, nil, nil, gen, nil, content.Options{})
+			got, err := svc.GenerateContent(context.Background(), params)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_GenerateContentCache(t *testing.T) {
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+	}
+
+	t.Run("identical request served from cache", func(t *testing.T) {
+		gen := mocks.NewAiGenerator(t)
+		gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: `{"title":"Protein","body":"Eat it."}`}, nil).Once()
+
+		svc := content.New(testutils.NewTestLogger(), nil, nil, nil, gen, nil, content.Options{CacheTTL: time.Hour})
+		first, err := svc.GenerateContent(context.Background(), params)
+		assert.NoError(t, err)
+		second, err := svc.GenerateContent(context.Background(), params)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		gen := mocks.NewAiGenerator(t)
+		gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: `{"title":"First","body":"Eat it."}`}, nil).Once()
+		gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: `{"title":"Second","body":"Eat it."}`}, nil).Once()
+
+		noCache := params
+		noCache.NoCache = true
+		svc := content.New(testutils.NewTestLogger(), nil, nil, nil, gen, nil, content.Options{CacheTTL: time.Hour})
+		first, err := svc.GenerateContent(context.Background(), noCache)
+		assert.NoError(t, err)
+		second, err := svc.GenerateContent(context.Background(), noCache)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first.Content, second.Content)
+	})
+}
+
+func TestContentService_GenerateContentWithPrompt(t *testing.T) {
+	type MockBehavior func(prompts *mocks.PromptRepo, gen *mocks.AiGenerator)
+
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+		PromptID: 1,
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.GeneratedPost
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(prompts *mocks.PromptRepo, gen *mocks.AiGenerator) {
+				prompts.EXPECT().Version(mock.Anything, int64(1), 0).Return(domain.PromptVersion{
+					PromptID: 1,
+					Version:  3,
+					Template: "Write about {{.Theme}} for {{.Level}} in {{.Language}}",
+				}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return req.System == "Write about protein for beginner in ru"
+				})).Return(ai.Response{Text: `{"title":"Protein","body":"Eat it."}`}, nil).Once()
+			},
+			want: domain.GeneratedPost{
+				Title:         "Protein",
+				Body:          "Eat it.",
+				Hashtags:      []string{},
+				Content:       "*Protein*\n\nEat it.",
+				PromptID:      1,
+				PromptVersion: 3,
+			},
+		},
+		{
+			name: "prompt not found",
+			mockBehavior: func(prompts *mocks.PromptRepo, gen *mocks.AiGenerator) {
+				prompts.EXPECT().Version(mock.Anything, int64(1), 0).Return(domain.PromptVersion{}, domain.ErrPromptVersionNotFound).Once()
+			},
+			wantErr: domain.ErrPromptVersionNotFound,
+		},
+		{
+			name: "invalid template",
+			mockBehavior: func(prompts *mocks.PromptRepo, gen *mocks.AiGenerator) {
+				prompts.EXPECT().Version(mock.Anything, int64(1), 0).Return(domain.PromptVersion{Template: "{{.Unknown}}"}, nil).Once()
+			},
+			wantErr: domain.ErrInvalidTemplate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prompts := mocks.NewPromptRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(prompts, gen)
+
+			svc := content.New(testutils.NewTestLogger(), nil, prompts, nil, gen, nil, content.Options{})
+			got, err := svc.GenerateContent(context.Background(), params)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_GenerateContentUsage(t *testing.T) {
+	type MockBehavior func(usage *mocks.UsageTracker, gen *mocks.AiGenerator)
+
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+	}
+	want := domain.GeneratedPost{
+		Title:    "Protein",
+		Body:     "Eat it.",
+		Hashtags: []string{},
+		Content:  "*Protein*\n\nEat it.",
+	}
+
+	testCases := []struct {
+		name         string
+		calls        int
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name:  "records usage and caches result",
+			calls: 2,
+			mockBehavior: func(usage *mocks.UsageTracker, gen *mocks.AiGenerator) {
+				usage.EXPECT().Reserve(mock.Anything, "admin").Return(nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{
+					Text:  `{"title":"Protein","body":"Eat it."}`,
+					Usage: ai.Usage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120},
+				}, nil).Once()
+				usage.EXPECT().Record(mock.Anything, "admin", domain.TokenUsage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120}).Return(nil).Once()
+			},
+		},
+		{
+			name:  "record error does not fail generation",
+			calls: 1,
+			mockBehavior: func(usage *mocks.UsageTracker, gen *mocks.AiGenerator) {
+				usage.EXPECT().Reserve(mock.Anything, "admin").Return(nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{Text: `{"title":"Protein","body":"Eat it."}`}, nil).Once()
+				usage.EXPECT().Record(mock.Anything, "admin", mock.Anything).Return(assert.AnError).Once()
+			},
+		},
+		{
+			name:  "quota exceeded",
+			calls: 1,
+			mockBehavior: func(usage *mocks.UsageTracker, gen *mocks.AiGenerator) {
+				usage.EXPECT().Reserve(mock.Anything, "admin").Return(domain.ErrQuotaExceeded).Once()
+			},
+			wantErr: domain.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := mocks.NewUsageTracker(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(usage, gen)
+
+			svc := content.New(testutils.NewTestLogger(), nil, nil, usage, gen, nil, content.Options{CacheTTL: time.Minute})
+			ctx := context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin")
+			for range tc.calls {
+				got, err := svc.GenerateContent(ctx, params)
+				if tc.wantErr != nil {
+					assert.ErrorIs(t, err, tc.wantErr)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func TestContentService_StreamContent(t *testing.T) {
+	type MockBehavior func(gen *mocks.AiGenerator)
+
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+	}
+	streamed := func(chunks ...string) func(ctx context.Context, req ai.Request, onChunk func(string) error) (ai.Response, error) {
+		return func(ctx context.Context, req ai.Request, onChunk func(string) error) (ai.Response, error) {
+			for _, chunk := range chunks {
+				if err := onChunk(chunk); err != nil {
+					return ai.Response{}, err
+				}
+			}
+			return ai.Response{Text: strings.Join(chunks, "")}, nil
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		cancel       bool
+		mockBehavior MockBehavior
+		wantChunks   []string
+		want         domain.GeneratedPost
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().StreamContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return !req.JSON && strings.Contains(req.Prompt, "protein")
+				}), mock.Anything).RunAndReturn(streamed("*Protein*\n\n", "Eat it.\n\n", "#food #gym")).Once()
+			},
+			wantChunks: []string{"*Protein*\n\n", "Eat it.\n\n", "#food #gym"},
+			want: domain.GeneratedPost{
+				Title:    "Protein",
+				Body:     "Eat it.",
+				Hashtags: []string{"#food", "#gym"},
+				Content:  "*Protein*\n\nEat it.\n\n#food #gym",
+			},
+		},
+		{
+			name: "without title and hashtags",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(streamed("Eat ", "it.")).Once()
+			},
+			wantChunks: []string{"Eat ", "it."},
+			want: domain.GeneratedPost{
+				Body:     "Eat it.",
+				Hashtags: []string{},
+				Content:  "Eat it.",
+			},
+		},
+		{
+			name: "empty post",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(streamed("#food")).Once()
+			},
+			wantChunks: []string{"#food"},
+			wantErr:    domain.ErrInvalidGeneration,
+		},
+		{
+			name:   "client disconnected",
+			cancel: true,
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, req ai.Request, onChunk func(string) error) (ai.Response, error) {
+						return ai.Response{}, ctx.Err()
+					}).Once()
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "ai error",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().StreamContent(mock.Anything, mock.Anything, mock.Anything).Return(ai.Response{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(gen)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+
+			svc := content.New(testutils.NewTestLogger(), nil, nil, nil, gen, nil, content.Options{})
+			var chunks []string
+			got, err := svc.StreamContent(ctx, params, func(chunk string) error {
+				chunks = append(chunks, chunk)
+				return nil
+			})
+			assert.Equal(t, tc.wantChunks, chunks)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_GenerateContentReview(t *testing.T) {
+	type MockBehavior func(gen *mocks.AiGenerator)
+
+	params := domain.GenerateParams{
+		Theme:    "protein",
+		Audience: domain.UserLvlBeginner,
+		Tone:     domain.ToneFriendly,
+		Length:   domain.LengthShort,
+		Language: "ru",
+	}
+	policy := domain.ReviewPolicy{Enabled: true, Rubric: "rubric", PassScore: 6}
+	generated := ai.Response{Text: `{"title":"Protein","body":"Eat it. Skip breakfast.","hashtags":["food"]}`}
+	isReview := func(req ai.Request) bool {
+		return req.System == "rubric" && strings.Contains(req.Prompt, "Skip breakfast.")
+	}
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         *domain.Review
+	}{
+		{
+			name: "pass",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(isReview)).
+					Return(ai.Response{Text: `{"safety":9,"level_fit":8,"accuracy":9,"flagged":[],"summary":"ok"}`}, nil).Once()
+			},
+			want: &domain.Review{
+				Verdict: domain.ReviewVerdictPass,
+				Scores:  domain.ReviewScores{Safety: 9, LevelFit: 8, Accuracy: 9},
+				Flagged: []domain.FlaggedSentence{},
+				Summary: "ok",
+			},
+		},
+		{
+			name: "warn drops sentences not from post",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(isReview)).
+					Return(ai.Response{Text: `{"safety":7,"level_fit":8,"accuracy":7,"flagged":[` +
+						`{"sentence":"Skip breakfast.","category":"fact","reason":"myth"},` +
+						`{"sentence":"Run a marathon.","category":"safety","reason":"unsafe"}],"summary":"minor"}`}, nil).Once()
+			},
+			want: &domain.Review{
+				Verdict: domain.ReviewVerdictWarn,
+				Scores:  domain.ReviewScores{Safety: 7, LevelFit: 8, Accuracy: 7},
+				Flagged: []domain.FlaggedSentence{{Sentence: "Skip breakfast.", Category: domain.ReviewCategoryFact, Reason: "myth"}},
+				Summary: "minor",
+			},
+		},
+		{
+			name: "fail on low score",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(isReview)).
+					Return(ai.Response{Text: `{"safety":3,"level_fit":8,"accuracy":9,"summary":"unsafe"}`}, nil).Once()
+			},
+			want: &domain.Review{
+				Verdict: domain.ReviewVerdictFail,
+				Scores:  domain.ReviewScores{Safety: 3, LevelFit: 8, Accuracy: 9},
+				Flagged: []domain.FlaggedSentence{},
+				Summary: "unsafe",
+			},
+		},
+		{
+			name: "invalid review is omitted",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(isReview)).
+					Return(ai.Response{Text: `{"safety":0}`}, nil).Once()
+			},
+		},
+		{
+			name: "reviewer error is omitted",
+			mockBehavior: func(gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(isReview)).Return(ai.Response{}, assert.AnError).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := mocks.NewAiGenerator(t)
+			gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+				return req.System == ""
+			})).Return(generated, nil).Once()
+			tc.mockBehavior(gen)
+
+			svc := content.New(testutils.NewTestLogger(), nil, nil, nil, gen, nil, content.Options{Review: policy})
+			got, err := svc.GenerateContent(context.Background(), params)
+			assert.NoError(t, err)
+			assert.Equal(t, "Eat it. Skip breakfast.", got.Body)
+			assert.Equal(t, tc.want, got.Review)
+		})
+	}
+}
+
+func TestContentService_CreatePostReview(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo, gen *mocks.AiGenerator)
+
+	in := domain.CreatePostDTO{
+		Content:  "Do 100 kg squats.",
+		Audience: domain.UserLvlBeginner,
+		Images:   []*multipart.FileHeader{},
+	}
+	failed := domain.Review{
+		Verdict: domain.ReviewVerdictFail,
+		Scores:  domain.ReviewScores{Safety: 2, LevelFit: 2, Accuracy: 8},
+		Flagged: []domain.FlaggedSentence{{Sentence: "Do 100 kg squats.", Category: domain.ReviewCategoryLevel, Reason: "too heavy"}},
+		Summary: "unsafe",
+	}
+	failedResp := ai.Response{Text: `{"safety":2,"level_fit":2,"accuracy":8,"flagged":[` +
+		`{"sentence":"Do 100 kg squats.","category":"level","reason":"too heavy"}],"summary":"unsafe"}`}
+
+	testCases := []struct {
+		name         string
+		block        bool
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name:  "blocked",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(failedResp, nil).Once()
+			},
+			wantErr: domain.ErrReviewFailed,
+		},
+		{
+			name: "saved with review when not blocking",
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(failedResp, nil).Once()
+				repo.EXPECT().Save(mock.Anything, postRepo.SavePostInput{
+					Content:  in.Content,
+					Images:   []string{},
+					Audience: in.Audience,
+					Review:   &failed,
+				}).Return(domain.Post{ID: 1, Review: &failed}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Review: &failed},
+		},
+		{
+			name:  "reviewer error when blocking",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, gen)
+
+			policy := domain.ReviewPolicy{Enabled: true, PassScore: 6, Block: tc.block}
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, gen, nil, content.Options{Review: policy})
+			got, err := svc.CreatePost(context.Background(), in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_ApprovePost(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo, gen *mocks.AiGenerator)
+
+	draft := domain.Post{ID: 1, Content: "Do 100 kg squats.", Audience: domain.UserLvlBeginner, Status: domain.PostStatusDraft}
+	failed := domain.Review{Verdict: domain.ReviewVerdictFail, Scores: domain.ReviewScores{Safety: 2, LevelFit: 2, Accuracy: 8}}
+	passed := domain.Review{Verdict: domain.ReviewVerdictPass, Scores: domain.ReviewScores{Safety: 8, LevelFit: 8, Accuracy: 8}, Flagged: []domain.FlaggedSentence{}}
+
+	testCases := []struct {
+		name         string
+		block        bool
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name: "not blocking",
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().Approve(mock.Anything, int64(1), (*domain.Review)(nil)).Return(domain.Post{ID: 1}, nil).Once()
+			},
+			want: domain.Post{ID: 1},
+		},
+		{
+			name:  "failed review",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				reviewed := draft
+				reviewed.Review = &failed
+				repo.EXPECT().PostByID(mock.Anything, int64(1)).Return(reviewed, nil).Once()
+			},
+			wantErr: domain.ErrReviewFailed,
+		},
+		{
+			name:  "not reviewed draft is reviewed again",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(1)).Return(draft, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).
+					Return(ai.Response{Text: `{"safety":8,"level_fit":8,"accuracy":8,"flagged":[],"summary":""}`}, nil).Once()
+				repo.EXPECT().Approve(mock.Anything, int64(1), &passed).Return(domain.Post{ID: 1, Review: &passed}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Review: &passed},
+		},
+		{
+			name:  "not reviewed draft fails review",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(1)).Return(draft, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).
+					Return(ai.Response{Text: `{"safety":2,"level_fit":2,"accuracy":8,"flagged":[],"summary":""}`}, nil).Once()
+			},
+			wantErr: domain.ErrReviewFailed,
+		},
+		{
+			name:  "reviewer is down",
+			block: true,
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(1)).Return(draft, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, gen)
+
+			policy := domain.ReviewPolicy{Enabled: true, PassScore: 6, Block: tc.block}
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, gen, nil, content.Options{Review: policy})
+			got, err := svc.ApprovePost(context.Background(), 1)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestContentService_RewritePost(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo, gen *mocks.AiGenerator)
+
+	source := domain.Post{ID: 7, Content: "*Squats*\n\nDo squats.", Audience: domain.UserLvlBeginner, Images: []string{"a.jpg"}}
+	rewritten := ai.Response{Text: `{"title":"Squats","body":"Do heavy squats.","hashtags":["legs"]}`}
+	want := domain.GeneratedPost{
+		Title:    "Squats",
+		Body:     "Do heavy squats.",
+		Hashtags: []string{"#legs"},
+		Content:  "*Squats*\n\nDo heavy squats.\n\n#legs",
+	}
+
+	testCases := []struct {
+		name         string
+		in           domain.RewriteDTO
+		mockBehavior MockBehavior
+		want         domain.RewriteResult
+		wantErr      error
+	}{
+		{
+			name: "adapt without save",
+			in:   domain.RewriteDTO{Transform: domain.RewriteAdaptToLevel, Level: domain.UserLvlAdvanced},
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(7)).Return(source, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return req.JSON && strings.Contains(req.Prompt, "Do squats.") && strings.Contains(req.Prompt, "опытные атлеты")
+				})).Return(rewritten, nil).Once()
+			},
+			want: domain.RewriteResult{Post: want},
+		},
+		{
+			name: "translate with save",
+			in:   domain.RewriteDTO{Transform: domain.RewriteTranslateTo, Language: "en", Save: true},
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(7)).Return(source, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "Язык ответа: en")
+				})).Return(rewritten, nil).Once()
+				repo.EXPECT().Save(mock.Anything, postRepo.SavePostInput{
+					Content:  want.Content,
+					Audience: domain.UserLvlBeginner,
+					Images:   []string{},
+					Status:   domain.PostStatusDraft,
+					SourceID: 7,
+				}).Return(domain.Post{ID: 8, SourceID: 7, Status: domain.PostStatusDraft}, nil).Once()
+			},
+			want: domain.RewriteResult{Post: want, Draft: &domain.Post{ID: 8, SourceID: 7, Status: domain.PostStatusDraft}},
+		},
+		{
+			name: "shorten repairs to limit",
+			in:   domain.RewriteDTO{Transform: domain.RewriteShortenTo, Length: 50},
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(7)).Return(source, nil).Once()
+				long := ai.Response{Text: `{"title":"Squats","body":"Do squats every day. Keep your back straight and knees out."}`}
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "не более 50 символов")
+				})).Return(long, nil).Times(3)
+			},
+			want: domain.RewriteResult{Post: domain.GeneratedPost{
+				Title:    "Squats",
+				Body:     "Do squats every day. Keep your back…",
+				Hashtags: []string{},
+				Content:  "*Squats*\n\nDo squats every day. Keep your back…",
+			}},
+		},
+		{
+			name: "post not found",
+			in:   domain.RewriteDTO{Transform: domain.RewriteChangeTone, Tone: domain.ToneExpert},
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(7)).Return(domain.Post{}, domain.ErrPostNotFound).Once()
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name: "ai error",
+			in:   domain.RewriteDTO{Transform: domain.RewriteChangeTone, Tone: domain.ToneExpert, Save: true},
+			mockBehavior: func(repo *mocks.PostRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().PostByID(mock.Anything, int64(7)).Return(source, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, gen)
+
+			svc := content.New(testutils.NewTestLogger(), repo, nil, nil, gen, nil, content.Options{})
+			got, err := svc.RewritePost(context.Background(), 7, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+const (
+	generateAttempts = 3
+	maxTitleLength   = 80
+	maxHashtags      = 5
+)
+
+var audiencePrompts = map[domain.UserLvl]string{
+	domain.UserLvlDefault:      "все подписчики, независимо от уровня подготовки",
+	domain.UserLvlBeginner:     "новички, которые только начинают тренироваться: простые слова, безопасные нагрузки, без сложных терминов",
+	domain.UserLvlIntermediate: "люди со средним уровнем подготовки, которые регулярно тренируются больше года",
+	domain.UserLvlAdvanced:     "опытные атлеты: можно использовать профессиональные термины и продвинутые методики",
+}
+
+var tonePrompts = map[domain.Tone]string{
+	domain.ToneFriendly:     "дружелюбный",
+	domain.ToneMotivational: "мотивирующий",
+	domain.ToneExpert:       "экспертный",
+	domain.ToneHumorous:     "с юмором",
+}
+
+func writeTopic(sb *strings.Builder, params domain.GenerateParams) {
+	fmt.Fprintf(sb, "Тема: %s\n", params.Theme)
+	fmt.Fprintf(sb, "Аудитория: %s\n", audiencePrompts[params.Audience])
+	fmt.Fprintf(sb, "Тон: %s\n", tonePrompts[params.Tone])
+	fmt.Fprintf(sb, "Язык ответа: %s\n\n", params.Language)
+}
+
+func writeFormat(sb *strings.Builder, limit int) {
+	sb.WriteString("Ответь строго одним JSON объектом без markdown разметки со следующими полями:\n")
+	fmt.Fprintf(sb, "\"title\" - короткий заголовок до %d символов,\n", maxTitleLength)
+	sb.WriteString("\"body\" - текст поста,\n")
+	fmt.Fprintf(sb, "\"hashtags\" - массив из 1-%d хэштегов,\n", maxHashtags)
+	sb.WriteString("\"image_prompt\" - описание изображения для поста на английском языке.\n")
+	fmt.Fprintf(sb, "Заголовок, текст и хэштеги вместе должны занимать не более %d символов.", limit)
+}
+
+func buildPrompt(params domain.GenerateParams) string {
+	var sb strings.Builder
+	writeTopic(&sb, params)
+	writeFormat(&sb, params.Length.Limit())
+	return sb.String()
+}
+
+// rewriteLimit is max length of rewritten post, only shorten_to sets its own limit
+func rewriteLimit(in domain.RewriteDTO) int {
+	if in.Transform == domain.RewriteShortenTo {
+		return in.Length
+	}
+	return domain.MaxPostLength
+}
+
+func buildRewritePrompt(content string, audience domain.UserLvl, in domain.RewriteDTO) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Исходный пост:\n%s\n\n", content)
+	switch in.Transform {
+	case domain.RewriteAdaptToLevel:
+		sb.WriteString("Задача: перепиши пост для другой аудитории, сохрани тему и главные советы, ")
+		sb.WriteString("но адаптируй нагрузки, упражнения и термины под уровень аудитории.\n")
+	case domain.RewriteShortenTo:
+		fmt.Fprintf(&sb, "Задача: сократи пост до %d символов, сохрани главную мысль и убери второстепенное.\n", in.Length)
+	case domain.RewriteTranslateTo:
+		fmt.Fprintf(&sb, "Задача: переведи пост на язык %s, сохрани смысл и подачу, переведи хэштеги.\n", in.Language)
+	case domain.RewriteChangeTone:
+		fmt.Fprintf(&sb, "Задача: перепиши пост в тоне \"%s\", сохрани смысл и советы.\n", tonePrompts[in.Tone])
+	}
+	fmt.Fprintf(&sb, "Аудитория: %s\n", audiencePrompts[audience])
+	if in.Transform == domain.RewriteTranslateTo {
+		fmt.Fprintf(&sb, "Язык ответа: %s\n\n", in.Language)
+	} else {
+		sb.WriteString("Язык ответа: тот же, что у исходного поста\n\n")
+	}
+	writeFormat(&sb, rewriteLimit(in))
+	return sb.String()
+}
+
+// buildStreamPrompt asks for ready to post text instead of json, so streamed chunks can be shown as is
+func buildStreamPrompt(params domain.GenerateParams) string {
+	var sb strings.Builder
+	writeTopic(&sb, params)
+	sb.WriteString("Ответь только текстом поста без markdown разметки и пояснений:\n")
+	fmt.Fprintf(&sb, "первая строка - короткий заголовок до %d символов,\n", maxTitleLength)
+	sb.WriteString("затем пустая строка и текст поста,\n")
+	fmt.Fprintf(&sb, "последняя строка - 1-%d хэштегов через пробел.\n", maxHashtags)
+	fmt.Fprintf(&sb, "Весь пост должен занимать не более %d символов.", params.Length.Limit())
+	return sb.String()
+}
+
+func repairPrompt(prompt, raw string, reason error) string {
+	return fmt.Sprintf("%s\n\nПредыдущий ответ не подошёл: %s.\nПредыдущий ответ:\n%s\n\nИсправь ответ и верни только JSON.", prompt, reason, raw)
+}
+
+type generatedJSON struct {
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	Hashtags    []string `json:"hashtags"`
+	ImagePrompt string   `json:"image_prompt"`
+}
+
+// parseGenerated extracts json object from model response, models often wrap it in markdown code block
+func parseGenerated(raw string) (domain.GeneratedPost, error) {
+	start, end := strings.IndexByte(raw, '{'), strings.LastIndexByte(raw, '}')
+	if start == -1 || end < start {
+		return domain.GeneratedPost{}, errors.New("response is not a json object")
+	}
+
+	var out generatedJSON
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &out); err != nil {
+		return domain.GeneratedPost{}, fmt.Errorf("invalid json: %w", err)
+	}
+
+	post := domain.GeneratedPost{
+		Title:       strings.TrimSpace(strings.ReplaceAll(out.Title, "*", "")),
+		Body:        strings.TrimSpace(out.Body),
+		Hashtags:    normalizeHashtags(out.Hashtags),
+		ImagePrompt: strings.TrimSpace(out.ImagePrompt),
+	}
+	post.Content = assembleContent(post)
+	return post, nil
+}
+
+// parsePlain splits streamed text into title, body and trailing hashtags line
+func parsePlain(raw string) domain.GeneratedPost {
+	lines := strings.Split(strings.TrimSpace(raw), "\n")
+
+	var post domain.GeneratedPost
+	if len(lines) > 1 {
+		post.Title = strings.TrimSpace(strings.Trim(lines[0], "*# "))
+		lines = lines[1:]
+	}
+	if last := strings.Fields(lines[len(lines)-1]); len(last) > 0 && isHashtags(last) {
+		post.Hashtags = last
+		lines = lines[:len(lines)-1]
+	}
+	post.Hashtags = normalizeHashtags(post.Hashtags)
+	post.Body = strings.TrimSpace(strings.Join(lines, "\n"))
+	post.Content = assembleContent(post)
+	return post
+}
+
+func isHashtags(words []string) bool {
+	for _, word := range words {
+		if !strings.HasPrefix(word, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeHashtags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.TrimLeft(tag, "# ")), "_")
+		if tag == "" {
+			continue
+		}
+		tag = "#" + tag
+		if !containsFold(res, tag) {
+			res = append(res, tag)
+		}
+		if len(res) == maxHashtags {
+			break
+		}
+	}
+	return res
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func assembleContent(post domain.GeneratedPost) string {
+	parts := make([]string, 0, 3)
+	if post.Title != "" {
+		parts = append(parts, "*"+post.Title+"*")
+	}
+	parts = append(parts, post.Body)
+	if len(post.Hashtags) > 0 {
+		parts = append(parts, strings.Join(post.Hashtags, " "))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func validateGenerated(post domain.GeneratedPost, limit int) error {
+	if post.Body == "" {
+		return errors.New("body is empty")
+	}
+	if n := utf8.RuneCountInString(post.Title); n > maxTitleLength {
+		return fmt.Errorf("title is %d characters long, limit is %d", n, maxTitleLength)
+	}
+	if n := utf8.RuneCountInString(post.Content); n > limit {
+		return fmt.Errorf("post is %d characters long, limit is %d", n, limit)
+	}
+	return nil
+}
+
+// fitGenerated repairs too long post by dropping hashtags and cutting body on sentence or word boundary
+func fitGenerated(post domain.GeneratedPost, limit int) domain.GeneratedPost {
+	if title := []rune(post.Title); len(title) > maxTitleLength {
+		post.Title = strings.TrimSpace(string(title[:maxTitleLength]))
+	}
+	post.Content = assembleContent(post)
+	for len(post.Hashtags) > 0 && utf8.RuneCountInString(post.Content) > limit {
+		post.Hashtags = post.Hashtags[:len(post.Hashtags)-1]
+		post.Content = assembleContent(post)
+	}
+
+	overflow := utf8.RuneCountInString(post.Content) - limit
+	if overflow <= 0 {
+		return post
+	}
+	body := []rune(post.Body)
+	keep := len(body) - overflow - 1
+	if keep <= 0 {
+		return post
+	}
+	cut := string(body[:keep])
+	if i := strings.LastIndexAny(cut, ".!?\n"); i > len(cut)/2 {
+		cut = cut[:i+1]
+	} else if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i] + "…"
+	} else {
+		cut += "…"
+	}
+	post.Body = strings.TrimSpace(cut)
+	post.Content = assembleContent(post)
+	return post
+}
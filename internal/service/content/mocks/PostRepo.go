@@ -24,6 +24,123 @@ func (_m *PostRepo) EXPECT() *PostRepo_Expecter {
 	return &PostRepo_Expecter{mock: &_m.Mock}
 }
 
+// Approve provides a mock function with given fields: ctx, id, review
+func (_m *PostRepo) Approve(ctx context.Context, id int64, review *domain.Review) (domain.Post, error) {
+	ret := _m.Called(ctx, id, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Approve")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.Review) (domain.Post, error)); ok {
+		return rf(ctx, id, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *domain.Review) domain.Post); ok {
+		r0 = rf(ctx, id, review)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, *domain.Review) error); ok {
+		r1 = rf(ctx, id, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Approve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Approve'
+type PostRepo_Approve_Call struct {
+	*mock.Call
+}
+
+// Approve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - review *domain.Review
+func (_e *PostRepo_Expecter) Approve(ctx interface{}, id interface{}, review interface{}) *PostRepo_Approve_Call {
+	return &PostRepo_Approve_Call{Call: _e.mock.On("Approve", ctx, id, review)}
+}
+
+func (_c *PostRepo_Approve_Call) Run(run func(ctx context.Context, id int64, review *domain.Review)) *PostRepo_Approve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*domain.Review))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Approve_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_Approve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Approve_Call) RunAndReturn(run func(context.Context, int64, *domain.Review) (domain.Post, error)) *PostRepo_Approve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Drafts provides a mock function with given fields: ctx, jobID
+func (_m *PostRepo) Drafts(ctx context.Context, jobID string) ([]domain.Post, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drafts")
+	}
+
+	var r0 []domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Post, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Post); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Drafts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drafts'
+type PostRepo_Drafts_Call struct {
+	*mock.Call
+}
+
+// Drafts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+func (_e *PostRepo_Expecter) Drafts(ctx interface{}, jobID interface{}) *PostRepo_Drafts_Call {
+	return &PostRepo_Drafts_Call{Call: _e.mock.On("Drafts", ctx, jobID)}
+}
+
+func (_c *PostRepo_Drafts_Call) Run(run func(ctx context.Context, jobID string)) *PostRepo_Drafts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Drafts_Call) Return(_a0 []domain.Post, _a1 error) *PostRepo_Drafts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Drafts_Call) RunAndReturn(run func(context.Context, string) ([]domain.Post, error)) *PostRepo_Drafts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function with given fields: ctx, audience, incoming
 func (_m *PostRepo) List(ctx context.Context, audience domain.UserLvl, incoming bool) ([]domain.Post, error) {
 	ret := _m.Called(ctx, audience, incoming)
@@ -84,6 +201,63 @@ func (_c *PostRepo_List_Call) RunAndReturn(run func(context.Context, domain.User
 	return _c
 }
 
+// PostByID provides a mock function with given fields: ctx, id
+func (_m *PostRepo) PostByID(ctx context.Context, id int64) (domain.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostByID")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_PostByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostByID'
+type PostRepo_PostByID_Call struct {
+	*mock.Call
+}
+
+// PostByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PostRepo_Expecter) PostByID(ctx interface{}, id interface{}) *PostRepo_PostByID_Call {
+	return &PostRepo_PostByID_Call{Call: _e.mock.On("PostByID", ctx, id)}
+}
+
+func (_c *PostRepo_PostByID_Call) Run(run func(ctx context.Context, id int64)) *PostRepo_PostByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PostRepo_PostByID_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_PostByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_PostByID_Call) RunAndReturn(run func(context.Context, int64) (domain.Post, error)) *PostRepo_PostByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function with given fields: ctx, id
 func (_m *PostRepo) Remove(ctx context.Context, id int64) (domain.Post, error) {
 	ret := _m.Called(ctx, id)
@@ -141,6 +315,123 @@ func (_c *PostRepo_Remove_Call) RunAndReturn(run func(context.Context, int64) (d
 	return _c
 }
 
+// Revision provides a mock function with given fields: ctx, postID, version
+func (_m *PostRepo) Revision(ctx context.Context, postID int64, version int) (domain.PostRevision, error) {
+	ret := _m.Called(ctx, postID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revision")
+	}
+
+	var r0 domain.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.PostRevision, error)); ok {
+		return rf(ctx, postID, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.PostRevision); ok {
+		r0 = rf(ctx, postID, version)
+	} else {
+		r0 = ret.Get(0).(domain.PostRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, postID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Revision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revision'
+type PostRepo_Revision_Call struct {
+	*mock.Call
+}
+
+// Revision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID int64
+//   - version int
+func (_e *PostRepo_Expecter) Revision(ctx interface{}, postID interface{}, version interface{}) *PostRepo_Revision_Call {
+	return &PostRepo_Revision_Call{Call: _e.mock.On("Revision", ctx, postID, version)}
+}
+
+func (_c *PostRepo_Revision_Call) Run(run func(ctx context.Context, postID int64, version int)) *PostRepo_Revision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Revision_Call) Return(_a0 domain.PostRevision, _a1 error) *PostRepo_Revision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Revision_Call) RunAndReturn(run func(context.Context, int64, int) (domain.PostRevision, error)) *PostRepo_Revision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revisions provides a mock function with given fields: ctx, postID
+func (_m *PostRepo) Revisions(ctx context.Context, postID int64) ([]domain.PostRevision, error) {
+	ret := _m.Called(ctx, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revisions")
+	}
+
+	var r0 []domain.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.PostRevision, error)); ok {
+		return rf(ctx, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PostRevision); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Revisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revisions'
+type PostRepo_Revisions_Call struct {
+	*mock.Call
+}
+
+// Revisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID int64
+func (_e *PostRepo_Expecter) Revisions(ctx interface{}, postID interface{}) *PostRepo_Revisions_Call {
+	return &PostRepo_Revisions_Call{Call: _e.mock.On("Revisions", ctx, postID)}
+}
+
+func (_c *PostRepo_Revisions_Call) Run(run func(ctx context.Context, postID int64)) *PostRepo_Revisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Revisions_Call) Return(_a0 []domain.PostRevision, _a1 error) *PostRepo_Revisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Revisions_Call) RunAndReturn(run func(context.Context, int64) ([]domain.PostRevision, error)) *PostRepo_Revisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, in
 func (_m *PostRepo) Save(ctx context.Context, in post.SavePostInput) (domain.Post, error) {
 	ret := _m.Called(ctx, in)
@@ -198,6 +489,122 @@ func (_c *PostRepo_Save_Call) RunAndReturn(run func(context.Context, post.SavePo
 	return _c
 }
 
+// SetEvergreen provides a mock function with given fields: ctx, id, evergreen
+func (_m *PostRepo) SetEvergreen(ctx context.Context, id int64, evergreen bool) (domain.Post, error) {
+	ret := _m.Called(ctx, id, evergreen)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEvergreen")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) (domain.Post, error)); ok {
+		return rf(ctx, id, evergreen)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, bool) domain.Post); ok {
+		r0 = rf(ctx, id, evergreen)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, bool) error); ok {
+		r1 = rf(ctx, id, evergreen)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_SetEvergreen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEvergreen'
+type PostRepo_SetEvergreen_Call struct {
+	*mock.Call
+}
+
+// SetEvergreen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - evergreen bool
+func (_e *PostRepo_Expecter) SetEvergreen(ctx interface{}, id interface{}, evergreen interface{}) *PostRepo_SetEvergreen_Call {
+	return &PostRepo_SetEvergreen_Call{Call: _e.mock.On("SetEvergreen", ctx, id, evergreen)}
+}
+
+func (_c *PostRepo_SetEvergreen_Call) Run(run func(ctx context.Context, id int64, evergreen bool)) *PostRepo_SetEvergreen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *PostRepo_SetEvergreen_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_SetEvergreen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_SetEvergreen_Call) RunAndReturn(run func(context.Context, int64, bool) (domain.Post, error)) *PostRepo_SetEvergreen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, id, in
+func (_m *PostRepo) Update(ctx context.Context, id int64, in post.UpdatePostInput) (domain.Post, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, post.UpdatePostInput) (domain.Post, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, post.UpdatePostInput) domain.Post); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, post.UpdatePostInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type PostRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - in post.UpdatePostInput
+func (_e *PostRepo_Expecter) Update(ctx interface{}, id interface{}, in interface{}) *PostRepo_Update_Call {
+	return &PostRepo_Update_Call{Call: _e.mock.On("Update", ctx, id, in)}
+}
+
+func (_c *PostRepo_Update_Call) Run(run func(ctx context.Context, id int64, in post.UpdatePostInput)) *PostRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(post.UpdatePostInput))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Update_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Update_Call) RunAndReturn(run func(context.Context, int64, post.UpdatePostInput) (domain.Post, error)) *PostRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewPostRepo creates a new instance of PostRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostRepo(t interface {
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PromptRepo is an autogenerated mock type for the PromptRepo type
+type PromptRepo struct {
+	mock.Mock
+}
+
+type PromptRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PromptRepo) EXPECT() *PromptRepo_Expecter {
+	return &PromptRepo_Expecter{mock: &_m.Mock}
+}
+
+// Version provides a mock function with given fields: ctx, id, version
+func (_m *PromptRepo) Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Version")
+	}
+
+	var r0 domain.PromptVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.PromptVersion, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.PromptVersion); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.PromptVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Version_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Version'
+type PromptRepo_Version_Call struct {
+	*mock.Call
+}
+
+// Version is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *PromptRepo_Expecter) Version(ctx interface{}, id interface{}, version interface{}) *PromptRepo_Version_Call {
+	return &PromptRepo_Version_Call{Call: _e.mock.On("Version", ctx, id, version)}
+}
+
+func (_c *PromptRepo_Version_Call) Run(run func(ctx context.Context, id int64, version int)) *PromptRepo_Version_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Version_Call) Return(_a0 domain.PromptVersion, _a1 error) *PromptRepo_Version_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Version_Call) RunAndReturn(run func(context.Context, int64, int) (domain.PromptVersion, error)) *PromptRepo_Version_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPromptRepo creates a new instance of PromptRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromptRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromptRepo {
+	mock := &PromptRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
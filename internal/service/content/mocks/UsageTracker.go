@@ -0,0 +1,132 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UsageTracker is an autogenerated mock type for the UsageTracker type
+type UsageTracker struct {
+	mock.Mock
+}
+
+type UsageTracker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UsageTracker) EXPECT() *UsageTracker_Expecter {
+	return &UsageTracker_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, admin, tokens
+func (_m *UsageTracker) Record(ctx context.Context, admin string, tokens domain.TokenUsage) error {
+	ret := _m.Called(ctx, admin, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.TokenUsage) error); ok {
+		r0 = rf(ctx, admin, tokens)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsageTracker_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type UsageTracker_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - tokens domain.TokenUsage
+func (_e *UsageTracker_Expecter) Record(ctx interface{}, admin interface{}, tokens interface{}) *UsageTracker_Record_Call {
+	return &UsageTracker_Record_Call{Call: _e.mock.On("Record", ctx, admin, tokens)}
+}
+
+func (_c *UsageTracker_Record_Call) Run(run func(ctx context.Context, admin string, tokens domain.TokenUsage)) *UsageTracker_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.TokenUsage))
+	})
+	return _c
+}
+
+func (_c *UsageTracker_Record_Call) Return(_a0 error) *UsageTracker_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsageTracker_Record_Call) RunAndReturn(run func(context.Context, string, domain.TokenUsage) error) *UsageTracker_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, admin
+func (_m *UsageTracker) Reserve(ctx context.Context, admin string) error {
+	ret := _m.Called(ctx, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, admin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsageTracker_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type UsageTracker_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+func (_e *UsageTracker_Expecter) Reserve(ctx interface{}, admin interface{}) *UsageTracker_Reserve_Call {
+	return &UsageTracker_Reserve_Call{Call: _e.mock.On("Reserve", ctx, admin)}
+}
+
+func (_c *UsageTracker_Reserve_Call) Run(run func(ctx context.Context, admin string)) *UsageTracker_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UsageTracker_Reserve_Call) Return(_a0 error) *UsageTracker_Reserve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsageTracker_Reserve_Call) RunAndReturn(run func(context.Context, string) error) *UsageTracker_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUsageTracker creates a new instance of UsageTracker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsageTracker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsageTracker {
+	mock := &UsageTracker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
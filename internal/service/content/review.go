@@ -0,0 +1,143 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+)
+
+const (
+	minReviewScore = 1
+	maxReviewScore = 10
+)
+
+var reviewCategories = []domain.ReviewCategory{domain.ReviewCategorySafety, domain.ReviewCategoryLevel, domain.ReviewCategoryFact}
+
+func buildReviewPrompt(content string, audience domain.UserLvl) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Аудитория: %s\n\n", audiencePrompts[audience])
+	fmt.Fprintf(&sb, "Пост:\n%s\n\n", content)
+	sb.WriteString("Оцени пост и ответь строго одним JSON объектом без markdown разметки со следующими полями:\n")
+	fmt.Fprintf(&sb, "\"safety\" - безопасность советов от %d до %d,\n", minReviewScore, maxReviewScore)
+	fmt.Fprintf(&sb, "\"level_fit\" - соответствие уровню аудитории от %d до %d,\n", minReviewScore, maxReviewScore)
+	fmt.Fprintf(&sb, "\"accuracy\" - фактическая точность от %d до %d,\n", minReviewScore, maxReviewScore)
+	sb.WriteString("\"flagged\" - массив проблемных предложений, каждое с полями \"sentence\" (точная цитата из поста), ")
+	sb.WriteString("\"category\" (safety, level или fact) и \"reason\",\n")
+	sb.WriteString("\"summary\" - краткий вывод в одно предложение.")
+	return sb.String()
+}
+
+type reviewJSON struct {
+	Safety   int                      `json:"safety"`
+	LevelFit int                      `json:"level_fit"`
+	Accuracy int                      `json:"accuracy"`
+	Flagged  []domain.FlaggedSentence `json:"flagged"`
+	Summary  string                   `json:"summary"`
+}
+
+// parseReview extracts reviewer scores, flagged sentences which are not quoted from the post are dropped
+func parseReview(raw, content string) (domain.Review, error) {
+	start, end := strings.IndexByte(raw, '{'), strings.LastIndexByte(raw, '}')
+	if start == -1 || end < start {
+		return domain.Review{}, errors.New("response is not a json object")
+	}
+	var res reviewJSON
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &res); err != nil {
+		return domain.Review{}, fmt.Errorf("invalid json: %w", err)
+	}
+
+	scores := domain.ReviewScores{Safety: res.Safety, LevelFit: res.LevelFit, Accuracy: res.Accuracy}
+	for _, score := range []int{scores.Safety, scores.LevelFit, scores.Accuracy} {
+		if score < minReviewScore || score > maxReviewScore {
+			return domain.Review{}, fmt.Errorf("score %d is out of range", score)
+		}
+	}
+
+	review := domain.Review{
+		Scores:  scores,
+		Flagged: make([]domain.FlaggedSentence, 0, len(res.Flagged)),
+		Summary: strings.TrimSpace(res.Summary),
+	}
+	for _, flagged := range res.Flagged {
+		flagged.Sentence = strings.TrimSpace(flagged.Sentence)
+		if flagged.Sentence == "" || !strings.Contains(content, flagged.Sentence) {
+			continue
+		}
+		if !slices.Contains(reviewCategories, flagged.Category) {
+			flagged.Category = domain.ReviewCategorySafety
+		}
+		review.Flagged = append(review.Flagged, flagged)
+	}
+	return review, nil
+}
+
+// reviewVerdict is computed locally, so pass score is not up to the model
+func reviewVerdict(review domain.Review, passScore int) domain.ReviewVerdict {
+	switch {
+	case review.Scores.Min() < passScore:
+		return domain.ReviewVerdictFail
+	case len(review.Flagged) > 0:
+		return domain.ReviewVerdictWarn
+	default:
+		return domain.ReviewVerdictPass
+	}
+}
+
+// review asks model to score post with configured rubric, nil review means review is disabled
+func (s *postService) review(ctx context.Context, logger *slog.Logger, content string, audience domain.UserLvl) (*domain.Review, error) {
+	if !s.policy.Enabled {
+		return nil, nil
+	}
+
+	resp, err := s.ai.GenerateContent(ctx, ai.Request{
+		Prompt: buildReviewPrompt(content, audience),
+		System: s.policy.Rubric,
+		JSON:   true,
+	})
+	if err != nil {
+		logger.Error("failed to review content", "error", err)
+		return nil, err
+	}
+	s.recordUsage(ctx, resp.Usage)
+
+	review, err := parseReview(resp.Text, content)
+	if err != nil {
+		logger.Warn("review response rejected", "reason", err)
+		return nil, err
+	}
+	review.Verdict = reviewVerdict(review, s.policy.PassScore)
+	return &review, nil
+}
+
+// attachReview does not fail generation, post is returned without review if reviewer failed
+func (s *postService) attachReview(ctx context.Context, logger *slog.Logger, post *domain.GeneratedPost, audience domain.UserLvl) {
+	review, err := s.review(ctx, logger, post.Content, audience)
+	if err != nil {
+		return
+	}
+	post.Review = review
+}
+
+// checkReview reviews post before saving, failed post is rejected when policy blocks it
+func (s *postService) checkReview(ctx context.Context, logger *slog.Logger, content string, audience domain.UserLvl) (*domain.Review, error) {
+	review, err := s.review(ctx, logger, content, audience)
+	if err != nil {
+		// without review nothing guarantees the post is safe
+		if s.policy.Block {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if review != nil && review.Verdict == domain.ReviewVerdictFail && s.policy.Block {
+		logger.Info("post blocked by review", "summary", review.Summary)
+		return nil, &domain.ReviewFailedError{Review: *review}
+	}
+	return review, nil
+}
@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type DeliveryRepo interface {
+	Enqueue(ctx context.Context, runID, postID int64, userIDs []int64) error
+	Pending(ctx context.Context, afterID int64, limit int) ([]domain.Delivery, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+	Expire(ctx context.Context, before time.Time) (int64, error)
+	Received(ctx context.Context, postID int64, userIDs []int64, since time.Time) ([]int64, error)
+}
+
+type Options struct {
+	// QuietHours are local hours when nothing is sent regardless of user window, empty window disables them
+	QuietHours domain.DeliveryWindow
+	// MaxDelay is how long delivery waits for user window before it expires, zero means forever
+	MaxDelay time.Duration
+	// ResendInterval is how long user is not sent the same post again
+	ResendInterval time.Duration
+	// BatchSize limits due deliveries returned at once and size of pages read from repo
+	BatchSize int
+}
+
+const defaultBatchSize = 500
+
+type service struct {
+	logger       *slog.Logger
+	deliveryRepo DeliveryRepo
+	opts         Options
+}
+
+func New(logger *slog.Logger, deliveryRepo DeliveryRepo, opts Options) *service {
+	return &service{logger, deliveryRepo, opts}
+}
+
+// Enqueue schedules post for users, it is sent when user delivery window opens
+func (s *service) Enqueue(ctx context.Context, runID, postID int64, userIDs []int64) error {
+	const op = "delivery.Enqueue"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("run_id", runID), slog.Int64("post_id", postID))
+
+	if err := s.deliveryRepo.Enqueue(ctx, runID, postID, userIDs); err != nil {
+		logger.Error("failed to enqueue deliveries", "error", err, "count", len(userIDs))
+		return err
+	}
+	return nil
+}
+
+// Due returns up to batch size pending deliveries which can be sent at now in local time of users.
+// Pages are read until batch is full, so deliveries waiting for later windows do not block due ones.
+func (s *service) Due(ctx context.Context, now time.Time) ([]domain.Delivery, error) {
+	const op = "delivery.Due"
+	logger := s.logger.With(slog.String("op", op))
+
+	if s.opts.MaxDelay > 0 {
+		expired, err := s.deliveryRepo.Expire(ctx, now.Add(-s.opts.MaxDelay))
+		if err != nil {
+			logger.Error("failed to expire deliveries", "error", err)
+			return nil, err
+		}
+		if expired > 0 {
+			logger.Warn("deliveries expired outside of user windows", "count", expired)
+		}
+	}
+
+	batch := s.opts.BatchSize
+	if batch <= 0 {
+		batch = defaultBatchSize
+	}
+
+	locations := make(map[string]*time.Location)
+	due := make([]domain.Delivery, 0)
+	var afterID int64
+	for {
+		pending, err := s.deliveryRepo.Pending(ctx, afterID, batch)
+		if err != nil {
+			logger.Error("failed to get pending deliveries", "error", err)
+			return nil, err
+		}
+
+		for _, d := range pending {
+			loc, ok := locations[d.TimeZone]
+			if !ok {
+				loc, err = time.LoadLocation(d.TimeZone)
+				if err != nil {
+					logger.Warn("unknown user time zone", "user_id", d.UserID, "time_zone", d.TimeZone)
+					loc, _ = time.LoadLocation(domain.DefaultTimeZone)
+				}
+				locations[d.TimeZone] = loc
+			}
+			if s.allowed(d.Window, now.In(loc).Hour()) {
+				due = append(due, d)
+				if len(due) == batch {
+					return due, nil
+				}
+			}
+		}
+		if len(pending) < batch {
+			return due, nil
+		}
+		afterID = pending[len(pending)-1].ID
+	}
+}
+
+// ExcludeRecent drops users which were sent post within resend interval before now
+func (s *service) ExcludeRecent(ctx context.Context, postID int64, userIDs []int64, now time.Time) ([]int64, error) {
+	const op = "delivery.ExcludeRecent"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("post_id", postID))
+
+	received, err := s.deliveryRepo.Received(ctx, postID, userIDs, now.Add(-s.opts.ResendInterval))
+	if err != nil {
+		logger.Error("failed to get received deliveries", "error", err)
+		return nil, err
+	}
+	if len(received) == 0 {
+		return userIDs, nil
+	}
+
+	skip := make(map[int64]struct{}, len(received))
+	for _, id := range received {
+		skip[id] = struct{}{}
+	}
+	res := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := skip[id]; !ok {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) allowed(window domain.DeliveryWindow, hour int) bool {
+	if s.opts.QuietHours.Valid() && s.opts.QuietHours.Contains(hour) {
+		return false
+	}
+	return window.Contains(hour)
+}
+
+// Complete saves result of sending, sendErr is nil when delivery was sent
+func (s *service) Complete(ctx context.Context, id int64, sendErr error) error {
+	const op = "delivery.Complete"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	var err error
+	if sendErr != nil {
+		err = s.deliveryRepo.MarkFailed(ctx, id, sendErr.Error())
+	} else {
+		err = s.deliveryRepo.MarkSent(ctx, id)
+	}
+	if err != nil {
+		logger.Error("failed to complete delivery", "error", err)
+		return err
+	}
+	return nil
+}
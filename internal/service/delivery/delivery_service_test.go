@@ -0,0 +1,176 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/delivery"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/delivery/mocks"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliveryService_Due(t *testing.T) {
+	type MockBehavior func(repo *mocks.DeliveryRepo)
+
+	// 06:30 UTC is 09:30 in Moscow, 13:30 in Novosibirsk and 22:30 in Los Angeles of the previous day
+	now := time.Date(2025, 3, 1, 6, 30, 0, 0, time.UTC)
+	daytime := domain.DeliveryWindow{Start: 9, End: 21}
+	opts := delivery.Options{QuietHours: domain.DeliveryWindow{Start: 22, End: 8}, MaxDelay: 24 * time.Hour}
+
+	testCases := []struct {
+		name         string
+		opts         delivery.Options
+		mockBehavior MockBehavior
+		want         []int64
+		wantErr      error
+	}{
+		{
+			name: "windows in user time zones",
+			opts: opts,
+			mockBehavior: func(repo *mocks.DeliveryRepo) {
+				repo.EXPECT().Expire(mock.Anything, now.Add(-24*time.Hour)).Return(0, nil).Once()
+				repo.EXPECT().Pending(mock.Anything, int64(0), 500).Return([]domain.Delivery{
+					{ID: 1, TimeZone: "Europe/Moscow", Window: daytime},
+					{ID: 2, TimeZone: "Europe/Moscow", Window: domain.DeliveryWindow{Start: 17, End: 22}},
+					{ID: 3, TimeZone: "Asia/Novosibirsk", Window: domain.DeliveryWindow{Start: 12, End: 17}},
+					{ID: 4, TimeZone: "UTC", Window: daytime},
+				}, nil).Once()
+			},
+			want: []int64{1, 3},
+		},
+		{
+			name: "quiet hours override window",
+			opts: opts,
+			mockBehavior: func(repo *mocks.DeliveryRepo) {
+				repo.EXPECT().Expire(mock.Anything, mock.Anything).Return(2, nil).Once()
+				repo.EXPECT().Pending(mock.Anything, int64(0), 500).Return([]domain.Delivery{
+					{ID: 1, TimeZone: "America/Los_Angeles", Window: domain.DeliveryWindow{Start: 20, End: 23}},
+					{ID: 2, TimeZone: "Unknown/Zone", Window: daytime},
+				}, nil).Once()
+			},
+			want: []int64{2},
+		},
+		{
+			name: "no quiet hours and delay",
+			opts: delivery.Options{},
+			mockBehavior: func(repo *mocks.DeliveryRepo) {
+				repo.EXPECT().Pending(mock.Anything, int64(0), 500).Return([]domain.Delivery{
+					{ID: 1, TimeZone: "America/Los_Angeles", Window: domain.DeliveryWindow{Start: 20, End: 23}},
+				}, nil).Once()
+			},
+			want: []int64{1},
+		},
+		{
+			name: "repo error",
+			opts: opts,
+			mockBehavior: func(repo *mocks.DeliveryRepo) {
+				repo.EXPECT().Expire(mock.Anything, mock.Anything).Return(0, nil).Once()
+				repo.EXPECT().Pending(mock.Anything, int64(0), 500).Return(nil, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewDeliveryRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := delivery.New(testutils.NewTestLogger(), repo, tc.opts)
+			got, err := svc.Due(context.Background(), now)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]int64, 0, len(got))
+			for _, d := range got {
+				ids = append(ids, d.ID)
+			}
+			assert.Equal(t, tc.want, ids)
+		})
+	}
+}
+
+func TestDeliveryService_DuePages(t *testing.T) {
+	now := time.Date(2025, 3, 1, 6, 30, 0, 0, time.UTC)
+	open := domain.DeliveryWindow{Start: 6, End: 12}
+	closed := domain.DeliveryWindow{Start: 20, End: 22}
+
+	t.Run("reads pages until batch is full", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().Pending(mock.Anything, int64(0), 2).Return([]domain.Delivery{
+			{ID: 1, TimeZone: "UTC", Window: closed},
+			{ID: 2, TimeZone: "UTC", Window: closed},
+		}, nil).Once()
+		repo.EXPECT().Pending(mock.Anything, int64(2), 2).Return([]domain.Delivery{
+			{ID: 3, TimeZone: "UTC", Window: open},
+			{ID: 4, TimeZone: "UTC", Window: open},
+		}, nil).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, delivery.Options{BatchSize: 2})
+		due, err := svc.Due(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Len(t, due, 2)
+		assert.Equal(t, int64(3), due[0].ID)
+	})
+
+	t.Run("stops on last page", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().Pending(mock.Anything, int64(0), 2).Return([]domain.Delivery{
+			{ID: 1, TimeZone: "UTC", Window: open},
+		}, nil).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, delivery.Options{BatchSize: 2})
+		due, err := svc.Due(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Len(t, due, 1)
+	})
+}
+
+func TestDeliveryService_Complete(t *testing.T) {
+	t.Run("sent", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().MarkSent(mock.Anything, int64(1)).Return(nil).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, delivery.Options{})
+		assert.NoError(t, svc.Complete(context.Background(), 1, nil))
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().MarkFailed(mock.Anything, int64(1), "bot was blocked by the user").Return(nil).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, delivery.Options{})
+		assert.NoError(t, svc.Complete(context.Background(), 1, errors.New("bot was blocked by the user")))
+	})
+}
+
+func TestDeliveryService_ExcludeRecent(t *testing.T) {
+	now := time.Date(2025, 3, 1, 6, 30, 0, 0, time.UTC)
+	opts := delivery.Options{ResendInterval: 30 * 24 * time.Hour}
+
+	t.Run("drops recent receivers", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().Received(mock.Anything, int64(7), []int64{1, 2, 3}, now.Add(-30*24*time.Hour)).Return([]int64{2}, nil).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, opts)
+		got, err := svc.ExcludeRecent(context.Background(), 7, []int64{1, 2, 3}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, got)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		repo := mocks.NewDeliveryRepo(t)
+		repo.EXPECT().Received(mock.Anything, int64(7), []int64{1}, mock.Anything).Return(nil, assert.AnError).Once()
+
+		svc := delivery.New(testutils.NewTestLogger(), repo, opts)
+		_, err := svc.ExcludeRecent(context.Background(), 7, []int64{1}, now)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,362 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DeliveryRepo is an autogenerated mock type for the DeliveryRepo type
+type DeliveryRepo struct {
+	mock.Mock
+}
+
+type DeliveryRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeliveryRepo) EXPECT() *DeliveryRepo_Expecter {
+	return &DeliveryRepo_Expecter{mock: &_m.Mock}
+}
+
+// Enqueue provides a mock function with given fields: ctx, runID, postID, userIDs
+func (_m *DeliveryRepo) Enqueue(ctx context.Context, runID int64, postID int64, userIDs []int64) error {
+	ret := _m.Called(ctx, runID, postID, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, []int64) error); ok {
+		r0 = rf(ctx, runID, postID, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepo_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type DeliveryRepo_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - runID int64
+//   - postID int64
+//   - userIDs []int64
+func (_e *DeliveryRepo_Expecter) Enqueue(ctx interface{}, runID interface{}, postID interface{}, userIDs interface{}) *DeliveryRepo_Enqueue_Call {
+	return &DeliveryRepo_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, runID, postID, userIDs)}
+}
+
+func (_c *DeliveryRepo_Enqueue_Call) Run(run func(ctx context.Context, runID int64, postID int64, userIDs []int64)) *DeliveryRepo_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].([]int64))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_Enqueue_Call) Return(_a0 error) *DeliveryRepo_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepo_Enqueue_Call) RunAndReturn(run func(context.Context, int64, int64, []int64) error) *DeliveryRepo_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Expire provides a mock function with given fields: ctx, before
+func (_m *DeliveryRepo) Expire(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Expire")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryRepo_Expire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Expire'
+type DeliveryRepo_Expire_Call struct {
+	*mock.Call
+}
+
+// Expire is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *DeliveryRepo_Expecter) Expire(ctx interface{}, before interface{}) *DeliveryRepo_Expire_Call {
+	return &DeliveryRepo_Expire_Call{Call: _e.mock.On("Expire", ctx, before)}
+}
+
+func (_c *DeliveryRepo_Expire_Call) Run(run func(ctx context.Context, before time.Time)) *DeliveryRepo_Expire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_Expire_Call) Return(_a0 int64, _a1 error) *DeliveryRepo_Expire_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryRepo_Expire_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *DeliveryRepo_Expire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, reason
+func (_m *DeliveryRepo) MarkFailed(ctx context.Context, id int64, reason string) error {
+	ret := _m.Called(ctx, id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepo_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type DeliveryRepo_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - reason string
+func (_e *DeliveryRepo_Expecter) MarkFailed(ctx interface{}, id interface{}, reason interface{}) *DeliveryRepo_MarkFailed_Call {
+	return &DeliveryRepo_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, reason)}
+}
+
+func (_c *DeliveryRepo_MarkFailed_Call) Run(run func(ctx context.Context, id int64, reason string)) *DeliveryRepo_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_MarkFailed_Call) Return(_a0 error) *DeliveryRepo_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepo_MarkFailed_Call) RunAndReturn(run func(context.Context, int64, string) error) *DeliveryRepo_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function with given fields: ctx, id
+func (_m *DeliveryRepo) MarkSent(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepo_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type DeliveryRepo_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *DeliveryRepo_Expecter) MarkSent(ctx interface{}, id interface{}) *DeliveryRepo_MarkSent_Call {
+	return &DeliveryRepo_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, id)}
+}
+
+func (_c *DeliveryRepo_MarkSent_Call) Run(run func(ctx context.Context, id int64)) *DeliveryRepo_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_MarkSent_Call) Return(_a0 error) *DeliveryRepo_MarkSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepo_MarkSent_Call) RunAndReturn(run func(context.Context, int64) error) *DeliveryRepo_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pending provides a mock function with given fields: ctx, afterID, limit
+func (_m *DeliveryRepo) Pending(ctx context.Context, afterID int64, limit int) ([]domain.Delivery, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pending")
+	}
+
+	var r0 []domain.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]domain.Delivery, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []domain.Delivery); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryRepo_Pending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pending'
+type DeliveryRepo_Pending_Call struct {
+	*mock.Call
+}
+
+// Pending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int64
+//   - limit int
+func (_e *DeliveryRepo_Expecter) Pending(ctx interface{}, afterID interface{}, limit interface{}) *DeliveryRepo_Pending_Call {
+	return &DeliveryRepo_Pending_Call{Call: _e.mock.On("Pending", ctx, afterID, limit)}
+}
+
+func (_c *DeliveryRepo_Pending_Call) Run(run func(ctx context.Context, afterID int64, limit int)) *DeliveryRepo_Pending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_Pending_Call) Return(_a0 []domain.Delivery, _a1 error) *DeliveryRepo_Pending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryRepo_Pending_Call) RunAndReturn(run func(context.Context, int64, int) ([]domain.Delivery, error)) *DeliveryRepo_Pending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Received provides a mock function with given fields: ctx, postID, userIDs, since
+func (_m *DeliveryRepo) Received(ctx context.Context, postID int64, userIDs []int64, since time.Time) ([]int64, error) {
+	ret := _m.Called(ctx, postID, userIDs, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Received")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, time.Time) ([]int64, error)); ok {
+		return rf(ctx, postID, userIDs, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []int64, time.Time) []int64); ok {
+		r0 = rf(ctx, postID, userIDs, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []int64, time.Time) error); ok {
+		r1 = rf(ctx, postID, userIDs, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryRepo_Received_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Received'
+type DeliveryRepo_Received_Call struct {
+	*mock.Call
+}
+
+// Received is a helper method to define mock.On call
+//   - ctx context.Context
+//   - postID int64
+//   - userIDs []int64
+//   - since time.Time
+func (_e *DeliveryRepo_Expecter) Received(ctx interface{}, postID interface{}, userIDs interface{}, since interface{}) *DeliveryRepo_Received_Call {
+	return &DeliveryRepo_Received_Call{Call: _e.mock.On("Received", ctx, postID, userIDs, since)}
+}
+
+func (_c *DeliveryRepo_Received_Call) Run(run func(ctx context.Context, postID int64, userIDs []int64, since time.Time)) *DeliveryRepo_Received_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]int64), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_Received_Call) Return(_a0 []int64, _a1 error) *DeliveryRepo_Received_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryRepo_Received_Call) RunAndReturn(run func(context.Context, int64, []int64, time.Time) ([]int64, error)) *DeliveryRepo_Received_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeliveryRepo creates a new instance of DeliveryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryRepo {
+	mock := &DeliveryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
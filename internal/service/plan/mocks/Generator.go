@@ -0,0 +1,94 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Generator is an autogenerated mock type for the Generator type
+type Generator struct {
+	mock.Mock
+}
+
+type Generator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Generator) EXPECT() *Generator_Expecter {
+	return &Generator_Expecter{mock: &_m.Mock}
+}
+
+// GenerateContent provides a mock function with given fields: ctx, params
+func (_m *Generator) GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateContent")
+	}
+
+	var r0 domain.GeneratedPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams) (domain.GeneratedPost, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.GenerateParams) domain.GeneratedPost); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(domain.GeneratedPost)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.GenerateParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Generator_GenerateContent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateContent'
+type Generator_GenerateContent_Call struct {
+	*mock.Call
+}
+
+// GenerateContent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params domain.GenerateParams
+func (_e *Generator_Expecter) GenerateContent(ctx interface{}, params interface{}) *Generator_GenerateContent_Call {
+	return &Generator_GenerateContent_Call{Call: _e.mock.On("GenerateContent", ctx, params)}
+}
+
+func (_c *Generator_GenerateContent_Call) Run(run func(ctx context.Context, params domain.GenerateParams)) *Generator_GenerateContent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.GenerateParams))
+	})
+	return _c
+}
+
+func (_c *Generator_GenerateContent_Call) Return(_a0 domain.GeneratedPost, _a1 error) *Generator_GenerateContent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Generator_GenerateContent_Call) RunAndReturn(run func(context.Context, domain.GenerateParams) (domain.GeneratedPost, error)) *Generator_GenerateContent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewGenerator creates a new instance of Generator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGenerator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Generator {
+	mock := &Generator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
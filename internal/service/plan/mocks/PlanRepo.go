@@ -0,0 +1,353 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	repoplan "github.com/SergeyBogomolovv/fitflow/internal/repo/plan"
+)
+
+// PlanRepo is an autogenerated mock type for the PlanRepo type
+type PlanRepo struct {
+	mock.Mock
+}
+
+type PlanRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PlanRepo) EXPECT() *PlanRepo_Expecter {
+	return &PlanRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimJob provides a mock function with given fields: ctx
+func (_m *PlanRepo) ClaimJob(ctx context.Context) (domain.PlanJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJob")
+	}
+
+	var r0 domain.PlanJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.PlanJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.PlanJob); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.PlanJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanRepo_ClaimJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimJob'
+type PlanRepo_ClaimJob_Call struct {
+	*mock.Call
+}
+
+// ClaimJob is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PlanRepo_Expecter) ClaimJob(ctx interface{}) *PlanRepo_ClaimJob_Call {
+	return &PlanRepo_ClaimJob_Call{Call: _e.mock.On("ClaimJob", ctx)}
+}
+
+func (_c *PlanRepo_ClaimJob_Call) Run(run func(ctx context.Context)) *PlanRepo_ClaimJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_ClaimJob_Call) Return(_a0 domain.PlanJob, _a1 error) *PlanRepo_ClaimJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanRepo_ClaimJob_Call) RunAndReturn(run func(context.Context) (domain.PlanJob, error)) *PlanRepo_ClaimJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishJob provides a mock function with given fields: ctx, id, status, errMsg
+func (_m *PlanRepo) FinishJob(ctx context.Context, id string, status domain.JobStatus, errMsg string) error {
+	ret := _m.Called(ctx, id, status, errMsg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.JobStatus, string) error); ok {
+		r0 = rf(ctx, id, status, errMsg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PlanRepo_FinishJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishJob'
+type PlanRepo_FinishJob_Call struct {
+	*mock.Call
+}
+
+// FinishJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status domain.JobStatus
+//   - errMsg string
+func (_e *PlanRepo_Expecter) FinishJob(ctx interface{}, id interface{}, status interface{}, errMsg interface{}) *PlanRepo_FinishJob_Call {
+	return &PlanRepo_FinishJob_Call{Call: _e.mock.On("FinishJob", ctx, id, status, errMsg)}
+}
+
+func (_c *PlanRepo_FinishJob_Call) Run(run func(ctx context.Context, id string, status domain.JobStatus, errMsg string)) *PlanRepo_FinishJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.JobStatus), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_FinishJob_Call) Return(_a0 error) *PlanRepo_FinishJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PlanRepo_FinishJob_Call) RunAndReturn(run func(context.Context, string, domain.JobStatus, string) error) *PlanRepo_FinishJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JobByID provides a mock function with given fields: ctx, id
+func (_m *PlanRepo) JobByID(ctx context.Context, id string) (domain.PlanJob, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobByID")
+	}
+
+	var r0 domain.PlanJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.PlanJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.PlanJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.PlanJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanRepo_JobByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JobByID'
+type PlanRepo_JobByID_Call struct {
+	*mock.Call
+}
+
+// JobByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *PlanRepo_Expecter) JobByID(ctx interface{}, id interface{}) *PlanRepo_JobByID_Call {
+	return &PlanRepo_JobByID_Call{Call: _e.mock.On("JobByID", ctx, id)}
+}
+
+func (_c *PlanRepo_JobByID_Call) Run(run func(ctx context.Context, id string)) *PlanRepo_JobByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_JobByID_Call) Return(_a0 domain.PlanJob, _a1 error) *PlanRepo_JobByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanRepo_JobByID_Call) RunAndReturn(run func(context.Context, string) (domain.PlanJob, error)) *PlanRepo_JobByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetRunning provides a mock function with given fields: ctx
+func (_m *PlanRepo) ResetRunning(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetRunning")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PlanRepo_ResetRunning_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetRunning'
+type PlanRepo_ResetRunning_Call struct {
+	*mock.Call
+}
+
+// ResetRunning is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PlanRepo_Expecter) ResetRunning(ctx interface{}) *PlanRepo_ResetRunning_Call {
+	return &PlanRepo_ResetRunning_Call{Call: _e.mock.On("ResetRunning", ctx)}
+}
+
+func (_c *PlanRepo_ResetRunning_Call) Run(run func(ctx context.Context)) *PlanRepo_ResetRunning_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_ResetRunning_Call) Return(_a0 error) *PlanRepo_ResetRunning_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PlanRepo_ResetRunning_Call) RunAndReturn(run func(context.Context) error) *PlanRepo_ResetRunning_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveJob provides a mock function with given fields: ctx, in
+func (_m *PlanRepo) SaveJob(ctx context.Context, in repoplan.SaveJobInput) (domain.PlanJob, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveJob")
+	}
+
+	var r0 domain.PlanJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repoplan.SaveJobInput) (domain.PlanJob, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repoplan.SaveJobInput) domain.PlanJob); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.PlanJob)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repoplan.SaveJobInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlanRepo_SaveJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveJob'
+type PlanRepo_SaveJob_Call struct {
+	*mock.Call
+}
+
+// SaveJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repoplan.SaveJobInput
+func (_e *PlanRepo_Expecter) SaveJob(ctx interface{}, in interface{}) *PlanRepo_SaveJob_Call {
+	return &PlanRepo_SaveJob_Call{Call: _e.mock.On("SaveJob", ctx, in)}
+}
+
+func (_c *PlanRepo_SaveJob_Call) Run(run func(ctx context.Context, in repoplan.SaveJobInput)) *PlanRepo_SaveJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repoplan.SaveJobInput))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_SaveJob_Call) Return(_a0 domain.PlanJob, _a1 error) *PlanRepo_SaveJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PlanRepo_SaveJob_Call) RunAndReturn(run func(context.Context, repoplan.SaveJobInput) (domain.PlanJob, error)) *PlanRepo_SaveJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProgress provides a mock function with given fields: ctx, id, completed, failed
+func (_m *PlanRepo) UpdateProgress(ctx context.Context, id string, completed int, failed int) error {
+	ret := _m.Called(ctx, id, completed, failed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) error); ok {
+		r0 = rf(ctx, id, completed, failed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PlanRepo_UpdateProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProgress'
+type PlanRepo_UpdateProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - completed int
+//   - failed int
+func (_e *PlanRepo_Expecter) UpdateProgress(ctx interface{}, id interface{}, completed interface{}, failed interface{}) *PlanRepo_UpdateProgress_Call {
+	return &PlanRepo_UpdateProgress_Call{Call: _e.mock.On("UpdateProgress", ctx, id, completed, failed)}
+}
+
+func (_c *PlanRepo_UpdateProgress_Call) Run(run func(ctx context.Context, id string, completed int, failed int)) *PlanRepo_UpdateProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *PlanRepo_UpdateProgress_Call) Return(_a0 error) *PlanRepo_UpdateProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PlanRepo_UpdateProgress_Call) RunAndReturn(run func(context.Context, string, int, int) error) *PlanRepo_UpdateProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPlanRepo creates a new instance of PlanRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPlanRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PlanRepo {
+	mock := &PlanRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
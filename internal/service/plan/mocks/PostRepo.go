@@ -0,0 +1,213 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	post "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+)
+
+// PostRepo is an autogenerated mock type for the PostRepo type
+type PostRepo struct {
+	mock.Mock
+}
+
+type PostRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PostRepo) EXPECT() *PostRepo_Expecter {
+	return &PostRepo_Expecter{mock: &_m.Mock}
+}
+
+// ApproveJob provides a mock function with given fields: ctx, jobID, skipFailed
+func (_m *PostRepo) ApproveJob(ctx context.Context, jobID string, skipFailed bool) (int64, error) {
+	ret := _m.Called(ctx, jobID, skipFailed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveJob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (int64, error)); ok {
+		return rf(ctx, jobID, skipFailed)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) int64); ok {
+		r0 = rf(ctx, jobID, skipFailed)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, jobID, skipFailed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_ApproveJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveJob'
+type PostRepo_ApproveJob_Call struct {
+	*mock.Call
+}
+
+// ApproveJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+//   - skipFailed bool
+func (_e *PostRepo_Expecter) ApproveJob(ctx interface{}, jobID interface{}, skipFailed interface{}) *PostRepo_ApproveJob_Call {
+	return &PostRepo_ApproveJob_Call{Call: _e.mock.On("ApproveJob", ctx, jobID, skipFailed)}
+}
+
+func (_c *PostRepo_ApproveJob_Call) Run(run func(ctx context.Context, jobID string, skipFailed bool)) *PostRepo_ApproveJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *PostRepo_ApproveJob_Call) Return(_a0 int64, _a1 error) *PostRepo_ApproveJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_ApproveJob_Call) RunAndReturn(run func(context.Context, string, bool) (int64, error)) *PostRepo_ApproveJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Drafts provides a mock function with given fields: ctx, jobID
+func (_m *PostRepo) Drafts(ctx context.Context, jobID string) ([]domain.Post, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drafts")
+	}
+
+	var r0 []domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Post, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Post); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Drafts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drafts'
+type PostRepo_Drafts_Call struct {
+	*mock.Call
+}
+
+// Drafts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+func (_e *PostRepo_Expecter) Drafts(ctx interface{}, jobID interface{}) *PostRepo_Drafts_Call {
+	return &PostRepo_Drafts_Call{Call: _e.mock.On("Drafts", ctx, jobID)}
+}
+
+func (_c *PostRepo_Drafts_Call) Run(run func(ctx context.Context, jobID string)) *PostRepo_Drafts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Drafts_Call) Return(_a0 []domain.Post, _a1 error) *PostRepo_Drafts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Drafts_Call) RunAndReturn(run func(context.Context, string) ([]domain.Post, error)) *PostRepo_Drafts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *PostRepo) Save(ctx context.Context, in post.SavePostInput) (domain.Post, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, post.SavePostInput) (domain.Post, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, post.SavePostInput) domain.Post); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, post.SavePostInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type PostRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in post.SavePostInput
+func (_e *PostRepo_Expecter) Save(ctx interface{}, in interface{}) *PostRepo_Save_Call {
+	return &PostRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *PostRepo_Save_Call) Run(run func(ctx context.Context, in post.SavePostInput)) *PostRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(post.SavePostInput))
+	})
+	return _c
+}
+
+func (_c *PostRepo_Save_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_Save_Call) RunAndReturn(run func(context.Context, post.SavePostInput) (domain.Post, error)) *PostRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPostRepo creates a new instance of PostRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPostRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PostRepo {
+	mock := &PostRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
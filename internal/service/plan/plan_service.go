@@ -0,0 +1,294 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	planRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/plan"
+	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/google/uuid"
+)
+
+type PlanRepo interface {
+	SaveJob(ctx context.Context, in planRepo.SaveJobInput) (domain.PlanJob, error)
+	JobByID(ctx context.Context, id string) (domain.PlanJob, error)
+	ClaimJob(ctx context.Context) (domain.PlanJob, error)
+	UpdateProgress(ctx context.Context, id string, completed, failed int) error
+	FinishJob(ctx context.Context, id string, status domain.JobStatus, errMsg string) error
+	ResetRunning(ctx context.Context) error
+}
+
+type PostRepo interface {
+	Save(ctx context.Context, in postRepo.SavePostInput) (domain.Post, error)
+	Drafts(ctx context.Context, jobID string) ([]domain.Post, error)
+	ApproveJob(ctx context.Context, jobID string, skipFailed bool) (int64, error)
+}
+
+type Generator interface {
+	GenerateContent(ctx context.Context, params domain.GenerateParams) (domain.GeneratedPost, error)
+}
+
+type service struct {
+	logger   *slog.Logger
+	planRepo PlanRepo
+	postRepo PostRepo
+	gen      Generator
+	wake     chan struct{}
+	// skipFailed keeps drafts which failed review or were not reviewed out of bulk approve
+	skipFailed bool
+}
+
+const (
+	maxPlanDays  = 31
+	maxPlanSlots = 100
+	pollInterval = 10 * time.Second
+)
+
+func New(logger *slog.Logger, planRepo PlanRepo, postRepo PostRepo, gen Generator, skipFailed bool) *service {
+	return &service{logger, planRepo, postRepo, gen, make(chan struct{}, 1), skipFailed}
+}
+
+func (s *service) CreatePlan(ctx context.Context, in domain.CreatePlanDTO) (domain.PlanJob, error) {
+	const op = "plan.CreatePlan"
+	logger := s.logger.With(slog.String("op", op))
+
+	params, err := buildPlan(in)
+	if err != nil {
+		return domain.PlanJob{}, err
+	}
+
+	job, err := s.planRepo.SaveJob(ctx, planRepo.SaveJobInput{
+		ID:        uuid.NewString(),
+		Params:    params,
+		CreatedBy: auth.AdminLogin(ctx),
+	})
+	if err != nil {
+		logger.Error("failed to save job", "error", err)
+		return domain.PlanJob{}, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	logger.Info("plan job created", "job_id", job.ID, "total", job.Total)
+	return job, nil
+}
+
+var planAudiences = []domain.UserLvl{
+	domain.UserLvlDefault,
+	domain.UserLvlBeginner,
+	domain.UserLvlIntermediate,
+	domain.UserLvlAdvanced,
+}
+
+// buildPlan spreads posts of every audience evenly over the date range, themes rotate per audience
+func buildPlan(in domain.CreatePlanDTO) (domain.PlanParams, error) {
+	from := in.From.UTC().Truncate(24 * time.Hour)
+	to := in.To.UTC().Truncate(24 * time.Hour)
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxPlanDays {
+		return domain.PlanParams{}, domain.ErrPlanTooLarge
+	}
+
+	slots := make([]domain.PlanSlot, 0)
+	for _, audience := range planAudiences {
+		perWeek := in.Cadence[audience]
+		if perWeek <= 0 {
+			continue
+		}
+		count := (perWeek*days + 6) / 7
+		for i := range count {
+			slots = append(slots, domain.PlanSlot{
+				Audience: audience,
+				Theme:    in.Themes[i%len(in.Themes)],
+				Date:     from.AddDate(0, 0, i*days/count),
+			})
+		}
+	}
+	if len(slots) > maxPlanSlots {
+		return domain.PlanParams{}, domain.ErrPlanTooLarge
+	}
+	slices.SortStableFunc(slots, func(a, b domain.PlanSlot) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return domain.PlanParams{
+		From:     from,
+		To:       to,
+		Cadence:  in.Cadence,
+		Themes:   in.Themes,
+		Tone:     in.Tone,
+		Length:   in.Length,
+		Language: in.Language,
+		PromptID: in.PromptID,
+		Slots:    slots,
+	}, nil
+}
+
+func (s *service) Job(ctx context.Context, id string) (domain.PlanJob, error) {
+	const op = "plan.Job"
+	logger := s.logger.With(slog.String("op", op), slog.String("job_id", id))
+
+	job, err := s.planRepo.JobByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrJobNotFound) {
+			logger.Error("failed to get job", "error", err)
+		}
+		return domain.PlanJob{}, err
+	}
+	return job, nil
+}
+
+func (s *service) JobDrafts(ctx context.Context, id string) ([]domain.Post, error) {
+	const op = "plan.JobDrafts"
+	logger := s.logger.With(slog.String("op", op), slog.String("job_id", id))
+
+	if _, err := s.Job(ctx, id); err != nil {
+		return nil, err
+	}
+
+	drafts, err := s.postRepo.Drafts(ctx, id)
+	if err != nil {
+		logger.Error("failed to get drafts", "error", err)
+		return nil, err
+	}
+	return drafts, nil
+}
+
+func (s *service) ApproveJob(ctx context.Context, id string) (int64, error) {
+	const op = "plan.ApproveJob"
+	logger := s.logger.With(slog.String("op", op), slog.String("job_id", id))
+
+	if _, err := s.Job(ctx, id); err != nil {
+		return 0, err
+	}
+
+	count, err := s.postRepo.ApproveJob(ctx, id, s.skipFailed)
+	if err != nil {
+		logger.Error("failed to approve drafts", "error", err)
+		return 0, err
+	}
+
+	logger.Info("drafts approved", "count", count)
+	return count, nil
+}
+
+// Run processes pending jobs one by one until ctx is done.
+// Only one worker may run at a time, jobs left running are returned to the queue on start.
+func (s *service) Run(ctx context.Context) {
+	const op = "plan.Run"
+	logger := s.logger.With(slog.String("op", op))
+	logger.Info("starting plan worker")
+
+	if err := s.planRepo.ResetRunning(ctx); err != nil {
+		logger.Error("failed to reset running jobs", "error", err)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			processed, err := s.ProcessNext(ctx)
+			if err != nil || !processed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessNext claims the oldest pending job and generates its drafts, reports false if there was no job
+func (s *service) ProcessNext(ctx context.Context) (bool, error) {
+	const op = "plan.ProcessNext"
+	logger := s.logger.With(slog.String("op", op))
+
+	job, err := s.planRepo.ClaimJob(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoJobs) {
+			return false, nil
+		}
+		logger.Error("failed to claim job", "error", err)
+		return false, err
+	}
+
+	s.process(ctx, job)
+	return true, nil
+}
+
+func (s *service) process(ctx context.Context, job domain.PlanJob) {
+	logger := s.logger.With(slog.String("op", "plan.process"), slog.String("job_id", job.ID))
+	ctx = context.WithValue(ctx, auth.AdminLoginKey{}, job.CreatedBy)
+
+	completed, failed := job.Completed, job.Failed
+	for _, slot := range job.Params.Slots[min(completed+failed, len(job.Params.Slots)):] {
+		// interrupted job stays running and is picked up again after restart
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := s.generateDraft(ctx, job, slot); err != nil {
+			logger.Warn("failed to generate draft", "error", err, "theme", slot.Theme, "audience", slot.Audience)
+			failed++
+		} else {
+			completed++
+		}
+
+		if err := s.planRepo.UpdateProgress(ctx, job.ID, completed, failed); err != nil {
+			logger.Error("failed to update progress", "error", err)
+		}
+	}
+
+	status, errMsg := domain.JobStatusDone, ""
+	if completed == 0 && failed > 0 {
+		status, errMsg = domain.JobStatusFailed, "failed to generate any draft"
+	}
+	if err := s.planRepo.FinishJob(ctx, job.ID, status, errMsg); err != nil {
+		logger.Error("failed to finish job", "error", err)
+		return
+	}
+	logger.Info("plan job finished", "completed", completed, "failed", failed)
+}
+
+func (s *service) generateDraft(ctx context.Context, job domain.PlanJob, slot domain.PlanSlot) error {
+	post, err := s.gen.GenerateContent(ctx, domain.GenerateParams{
+		Theme:    slot.Theme,
+		Audience: slot.Audience,
+		Tone:     job.Params.Tone,
+		Length:   job.Params.Length,
+		Language: job.Params.Language,
+		PromptID: job.Params.PromptID,
+		// slots of one job repeat themes, cached post would be saved as several identical drafts
+		NoCache: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	date := slot.Date
+	_, err = s.postRepo.Save(ctx, postRepo.SavePostInput{
+		Content:       post.Content,
+		Audience:      slot.Audience,
+		Images:        []string{},
+		Author:        job.CreatedBy,
+		Status:        domain.PostStatusDraft,
+		ScheduledFor:  &date,
+		JobID:         job.ID,
+		PromptID:      post.PromptID,
+		PromptVersion: post.PromptVersion,
+		Review:        post.Review,
+	})
+	return err
+}
@@ -0,0 +1,314 @@
+package plan_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	planRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/plan"
+	postRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/post"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/content"
+	contentMocks "github.com/SergeyBogomolovv/fitflow/internal/service/content/mocks"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/plan"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/plan/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPlanService_CreatePlan(t *testing.T) {
+	type MockBehavior func(repo *mocks.PlanRepo)
+
+	testCases := []struct {
+		name         string
+		in           domain.CreatePlanDTO
+		mockBehavior MockBehavior
+		wantSlots    []domain.PlanSlot
+		wantErr      error
+	}{
+		{
+			name: "success",
+			in: domain.CreatePlanDTO{
+				From:    date(3),
+				To:      date(9),
+				Cadence: map[domain.UserLvl]int{domain.UserLvlBeginner: 2, domain.UserLvlAdvanced: 1},
+				Themes:  []string{"protein", "sleep"},
+			},
+			mockBehavior: func(repo *mocks.PlanRepo) {
+				repo.EXPECT().SaveJob(mock.Anything, mock.MatchedBy(func(in planRepo.SaveJobInput) bool {
+					return in.ID != "" && in.CreatedBy == "admin"
+				})).RunAndReturn(func(ctx context.Context, in planRepo.SaveJobInput) (domain.PlanJob, error) {
+					return domain.PlanJob{ID: in.ID, Params: in.Params, Total: len(in.Params.Slots)}, nil
+				}).Once()
+			},
+			wantSlots: []domain.PlanSlot{
+				{Audience: domain.UserLvlBeginner, Theme: "protein", Date: date(3)},
+				{Audience: domain.UserLvlAdvanced, Theme: "protein", Date: date(3)},
+				{Audience: domain.UserLvlBeginner, Theme: "sleep", Date: date(6)},
+			},
+		},
+		{
+			name: "range too long",
+			in: domain.CreatePlanDTO{
+				From:    date(1),
+				To:      date(1).AddDate(0, 2, 0),
+				Cadence: map[domain.UserLvl]int{domain.UserLvlBeginner: 2},
+				Themes:  []string{"protein"},
+			},
+			mockBehavior: func(repo *mocks.PlanRepo) {},
+			wantErr:      domain.ErrPlanTooLarge,
+		},
+		{
+			name: "too many slots",
+			in: domain.CreatePlanDTO{
+				From: date(1),
+				To:   date(31),
+				Cadence: map[domain.UserLvl]int{
+					domain.UserLvlBeginner:     14,
+					domain.UserLvlIntermediate: 14,
+				},
+				Themes: []string{"protein"},
+			},
+			mockBehavior: func(repo *mocks.PlanRepo) {},
+			wantErr:      domain.ErrPlanTooLarge,
+		},
+		{
+			name: "failed to save",
+			in: domain.CreatePlanDTO{
+				From:    date(3),
+				To:      date(3),
+				Cadence: map[domain.UserLvl]int{domain.UserLvlBeginner: 1},
+				Themes:  []string{"protein"},
+			},
+			mockBehavior: func(repo *mocks.PlanRepo) {
+				repo.EXPECT().SaveJob(mock.Anything, mock.Anything).Return(domain.PlanJob{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPlanRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := plan.New(testutils.NewTestLogger(), repo, nil, nil, false)
+			ctx := context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin")
+			got, err := svc.CreatePlan(ctx, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantSlots, got.Params.Slots)
+			assert.Equal(t, len(tc.wantSlots), got.Total)
+		})
+	}
+}
+
+func TestPlanService_ProcessNext(t *testing.T) {
+	type MockBehavior func(repo *mocks.PlanRepo, posts *mocks.PostRepo, gen *mocks.Generator)
+
+	job := domain.PlanJob{
+		ID:        "job",
+		CreatedBy: "admin",
+		Params: domain.PlanParams{
+			Tone:     domain.ToneFriendly,
+			Length:   domain.LengthLong,
+			Language: "ru",
+			Slots: []domain.PlanSlot{
+				{Audience: domain.UserLvlBeginner, Theme: "protein", Date: date(3)},
+				{Audience: domain.UserLvlAdvanced, Theme: "sleep", Date: date(4)},
+			},
+		},
+		Total: 2,
+	}
+
+	testCases := []struct {
+		name          string
+		mockBehavior  MockBehavior
+		wantProcessed bool
+		wantErr       bool
+	}{
+		{
+			name: "no jobs",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo, gen *mocks.Generator) {
+				repo.EXPECT().ClaimJob(mock.Anything).Return(domain.PlanJob{}, domain.ErrNoJobs).Once()
+			},
+			wantProcessed: false,
+		},
+		{
+			name: "claim error",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo, gen *mocks.Generator) {
+				repo.EXPECT().ClaimJob(mock.Anything).Return(domain.PlanJob{}, assert.AnError).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "generates drafts",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo, gen *mocks.Generator) {
+				repo.EXPECT().ClaimJob(mock.Anything).Return(job, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, domain.GenerateParams{
+					Theme: "protein", Audience: domain.UserLvlBeginner, Tone: domain.ToneFriendly, Length: domain.LengthLong, Language: "ru", NoCache: true,
+				}).Return(domain.GeneratedPost{Content: "protein post"}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, assert.AnError).Once()
+				scheduled := date(3)
+				posts.EXPECT().Save(mock.Anything, postRepo.SavePostInput{
+					Content:      "protein post",
+					Audience:     domain.UserLvlBeginner,
+					Images:       []string{},
+					Author:       "admin",
+					Status:       domain.PostStatusDraft,
+					ScheduledFor: &scheduled,
+					JobID:        job.ID,
+				}).Return(domain.Post{ID: 1}, nil).Once()
+				repo.EXPECT().UpdateProgress(mock.Anything, job.ID, 1, 0).Return(nil).Once()
+				repo.EXPECT().UpdateProgress(mock.Anything, job.ID, 1, 1).Return(nil).Once()
+				repo.EXPECT().FinishJob(mock.Anything, job.ID, domain.JobStatusDone, "").Return(nil).Once()
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "resumes interrupted job",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo, gen *mocks.Generator) {
+				resumed := job
+				resumed.Failed = 1
+				repo.EXPECT().ClaimJob(mock.Anything).Return(resumed, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(domain.GeneratedPost{}, assert.AnError).Once()
+				repo.EXPECT().UpdateProgress(mock.Anything, job.ID, 0, 2).Return(nil).Once()
+				repo.EXPECT().FinishJob(mock.Anything, job.ID, domain.JobStatusFailed, mock.Anything).Return(nil).Once()
+			},
+			wantProcessed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPlanRepo(t)
+			posts := mocks.NewPostRepo(t)
+			gen := mocks.NewGenerator(t)
+			tc.mockBehavior(repo, posts, gen)
+
+			svc := plan.New(testutils.NewTestLogger(), repo, posts, gen, false)
+			processed, err := svc.ProcessNext(context.Background())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantProcessed, processed)
+		})
+	}
+}
+
+func TestPlanService_ProcessNextRepeatedThemes(t *testing.T) {
+	job := domain.PlanJob{
+		ID:        "job",
+		CreatedBy: "admin",
+		Params: domain.PlanParams{
+			Tone:     domain.ToneFriendly,
+			Length:   domain.LengthLong,
+			Language: "ru",
+			Slots: []domain.PlanSlot{
+				{Audience: domain.UserLvlBeginner, Theme: "protein", Date: date(3)},
+				{Audience: domain.UserLvlBeginner, Theme: "protein", Date: date(5)},
+				{Audience: domain.UserLvlBeginner, Theme: "protein", Date: date(7)},
+			},
+		},
+		Total: 3,
+	}
+
+	repo := mocks.NewPlanRepo(t)
+	posts := mocks.NewPostRepo(t)
+	model := contentMocks.NewAiGenerator(t)
+	usage := contentMocks.NewUsageTracker(t)
+	usage.EXPECT().Reserve(mock.Anything, "admin").Return(nil).Times(3)
+	usage.EXPECT().Record(mock.Anything, "admin", mock.Anything).Return(nil).Times(3)
+
+	repo.EXPECT().ClaimJob(mock.Anything).Return(job, nil).Once()
+	for i := range job.Params.Slots {
+		model.EXPECT().GenerateContent(mock.Anything, mock.Anything).
+			Return(ai.Response{Text: fmt.Sprintf(`{"title":"Protein %d","body":"Eat it."}`, i)}, nil).Once()
+	}
+	var drafts []string
+	posts.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, in postRepo.SavePostInput) (domain.Post, error) {
+		drafts = append(drafts, in.Content)
+		return domain.Post{ID: int64(len(drafts))}, nil
+	}).Times(3)
+	repo.EXPECT().UpdateProgress(mock.Anything, job.ID, mock.Anything, 0).Return(nil).Times(3)
+	repo.EXPECT().FinishJob(mock.Anything, job.ID, domain.JobStatusDone, "").Return(nil).Once()
+
+	// real generator with cache enabled, so repeated slots would get the same cached post
+	gen := content.New(testutils.NewTestLogger(), nil, nil, usage, model, nil, content.Options{CacheTTL: time.Hour})
+	svc := plan.New(testutils.NewTestLogger(), repo, posts, gen, false)
+	processed, err := svc.ProcessNext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	assert.Len(t, drafts, 3)
+	slices.Sort(drafts)
+	assert.Len(t, slices.Compact(drafts), 3, "drafts of repeated theme must differ")
+}
+
+func TestPlanService_ApproveJob(t *testing.T) {
+	type MockBehavior func(repo *mocks.PlanRepo, posts *mocks.PostRepo)
+
+	testCases := []struct {
+		name         string
+		block        bool
+		mockBehavior MockBehavior
+		want         int64
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo) {
+				repo.EXPECT().JobByID(mock.Anything, "job").Return(domain.PlanJob{ID: "job"}, nil).Once()
+				posts.EXPECT().ApproveJob(mock.Anything, "job", false).Return(3, nil).Once()
+			},
+			want: 3,
+		},
+		{
+			name:  "blocking skips failed and not reviewed drafts",
+			block: true,
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo) {
+				repo.EXPECT().JobByID(mock.Anything, "job").Return(domain.PlanJob{ID: "job"}, nil).Once()
+				posts.EXPECT().ApproveJob(mock.Anything, "job", true).Return(1, nil).Once()
+			},
+			want: 1,
+		},
+		{
+			name: "job not found",
+			mockBehavior: func(repo *mocks.PlanRepo, posts *mocks.PostRepo) {
+				repo.EXPECT().JobByID(mock.Anything, "job").Return(domain.PlanJob{}, domain.ErrJobNotFound).Once()
+			},
+			wantErr: domain.ErrJobNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPlanRepo(t)
+			posts := mocks.NewPostRepo(t)
+			tc.mockBehavior(repo, posts)
+
+			svc := plan.New(testutils.NewTestLogger(), repo, posts, nil, tc.block)
+			got, err := svc.ApproveJob(context.Background(), "job")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
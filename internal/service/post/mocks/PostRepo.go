@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // PostRepo is an autogenerated mock type for the PostRepo type
@@ -79,6 +81,64 @@ func (_c *PostRepo_LatestByAudience_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// LeastRecentEvergreen provides a mock function with given fields: ctx, audience, sentBefore
+func (_m *PostRepo) LeastRecentEvergreen(ctx context.Context, audience domain.UserLvl, sentBefore time.Time) (domain.Post, error) {
+	ret := _m.Called(ctx, audience, sentBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeastRecentEvergreen")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, time.Time) (domain.Post, error)); ok {
+		return rf(ctx, audience, sentBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl, time.Time) domain.Post); ok {
+		r0 = rf(ctx, audience, sentBefore)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl, time.Time) error); ok {
+		r1 = rf(ctx, audience, sentBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_LeastRecentEvergreen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeastRecentEvergreen'
+type PostRepo_LeastRecentEvergreen_Call struct {
+	*mock.Call
+}
+
+// LeastRecentEvergreen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+//   - sentBefore time.Time
+func (_e *PostRepo_Expecter) LeastRecentEvergreen(ctx interface{}, audience interface{}, sentBefore interface{}) *PostRepo_LeastRecentEvergreen_Call {
+	return &PostRepo_LeastRecentEvergreen_Call{Call: _e.mock.On("LeastRecentEvergreen", ctx, audience, sentBefore)}
+}
+
+func (_c *PostRepo_LeastRecentEvergreen_Call) Run(run func(ctx context.Context, audience domain.UserLvl, sentBefore time.Time)) *PostRepo_LeastRecentEvergreen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PostRepo_LeastRecentEvergreen_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_LeastRecentEvergreen_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_LeastRecentEvergreen_Call) RunAndReturn(run func(context.Context, domain.UserLvl, time.Time) (domain.Post, error)) *PostRepo_LeastRecentEvergreen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MarkAsPosted provides a mock function with given fields: ctx, id
 func (_m *PostRepo) MarkAsPosted(ctx context.Context, id int64) error {
 	ret := _m.Called(ctx, id)
@@ -126,6 +186,63 @@ func (_c *PostRepo_MarkAsPosted_Call) RunAndReturn(run func(context.Context, int
 	return _c
 }
 
+// PostByID provides a mock function with given fields: ctx, id
+func (_m *PostRepo) PostByID(ctx context.Context, id int64) (domain.Post, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostByID")
+	}
+
+	var r0 domain.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Post, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Post); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Post)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostRepo_PostByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostByID'
+type PostRepo_PostByID_Call struct {
+	*mock.Call
+}
+
+// PostByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PostRepo_Expecter) PostByID(ctx interface{}, id interface{}) *PostRepo_PostByID_Call {
+	return &PostRepo_PostByID_Call{Call: _e.mock.On("PostByID", ctx, id)}
+}
+
+func (_c *PostRepo_PostByID_Call) Run(run func(ctx context.Context, id int64)) *PostRepo_PostByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PostRepo_PostByID_Call) Return(_a0 domain.Post, _a1 error) *PostRepo_PostByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PostRepo_PostByID_Call) RunAndReturn(run func(context.Context, int64) (domain.Post, error)) *PostRepo_PostByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewPostRepo creates a new instance of PostRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPostRepo(t interface {
@@ -4,22 +4,27 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 )
 
 type PostRepo interface {
 	LatestByAudience(ctx context.Context, audience domain.UserLvl) (domain.Post, error)
+	LeastRecentEvergreen(ctx context.Context, audience domain.UserLvl, sentBefore time.Time) (domain.Post, error)
 	MarkAsPosted(ctx context.Context, id int64) error
+	PostByID(ctx context.Context, id int64) (domain.Post, error)
 }
 
 type postService struct {
 	logger   *slog.Logger
 	postRepo PostRepo
+	// evergreenInterval is minimal time between sends of the same evergreen post
+	evergreenInterval time.Duration
 }
 
-func New(logger *slog.Logger, repo PostRepo) *postService {
-	return &postService{logger, repo}
+func New(logger *slog.Logger, repo PostRepo, evergreenInterval time.Duration) *postService {
+	return &postService{logger, repo, evergreenInterval}
 }
 
 func (s *postService) PickLatest(ctx context.Context, audience domain.UserLvl) (domain.Post, error) {
@@ -37,6 +42,35 @@ func (s *postService) PickLatest(ctx context.Context, audience domain.UserLvl) (
 	return post, nil
 }
 
+// PickEvergreen returns evergreen post of audience which was sent the longest ago, at least evergreen interval before
+func (s *postService) PickEvergreen(ctx context.Context, audience domain.UserLvl) (domain.Post, error) {
+	const op = "post.PickEvergreen"
+	logger := s.logger.With(slog.String("op", op), slog.String("audience", string(audience)))
+
+	post, err := s.postRepo.LeastRecentEvergreen(ctx, audience, time.Now().Add(-s.evergreenInterval))
+	if err != nil {
+		if !errors.Is(err, domain.ErrNoPosts) {
+			logger.Error("failed to get evergreen post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+	return post, nil
+}
+
+func (s *postService) Post(ctx context.Context, id int64) (domain.Post, error) {
+	const op = "post.Post"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	post, err := s.postRepo.PostByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			logger.Error("failed to get post", "error", err)
+		}
+		return domain.Post{}, err
+	}
+	return post, nil
+}
+
 func (s *postService) MarkAsPosted(ctx context.Context, id int64) error {
 	const op = "post.MarkAsPosted"
 	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
@@ -3,12 +3,14 @@ package post_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 	postSvc "github.com/SergeyBogomolovv/fitflow/internal/service/post"
 	"github.com/SergeyBogomolovv/fitflow/internal/service/post/mocks"
 	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestPostService_PickLatest(t *testing.T) {
@@ -55,7 +57,7 @@ func TestPostService_PickLatest(t *testing.T) {
 			repo := mocks.NewPostRepo(t)
 			tc.mockBehavior(repo, tc.args)
 
-			svc := postSvc.New(testutils.NewTestLogger(), repo)
+			svc := postSvc.New(testutils.NewTestLogger(), repo, time.Hour)
 			got, err := svc.PickLatest(tc.args.ctx, tc.args.audience)
 			if tc.wantErr != nil {
 				assert.ErrorIs(t, err, tc.wantErr)
@@ -110,10 +112,62 @@ func TestPostService_MarkAsPosted(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			repo := mocks.NewPostRepo(t)
 			tc.mockBehavior(repo, tc.args)
-			svc := postSvc.New(testutils.NewTestLogger(), repo)
+			svc := postSvc.New(testutils.NewTestLogger(), repo, time.Hour)
 
 			got := svc.MarkAsPosted(tc.args.ctx, tc.args.id)
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestPostService_PickEvergreen(t *testing.T) {
+	type MockBehavior func(repo *mocks.PostRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.Post
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().LeastRecentEvergreen(mock.Anything, domain.UserLvlBeginner, mock.MatchedBy(func(before time.Time) bool {
+					return time.Since(before) >= 30*24*time.Hour
+				})).Return(domain.Post{ID: 1, Evergreen: true}, nil).Once()
+			},
+			want: domain.Post{ID: 1, Evergreen: true},
+		},
+		{
+			name: "no posts",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().LeastRecentEvergreen(mock.Anything, domain.UserLvlBeginner, mock.Anything).Return(domain.Post{}, domain.ErrNoPosts).Once()
+			},
+			wantErr: domain.ErrNoPosts,
+		},
+		{
+			name: "repo error",
+			mockBehavior: func(repo *mocks.PostRepo) {
+				repo.EXPECT().LeastRecentEvergreen(mock.Anything, domain.UserLvlBeginner, mock.Anything).Return(domain.Post{}, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPostRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := postSvc.New(testutils.NewTestLogger(), repo, 30*24*time.Hour)
+			got, err := svc.PickEvergreen(context.Background(), domain.UserLvlBeginner)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
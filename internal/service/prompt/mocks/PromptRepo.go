@@ -0,0 +1,491 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	repoprompt "github.com/SergeyBogomolovv/fitflow/internal/repo/prompt"
+)
+
+// PromptRepo is an autogenerated mock type for the PromptRepo type
+type PromptRepo struct {
+	mock.Mock
+}
+
+type PromptRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PromptRepo) EXPECT() *PromptRepo_Expecter {
+	return &PromptRepo_Expecter{mock: &_m.Mock}
+}
+
+// Activate provides a mock function with given fields: ctx, id, version
+func (_m *PromptRepo) Activate(ctx context.Context, id int64, version int) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.Prompt, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.Prompt); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Activate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Activate'
+type PromptRepo_Activate_Call struct {
+	*mock.Call
+}
+
+// Activate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *PromptRepo_Expecter) Activate(ctx interface{}, id interface{}, version interface{}) *PromptRepo_Activate_Call {
+	return &PromptRepo_Activate_Call{Call: _e.mock.On("Activate", ctx, id, version)}
+}
+
+func (_c *PromptRepo_Activate_Call) Run(run func(ctx context.Context, id int64, version int)) *PromptRepo_Activate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Activate_Call) Return(_a0 domain.Prompt, _a1 error) *PromptRepo_Activate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Activate_Call) RunAndReturn(run func(context.Context, int64, int) (domain.Prompt, error)) *PromptRepo_Activate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *PromptRepo) List(ctx context.Context) ([]domain.Prompt, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Prompt, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Prompt); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Prompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type PromptRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *PromptRepo_Expecter) List(ctx interface{}) *PromptRepo_List_Call {
+	return &PromptRepo_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *PromptRepo_List_Call) Run(run func(ctx context.Context)) *PromptRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_List_Call) Return(_a0 []domain.Prompt, _a1 error) *PromptRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_List_Call) RunAndReturn(run func(context.Context) ([]domain.Prompt, error)) *PromptRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PromptByID provides a mock function with given fields: ctx, id
+func (_m *PromptRepo) PromptByID(ctx context.Context, id int64) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromptByID")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.Prompt, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.Prompt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_PromptByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromptByID'
+type PromptRepo_PromptByID_Call struct {
+	*mock.Call
+}
+
+// PromptByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptRepo_Expecter) PromptByID(ctx interface{}, id interface{}) *PromptRepo_PromptByID_Call {
+	return &PromptRepo_PromptByID_Call{Call: _e.mock.On("PromptByID", ctx, id)}
+}
+
+func (_c *PromptRepo_PromptByID_Call) Run(run func(ctx context.Context, id int64)) *PromptRepo_PromptByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_PromptByID_Call) Return(_a0 domain.Prompt, _a1 error) *PromptRepo_PromptByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_PromptByID_Call) RunAndReturn(run func(context.Context, int64) (domain.Prompt, error)) *PromptRepo_PromptByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, id
+func (_m *PromptRepo) Remove(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PromptRepo_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type PromptRepo_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptRepo_Expecter) Remove(ctx interface{}, id interface{}) *PromptRepo_Remove_Call {
+	return &PromptRepo_Remove_Call{Call: _e.mock.On("Remove", ctx, id)}
+}
+
+func (_c *PromptRepo_Remove_Call) Run(run func(ctx context.Context, id int64)) *PromptRepo_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Remove_Call) Return(_a0 error) *PromptRepo_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PromptRepo_Remove_Call) RunAndReturn(run func(context.Context, int64) error) *PromptRepo_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *PromptRepo) Save(ctx context.Context, in repoprompt.SavePromptInput) (domain.Prompt, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repoprompt.SavePromptInput) (domain.Prompt, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repoprompt.SavePromptInput) domain.Prompt); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repoprompt.SavePromptInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type PromptRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repoprompt.SavePromptInput
+func (_e *PromptRepo_Expecter) Save(ctx interface{}, in interface{}) *PromptRepo_Save_Call {
+	return &PromptRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *PromptRepo_Save_Call) Run(run func(ctx context.Context, in repoprompt.SavePromptInput)) *PromptRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repoprompt.SavePromptInput))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Save_Call) Return(_a0 domain.Prompt, _a1 error) *PromptRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Save_Call) RunAndReturn(run func(context.Context, repoprompt.SavePromptInput) (domain.Prompt, error)) *PromptRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, id, in
+func (_m *PromptRepo) Update(ctx context.Context, id int64, in repoprompt.UpdatePromptInput) (domain.Prompt, error) {
+	ret := _m.Called(ctx, id, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 domain.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, repoprompt.UpdatePromptInput) (domain.Prompt, error)); ok {
+		return rf(ctx, id, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, repoprompt.UpdatePromptInput) domain.Prompt); ok {
+		r0 = rf(ctx, id, in)
+	} else {
+		r0 = ret.Get(0).(domain.Prompt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, repoprompt.UpdatePromptInput) error); ok {
+		r1 = rf(ctx, id, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type PromptRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - in repoprompt.UpdatePromptInput
+func (_e *PromptRepo_Expecter) Update(ctx interface{}, id interface{}, in interface{}) *PromptRepo_Update_Call {
+	return &PromptRepo_Update_Call{Call: _e.mock.On("Update", ctx, id, in)}
+}
+
+func (_c *PromptRepo_Update_Call) Run(run func(ctx context.Context, id int64, in repoprompt.UpdatePromptInput)) *PromptRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(repoprompt.UpdatePromptInput))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Update_Call) Return(_a0 domain.Prompt, _a1 error) *PromptRepo_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Update_Call) RunAndReturn(run func(context.Context, int64, repoprompt.UpdatePromptInput) (domain.Prompt, error)) *PromptRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Version provides a mock function with given fields: ctx, id, version
+func (_m *PromptRepo) Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Version")
+	}
+
+	var r0 domain.PromptVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (domain.PromptVersion, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) domain.PromptVersion); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(domain.PromptVersion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Version_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Version'
+type PromptRepo_Version_Call struct {
+	*mock.Call
+}
+
+// Version is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - version int
+func (_e *PromptRepo_Expecter) Version(ctx interface{}, id interface{}, version interface{}) *PromptRepo_Version_Call {
+	return &PromptRepo_Version_Call{Call: _e.mock.On("Version", ctx, id, version)}
+}
+
+func (_c *PromptRepo_Version_Call) Run(run func(ctx context.Context, id int64, version int)) *PromptRepo_Version_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Version_Call) Return(_a0 domain.PromptVersion, _a1 error) *PromptRepo_Version_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Version_Call) RunAndReturn(run func(context.Context, int64, int) (domain.PromptVersion, error)) *PromptRepo_Version_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Versions provides a mock function with given fields: ctx, id
+func (_m *PromptRepo) Versions(ctx context.Context, id int64) ([]domain.PromptVersion, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Versions")
+	}
+
+	var r0 []domain.PromptVersion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]domain.PromptVersion, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []domain.PromptVersion); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PromptVersion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PromptRepo_Versions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Versions'
+type PromptRepo_Versions_Call struct {
+	*mock.Call
+}
+
+// Versions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *PromptRepo_Expecter) Versions(ctx interface{}, id interface{}) *PromptRepo_Versions_Call {
+	return &PromptRepo_Versions_Call{Call: _e.mock.On("Versions", ctx, id)}
+}
+
+func (_c *PromptRepo_Versions_Call) Run(run func(ctx context.Context, id int64)) *PromptRepo_Versions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *PromptRepo_Versions_Call) Return(_a0 []domain.PromptVersion, _a1 error) *PromptRepo_Versions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PromptRepo_Versions_Call) RunAndReturn(run func(context.Context, int64) ([]domain.PromptVersion, error)) *PromptRepo_Versions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPromptRepo creates a new instance of PromptRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPromptRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PromptRepo {
+	mock := &PromptRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
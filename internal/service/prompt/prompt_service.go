@@ -0,0 +1,190 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	promptRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/prompt"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+)
+
+type PromptRepo interface {
+	Save(ctx context.Context, in promptRepo.SavePromptInput) (domain.Prompt, error)
+	List(ctx context.Context) ([]domain.Prompt, error)
+	PromptByID(ctx context.Context, id int64) (domain.Prompt, error)
+	Update(ctx context.Context, id int64, in promptRepo.UpdatePromptInput) (domain.Prompt, error)
+	Remove(ctx context.Context, id int64) error
+	Versions(ctx context.Context, id int64) ([]domain.PromptVersion, error)
+	Version(ctx context.Context, id int64, version int) (domain.PromptVersion, error)
+	Activate(ctx context.Context, id int64, version int) (domain.Prompt, error)
+}
+
+type promptService struct {
+	logger     *slog.Logger
+	promptRepo PromptRepo
+}
+
+func New(logger *slog.Logger, promptRepo PromptRepo) *promptService {
+	return &promptService{logger, promptRepo}
+}
+
+// sampleVars are used to check that template renders before it is saved
+var sampleVars = domain.PromptVars{
+	Theme:    "Польза протеина",
+	Level:    domain.UserLvlBeginner,
+	Language: "ru",
+	Tone:     domain.ToneFriendly,
+	Length:   domain.LengthLong,
+}
+
+func (s *promptService) CreatePrompt(ctx context.Context, in domain.CreatePromptDTO) (domain.Prompt, error) {
+	const op = "prompt.CreatePrompt"
+	logger := s.logger.With(slog.String("op", op), slog.String("name", in.Name))
+
+	if _, err := domain.RenderPrompt(in.Template, sampleVars); err != nil {
+		return domain.Prompt{}, err
+	}
+
+	prompt, err := s.promptRepo.Save(ctx, promptRepo.SavePromptInput{
+		Name:        in.Name,
+		Description: in.Description,
+		Template:    in.Template,
+		Author:      auth.AdminLogin(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptAlreadyExists) {
+			return domain.Prompt{}, err
+		}
+		logger.Error("failed to save prompt", "error", err)
+		return domain.Prompt{}, err
+	}
+
+	logger.Info("prompt created", "id", prompt.ID)
+	return prompt, nil
+}
+
+func (s *promptService) Prompts(ctx context.Context) ([]domain.Prompt, error) {
+	const op = "prompt.Prompts"
+	logger := s.logger.With(slog.String("op", op))
+
+	prompts, err := s.promptRepo.List(ctx)
+	if err != nil {
+		logger.Error("failed to get prompts", "error", err)
+		return nil, err
+	}
+	return prompts, nil
+}
+
+func (s *promptService) Prompt(ctx context.Context, id int64) (domain.Prompt, error) {
+	const op = "prompt.Prompt"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	prompt, err := s.promptRepo.PromptByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			return domain.Prompt{}, err
+		}
+		logger.Error("failed to get prompt", "error", err)
+		return domain.Prompt{}, err
+	}
+	return prompt, nil
+}
+
+// UpdatePrompt saves new template as the next version and makes it active
+func (s *promptService) UpdatePrompt(ctx context.Context, id int64, in domain.UpdatePromptDTO) (domain.Prompt, error) {
+	const op = "prompt.UpdatePrompt"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if in.Template != nil {
+		if _, err := domain.RenderPrompt(*in.Template, sampleVars); err != nil {
+			return domain.Prompt{}, err
+		}
+	}
+
+	prompt, err := s.promptRepo.Update(ctx, id, promptRepo.UpdatePromptInput{
+		Description: in.Description,
+		Template:    in.Template,
+		Author:      auth.AdminLogin(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			return domain.Prompt{}, err
+		}
+		logger.Error("failed to update prompt", "error", err)
+		return domain.Prompt{}, err
+	}
+
+	logger.Info("prompt updated", "version", prompt.ActiveVersion)
+	return prompt, nil
+}
+
+func (s *promptService) RemovePrompt(ctx context.Context, id int64) error {
+	const op = "prompt.RemovePrompt"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if err := s.promptRepo.Remove(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrPromptNotFound) {
+			return err
+		}
+		logger.Error("failed to remove prompt", "error", err)
+		return err
+	}
+
+	logger.Info("prompt removed")
+	return nil
+}
+
+func (s *promptService) PromptVersions(ctx context.Context, id int64) ([]domain.PromptVersion, error) {
+	const op = "prompt.PromptVersions"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if _, err := s.Prompt(ctx, id); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.promptRepo.Versions(ctx, id)
+	if err != nil {
+		logger.Error("failed to get prompt versions", "error", err)
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (s *promptService) PromptVersion(ctx context.Context, id int64, version int) (domain.PromptVersion, error) {
+	const op = "prompt.PromptVersion"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id), slog.Int("version", version))
+
+	v, err := s.promptRepo.Version(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptVersionNotFound) {
+			return domain.PromptVersion{}, err
+		}
+		logger.Error("failed to get prompt version", "error", err)
+		return domain.PromptVersion{}, err
+	}
+	return v, nil
+}
+
+// ActivateVersion makes one of existing versions active, used to roll back template changes
+func (s *promptService) ActivateVersion(ctx context.Context, id int64, version int) (domain.Prompt, error) {
+	const op = "prompt.ActivateVersion"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id), slog.Int("version", version))
+
+	if _, err := s.Prompt(ctx, id); err != nil {
+		return domain.Prompt{}, err
+	}
+
+	prompt, err := s.promptRepo.Activate(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrPromptVersionNotFound) {
+			return domain.Prompt{}, err
+		}
+		logger.Error("failed to activate prompt version", "error", err)
+		return domain.Prompt{}, err
+	}
+
+	logger.Info("prompt version activated")
+	return prompt, nil
+}
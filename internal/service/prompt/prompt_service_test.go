@@ -0,0 +1,194 @@
+package prompt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	promptRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/prompt"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/prompt"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/prompt/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromptService_CreatePrompt(t *testing.T) {
+	type MockBehavior func(repo *mocks.PromptRepo)
+
+	testCases := []struct {
+		name         string
+		in           domain.CreatePromptDTO
+		mockBehavior MockBehavior
+		want         domain.Prompt
+		wantErr      error
+	}{
+		{
+			name: "success",
+			in:   domain.CreatePromptDTO{Name: "expert", Template: "Theme {{.Theme}} for {{.Level}}"},
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().Save(mock.Anything, promptRepo.SavePromptInput{
+					Name:     "expert",
+					Template: "Theme {{.Theme}} for {{.Level}}",
+					Author:   "admin",
+				}).Return(domain.Prompt{ID: 1, Name: "expert", ActiveVersion: 1}, nil).Once()
+			},
+			want: domain.Prompt{ID: 1, Name: "expert", ActiveVersion: 1},
+		},
+		{
+			name:         "unknown variable",
+			in:           domain.CreatePromptDTO{Name: "expert", Template: "Theme {{.Topic}}"},
+			mockBehavior: func(repo *mocks.PromptRepo) {},
+			wantErr:      domain.ErrInvalidTemplate,
+		},
+		{
+			name:         "invalid syntax",
+			in:           domain.CreatePromptDTO{Name: "expert", Template: "Theme {{.Theme"},
+			mockBehavior: func(repo *mocks.PromptRepo) {},
+			wantErr:      domain.ErrInvalidTemplate,
+		},
+		{
+			name: "already exists",
+			in:   domain.CreatePromptDTO{Name: "expert", Template: "Theme {{.Theme}}"},
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(domain.Prompt{}, domain.ErrPromptAlreadyExists).Once()
+			},
+			wantErr: domain.ErrPromptAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPromptRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := prompt.New(testutils.NewTestLogger(), repo)
+			ctx := context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin")
+			got, err := svc.CreatePrompt(ctx, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPromptService_UpdatePrompt(t *testing.T) {
+	type MockBehavior func(repo *mocks.PromptRepo)
+
+	template := "New {{.Theme}}"
+	invalid := "New {{.Topic}}"
+	description := "new description"
+
+	testCases := []struct {
+		name         string
+		in           domain.UpdatePromptDTO
+		mockBehavior MockBehavior
+		want         domain.Prompt
+		wantErr      error
+	}{
+		{
+			name: "new version",
+			in:   domain.UpdatePromptDTO{Template: &template},
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().Update(mock.Anything, int64(1), promptRepo.UpdatePromptInput{Template: &template, Author: "admin"}).
+					Return(domain.Prompt{ID: 1, ActiveVersion: 2, Template: template}, nil).Once()
+			},
+			want: domain.Prompt{ID: 1, ActiveVersion: 2, Template: template},
+		},
+		{
+			name: "description only",
+			in:   domain.UpdatePromptDTO{Description: &description},
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().Update(mock.Anything, int64(1), promptRepo.UpdatePromptInput{Description: &description, Author: "admin"}).
+					Return(domain.Prompt{ID: 1, ActiveVersion: 1, Description: description}, nil).Once()
+			},
+			want: domain.Prompt{ID: 1, ActiveVersion: 1, Description: description},
+		},
+		{
+			name:         "invalid template",
+			in:           domain.UpdatePromptDTO{Template: &invalid},
+			mockBehavior: func(repo *mocks.PromptRepo) {},
+			wantErr:      domain.ErrInvalidTemplate,
+		},
+		{
+			name: "not found",
+			in:   domain.UpdatePromptDTO{Template: &template},
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().Update(mock.Anything, int64(1), mock.Anything).Return(domain.Prompt{}, domain.ErrPromptNotFound).Once()
+			},
+			wantErr: domain.ErrPromptNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPromptRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := prompt.New(testutils.NewTestLogger(), repo)
+			ctx := context.WithValue(context.Background(), auth.AdminLoginKey{}, "admin")
+			got, err := svc.UpdatePrompt(ctx, 1, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPromptService_ActivateVersion(t *testing.T) {
+	type MockBehavior func(repo *mocks.PromptRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.Prompt
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().PromptByID(mock.Anything, int64(1)).Return(domain.Prompt{ID: 1, ActiveVersion: 3}, nil).Once()
+				repo.EXPECT().Activate(mock.Anything, int64(1), 2).Return(domain.Prompt{ID: 1, ActiveVersion: 2}, nil).Once()
+			},
+			want: domain.Prompt{ID: 1, ActiveVersion: 2},
+		},
+		{
+			name: "prompt not found",
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().PromptByID(mock.Anything, int64(1)).Return(domain.Prompt{}, domain.ErrPromptNotFound).Once()
+			},
+			wantErr: domain.ErrPromptNotFound,
+		},
+		{
+			name: "version not found",
+			mockBehavior: func(repo *mocks.PromptRepo) {
+				repo.EXPECT().PromptByID(mock.Anything, int64(1)).Return(domain.Prompt{ID: 1, ActiveVersion: 1}, nil).Once()
+				repo.EXPECT().Activate(mock.Anything, int64(1), 2).Return(domain.Prompt{}, domain.ErrPromptVersionNotFound).Once()
+			},
+			wantErr: domain.ErrPromptVersionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewPromptRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := prompt.New(testutils.NewTestLogger(), repo)
+			got, err := svc.ActivateVersion(context.Background(), 1, 2)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
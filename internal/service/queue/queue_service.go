@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+)
+
+type PostRepo interface {
+	CountQueued(ctx context.Context) (map[domain.UserLvl]int, error)
+}
+
+type ScheduleService interface {
+	Schedules(ctx context.Context, runs int) ([]domain.Schedule, error)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg notify.Message) error
+}
+
+// cadenceRuns is number of upcoming runs which broadcast cadence is averaged over
+const cadenceRuns = 50
+
+const eventQueueDepth = "queue_depth"
+
+type service struct {
+	logger     *slog.Logger
+	postRepo   PostRepo
+	schedules  ScheduleService
+	notifier   Notifier
+	thresholds domain.QueueThresholds
+
+	mu sync.Mutex
+	// statuses are last reported statuses by audience, alerts are sent when status changes
+	statuses map[domain.UserLvl]domain.QueueStatus
+}
+
+func New(logger *slog.Logger, postRepo PostRepo, schedules ScheduleService, notifier Notifier, thresholds domain.QueueThresholds) *service {
+	return &service{
+		logger:     logger,
+		postRepo:   postRepo,
+		schedules:  schedules,
+		notifier:   notifier,
+		thresholds: thresholds,
+		statuses:   make(map[domain.UserLvl]domain.QueueStatus),
+	}
+}
+
+// Depths returns queue depth of every audience in days of content left at its schedule
+func (s *service) Depths(ctx context.Context) ([]domain.QueueDepth, error) {
+	const op = "queue.Depths"
+	logger := s.logger.With(slog.String("op", op))
+
+	counts, err := s.postRepo.CountQueued(ctx)
+	if err != nil {
+		logger.Error("failed to count queued posts", "error", err)
+		return nil, err
+	}
+	schedules, err := s.schedules.Schedules(ctx, cadenceRuns)
+	if err != nil {
+		return nil, err
+	}
+	runs := make(map[domain.UserLvl][]time.Time, len(schedules))
+	for _, schedule := range schedules {
+		runs[schedule.Audience] = schedule.NextRuns
+	}
+
+	now := time.Now()
+	res := make([]domain.QueueDepth, 0, len(domain.Audiences))
+	for _, audience := range domain.Audiences {
+		depth := domain.QueueDepth{Audience: audience, Posts: counts[audience]}
+		if perDay := runsPerDay(runs[audience], now); perDay > 0 {
+			days := round(float64(depth.Posts)/perDay, 1)
+			depth.RunsPerDay = round(perDay, 2)
+			depth.DaysLeft = &days
+		}
+		depth.Status = s.thresholds.Status(depth.Posts, depth.DaysLeft)
+		res = append(res, depth)
+	}
+	return res, nil
+}
+
+// Check alerts admins about audiences whose queue status changed since the previous check
+func (s *service) Check(ctx context.Context) error {
+	const op = "queue.Check"
+	logger := s.logger.With(slog.String("op", op))
+
+	depths, err := s.Depths(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, depth := range depths {
+		prev, checked := s.statuses[depth.Audience]
+		if prev == depth.Status || (!checked && depth.Status == domain.QueueStatusOK) {
+			s.statuses[depth.Audience] = depth.Status
+			continue
+		}
+
+		logger.Warn("queue status changed", "audience", depth.Audience, "status", depth.Status, "posts", depth.Posts)
+		msg := notify.Message{Event: eventQueueDepth, Text: alertText(depth), Data: depth}
+		if err := s.notifier.Notify(ctx, msg); err != nil {
+			// status is not saved, so alert is retried on the next check
+			logger.Error("failed to send queue alert", "audience", depth.Audience, "error", err)
+			continue
+		}
+		s.statuses[depth.Audience] = depth.Status
+	}
+	return nil
+}
+
+// Run checks queues every interval until ctx is done
+func (s *service) Run(ctx context.Context, interval time.Duration) {
+	const op = "queue.Run"
+	logger := s.logger.With(slog.String("op", op))
+	logger.Info("starting queue monitor", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runsPerDay averages upcoming runs over time until the last of them
+func runsPerDay(runs []time.Time, now time.Time) float64 {
+	if len(runs) == 0 {
+		return 0
+	}
+	span := runs[len(runs)-1].Sub(now)
+	if span <= 0 {
+		return 0
+	}
+	return float64(len(runs)) / span.Hours() * 24
+}
+
+func round(value float64, digits int) float64 {
+	p := math.Pow10(digits)
+	return math.Round(value*p) / p
+}
+
+func alertText(depth domain.QueueDepth) string {
+	switch depth.Status {
+	case domain.QueueStatusEmpty:
+		return fmt.Sprintf("🚨 Очередь постов аудитории %s пуста, рассылаются повторно вечнозелёные посты, без них рассылки пропускаются. Добавьте или одобрите посты.", depth.Audience)
+	case domain.QueueStatusCritical:
+		return fmt.Sprintf("🚨 Посты аудитории %s почти закончились: осталось %d, хватит на %.1f дн.", depth.Audience, depth.Posts, *depth.DaysLeft)
+	case domain.QueueStatusLow:
+		return fmt.Sprintf("⚠️ Посты аудитории %s заканчиваются: осталось %d, хватит на %.1f дн.", depth.Audience, depth.Posts, *depth.DaysLeft)
+	}
+	if depth.DaysLeft == nil {
+		return fmt.Sprintf("✅ Очередь постов аудитории %s в норме: %d постов, рассылка отключена.", depth.Audience, depth.Posts)
+	}
+	return fmt.Sprintf("✅ Очередь постов аудитории %s пополнена: %d постов, хватит на %.1f дн.", depth.Audience, depth.Posts, *depth.DaysLeft)
+}
@@ -0,0 +1,137 @@
+package queue_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/queue"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/queue/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var thresholds = domain.QueueThresholds{Low: 7, Critical: 2}
+
+// runsEvery returns 50 upcoming runs with period
+func runsEvery(period time.Duration) []time.Time {
+	now := time.Now()
+	runs := make([]time.Time, 0, 50)
+	for i := 1; i <= 50; i++ {
+		runs = append(runs, now.Add(time.Duration(i)*period))
+	}
+	return runs
+}
+
+func schedules() []domain.Schedule {
+	return []domain.Schedule{
+		{Audience: domain.UserLvlDefault, Spec: "@daily"},
+		{Audience: domain.UserLvlBeginner, Spec: "@daily", Enabled: true, NextRuns: runsEvery(24 * time.Hour)},
+		{Audience: domain.UserLvlIntermediate, Spec: "0 0 */12 * * *", Enabled: true, NextRuns: runsEvery(12 * time.Hour)},
+		{Audience: domain.UserLvlAdvanced, Spec: "@daily", Enabled: true, NextRuns: runsEvery(24 * time.Hour)},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestQueueService_Depths(t *testing.T) {
+	type MockBehavior func(posts *mocks.PostRepo, schedules *mocks.ScheduleService)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         []domain.QueueDepth
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(posts *mocks.PostRepo, svc *mocks.ScheduleService) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{
+					domain.UserLvlBeginner:     3,
+					domain.UserLvlIntermediate: 2,
+				}, nil).Once()
+				svc.EXPECT().Schedules(mock.Anything, 50).Return(schedules(), nil).Once()
+			},
+			want: []domain.QueueDepth{
+				{Audience: domain.UserLvlDefault, Status: domain.QueueStatusOK},
+				{Audience: domain.UserLvlBeginner, Posts: 3, RunsPerDay: 1, DaysLeft: ptr(3.0), Status: domain.QueueStatusLow},
+				{Audience: domain.UserLvlIntermediate, Posts: 2, RunsPerDay: 2, DaysLeft: ptr(1.0), Status: domain.QueueStatusCritical},
+				{Audience: domain.UserLvlAdvanced, RunsPerDay: 1, DaysLeft: ptr(0.0), Status: domain.QueueStatusEmpty},
+			},
+		},
+		{
+			name: "repo error",
+			mockBehavior: func(posts *mocks.PostRepo, svc *mocks.ScheduleService) {
+				posts.EXPECT().CountQueued(mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			posts := mocks.NewPostRepo(t)
+			scheduleSvc := mocks.NewScheduleService(t)
+			tc.mockBehavior(posts, scheduleSvc)
+
+			svc := queue.New(testutils.NewTestLogger(), posts, scheduleSvc, mocks.NewNotifier(t), thresholds)
+			got, err := svc.Depths(context.Background())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestQueueService_Check(t *testing.T) {
+	posts := mocks.NewPostRepo(t)
+	scheduleSvc := mocks.NewScheduleService(t)
+	notifier := mocks.NewNotifier(t)
+	scheduleSvc.EXPECT().Schedules(mock.Anything, 50).RunAndReturn(func(ctx context.Context, runs int) ([]domain.Schedule, error) {
+		return schedules(), nil
+	})
+	svc := queue.New(testutils.NewTestLogger(), posts, scheduleSvc, notifier, thresholds)
+	ctx := context.Background()
+
+	alert := func(audience domain.UserLvl, status domain.QueueStatus, text string) any {
+		return mock.MatchedBy(func(msg notify.Message) bool {
+			depth, ok := msg.Data.(domain.QueueDepth)
+			return ok && msg.Event == "queue_depth" && depth.Audience == audience && depth.Status == status && strings.Contains(msg.Text, text)
+		})
+	}
+
+	// the first check alerts only about problems
+	posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{
+		domain.UserLvlBeginner:     30,
+		domain.UserLvlIntermediate: 30,
+	}, nil).Once()
+	notifier.EXPECT().Notify(mock.Anything, alert(domain.UserLvlAdvanced, domain.QueueStatusEmpty, "пуста, рассылаются повторно вечнозелёные посты")).Return(nil).Once()
+	assert.NoError(t, svc.Check(ctx))
+
+	// unchanged statuses are not repeated, failed alert is retried
+	posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{
+		domain.UserLvlBeginner:     3,
+		domain.UserLvlIntermediate: 30,
+	}, nil).Twice()
+	notifier.EXPECT().Notify(mock.Anything, alert(domain.UserLvlBeginner, domain.QueueStatusLow, "хватит на 3.0 дн.")).Return(assert.AnError).Once()
+	assert.NoError(t, svc.Check(ctx))
+	notifier.EXPECT().Notify(mock.Anything, alert(domain.UserLvlBeginner, domain.QueueStatusLow, "хватит на 3.0 дн.")).Return(nil).Once()
+	assert.NoError(t, svc.Check(ctx))
+
+	// refilled queue is reported
+	posts.EXPECT().CountQueued(mock.Anything).Return(map[domain.UserLvl]int{
+		domain.UserLvlBeginner:     30,
+		domain.UserLvlIntermediate: 30,
+	}, nil).Once()
+	notifier.EXPECT().Notify(mock.Anything, alert(domain.UserLvlBeginner, domain.QueueStatusOK, "пополнена")).Return(nil).Once()
+	assert.NoError(t, svc.Check(ctx))
+}
@@ -0,0 +1,596 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	repoquiz "github.com/SergeyBogomolovv/fitflow/internal/repo/quiz"
+
+	time "time"
+)
+
+// QuizRepo is an autogenerated mock type for the QuizRepo type
+type QuizRepo struct {
+	mock.Mock
+}
+
+type QuizRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *QuizRepo) EXPECT() *QuizRepo_Expecter {
+	return &QuizRepo_Expecter{mock: &_m.Mock}
+}
+
+// Activate provides a mock function with given fields: ctx, version
+func (_m *QuizRepo) Activate(ctx context.Context, version int) (domain.Quiz, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Activate")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Quiz, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Quiz); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_Activate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Activate'
+type QuizRepo_Activate_Call struct {
+	*mock.Call
+}
+
+// Activate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizRepo_Expecter) Activate(ctx interface{}, version interface{}) *QuizRepo_Activate_Call {
+	return &QuizRepo_Activate_Call{Call: _e.mock.On("Activate", ctx, version)}
+}
+
+func (_c *QuizRepo_Activate_Call) Run(run func(ctx context.Context, version int)) *QuizRepo_Activate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_Activate_Call) Return(_a0 domain.Quiz, _a1 error) *QuizRepo_Activate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_Activate_Call) RunAndReturn(run func(context.Context, int) (domain.Quiz, error)) *QuizRepo_Activate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Active provides a mock function with given fields: ctx
+func (_m *QuizRepo) Active(ctx context.Context) (domain.Quiz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Active")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (domain.Quiz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Quiz); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_Active_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Active'
+type QuizRepo_Active_Call struct {
+	*mock.Call
+}
+
+// Active is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QuizRepo_Expecter) Active(ctx interface{}) *QuizRepo_Active_Call {
+	return &QuizRepo_Active_Call{Call: _e.mock.On("Active", ctx)}
+}
+
+func (_c *QuizRepo_Active_Call) Run(run func(ctx context.Context)) *QuizRepo_Active_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_Active_Call) Return(_a0 domain.Quiz, _a1 error) *QuizRepo_Active_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_Active_Call) RunAndReturn(run func(context.Context) (domain.Quiz, error)) *QuizRepo_Active_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AnswerCounts provides a mock function with given fields: ctx, version
+func (_m *QuizRepo) AnswerCounts(ctx context.Context, version int) ([]repoquiz.AnswerCount, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnswerCounts")
+	}
+
+	var r0 []repoquiz.AnswerCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]repoquiz.AnswerCount, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []repoquiz.AnswerCount); ok {
+		r0 = rf(ctx, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repoquiz.AnswerCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_AnswerCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnswerCounts'
+type QuizRepo_AnswerCounts_Call struct {
+	*mock.Call
+}
+
+// AnswerCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizRepo_Expecter) AnswerCounts(ctx interface{}, version interface{}) *QuizRepo_AnswerCounts_Call {
+	return &QuizRepo_AnswerCounts_Call{Call: _e.mock.On("AnswerCounts", ctx, version)}
+}
+
+func (_c *QuizRepo_AnswerCounts_Call) Run(run func(ctx context.Context, version int)) *QuizRepo_AnswerCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_AnswerCounts_Call) Return(_a0 []repoquiz.AnswerCount, _a1 error) *QuizRepo_AnswerCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_AnswerCounts_Call) RunAndReturn(run func(context.Context, int) ([]repoquiz.AnswerCount, error)) *QuizRepo_AnswerCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastAttempt provides a mock function with given fields: ctx, userID
+func (_m *QuizRepo) LastAttempt(ctx context.Context, userID int64) (domain.QuizAttempt, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastAttempt")
+	}
+
+	var r0 domain.QuizAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.QuizAttempt, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.QuizAttempt); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.QuizAttempt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_LastAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastAttempt'
+type QuizRepo_LastAttempt_Call struct {
+	*mock.Call
+}
+
+// LastAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *QuizRepo_Expecter) LastAttempt(ctx interface{}, userID interface{}) *QuizRepo_LastAttempt_Call {
+	return &QuizRepo_LastAttempt_Call{Call: _e.mock.On("LastAttempt", ctx, userID)}
+}
+
+func (_c *QuizRepo_LastAttempt_Call) Run(run func(ctx context.Context, userID int64)) *QuizRepo_LastAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_LastAttempt_Call) Return(_a0 domain.QuizAttempt, _a1 error) *QuizRepo_LastAttempt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_LastAttempt_Call) RunAndReturn(run func(context.Context, int64) (domain.QuizAttempt, error)) *QuizRepo_LastAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LevelStats provides a mock function with given fields: ctx, from, version
+func (_m *QuizRepo) LevelStats(ctx context.Context, from time.Time, version int) ([]domain.LevelStats, error) {
+	ret := _m.Called(ctx, from, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LevelStats")
+	}
+
+	var r0 []domain.LevelStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]domain.LevelStats, error)); ok {
+		return rf(ctx, from, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []domain.LevelStats); ok {
+		r0 = rf(ctx, from, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LevelStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, from, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_LevelStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LevelStats'
+type QuizRepo_LevelStats_Call struct {
+	*mock.Call
+}
+
+// LevelStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - version int
+func (_e *QuizRepo_Expecter) LevelStats(ctx interface{}, from interface{}, version interface{}) *QuizRepo_LevelStats_Call {
+	return &QuizRepo_LevelStats_Call{Call: _e.mock.On("LevelStats", ctx, from, version)}
+}
+
+func (_c *QuizRepo_LevelStats_Call) Run(run func(ctx context.Context, from time.Time, version int)) *QuizRepo_LevelStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_LevelStats_Call) Return(_a0 []domain.LevelStats, _a1 error) *QuizRepo_LevelStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_LevelStats_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]domain.LevelStats, error)) *QuizRepo_LevelStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *QuizRepo) List(ctx context.Context) ([]domain.Quiz, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Quiz, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Quiz); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Quiz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type QuizRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *QuizRepo_Expecter) List(ctx interface{}) *QuizRepo_List_Call {
+	return &QuizRepo_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *QuizRepo_List_Call) Run(run func(ctx context.Context)) *QuizRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_List_Call) Return(_a0 []domain.Quiz, _a1 error) *QuizRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_List_Call) RunAndReturn(run func(context.Context) ([]domain.Quiz, error)) *QuizRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QuizByVersion provides a mock function with given fields: ctx, version
+func (_m *QuizRepo) QuizByVersion(ctx context.Context, version int) (domain.Quiz, error) {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuizByVersion")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Quiz, error)); ok {
+		return rf(ctx, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Quiz); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_QuizByVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuizByVersion'
+type QuizRepo_QuizByVersion_Call struct {
+	*mock.Call
+}
+
+// QuizByVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizRepo_Expecter) QuizByVersion(ctx interface{}, version interface{}) *QuizRepo_QuizByVersion_Call {
+	return &QuizRepo_QuizByVersion_Call{Call: _e.mock.On("QuizByVersion", ctx, version)}
+}
+
+func (_c *QuizRepo_QuizByVersion_Call) Run(run func(ctx context.Context, version int)) *QuizRepo_QuizByVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_QuizByVersion_Call) Return(_a0 domain.Quiz, _a1 error) *QuizRepo_QuizByVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_QuizByVersion_Call) RunAndReturn(run func(context.Context, int) (domain.Quiz, error)) *QuizRepo_QuizByVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, version
+func (_m *QuizRepo) Remove(ctx context.Context, version int) error {
+	ret := _m.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QuizRepo_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type QuizRepo_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int
+func (_e *QuizRepo_Expecter) Remove(ctx interface{}, version interface{}) *QuizRepo_Remove_Call {
+	return &QuizRepo_Remove_Call{Call: _e.mock.On("Remove", ctx, version)}
+}
+
+func (_c *QuizRepo_Remove_Call) Run(run func(ctx context.Context, version int)) *QuizRepo_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_Remove_Call) Return(_a0 error) *QuizRepo_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QuizRepo_Remove_Call) RunAndReturn(run func(context.Context, int) error) *QuizRepo_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *QuizRepo) Save(ctx context.Context, in repoquiz.SaveQuizInput) (domain.Quiz, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.Quiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repoquiz.SaveQuizInput) (domain.Quiz, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repoquiz.SaveQuizInput) domain.Quiz); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Quiz)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repoquiz.SaveQuizInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QuizRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type QuizRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repoquiz.SaveQuizInput
+func (_e *QuizRepo_Expecter) Save(ctx interface{}, in interface{}) *QuizRepo_Save_Call {
+	return &QuizRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *QuizRepo_Save_Call) Run(run func(ctx context.Context, in repoquiz.SaveQuizInput)) *QuizRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repoquiz.SaveQuizInput))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_Save_Call) Return(_a0 domain.Quiz, _a1 error) *QuizRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *QuizRepo_Save_Call) RunAndReturn(run func(context.Context, repoquiz.SaveQuizInput) (domain.Quiz, error)) *QuizRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveResult provides a mock function with given fields: ctx, in
+func (_m *QuizRepo) SaveResult(ctx context.Context, in repoquiz.SaveResultInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, repoquiz.SaveResultInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// QuizRepo_SaveResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveResult'
+type QuizRepo_SaveResult_Call struct {
+	*mock.Call
+}
+
+// SaveResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repoquiz.SaveResultInput
+func (_e *QuizRepo_Expecter) SaveResult(ctx interface{}, in interface{}) *QuizRepo_SaveResult_Call {
+	return &QuizRepo_SaveResult_Call{Call: _e.mock.On("SaveResult", ctx, in)}
+}
+
+func (_c *QuizRepo_SaveResult_Call) Run(run func(ctx context.Context, in repoquiz.SaveResultInput)) *QuizRepo_SaveResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repoquiz.SaveResultInput))
+	})
+	return _c
+}
+
+func (_c *QuizRepo_SaveResult_Call) Return(_a0 error) *QuizRepo_SaveResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *QuizRepo_SaveResult_Call) RunAndReturn(run func(context.Context, repoquiz.SaveResultInput) error) *QuizRepo_SaveResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewQuizRepo creates a new instance of QuizRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQuizRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuizRepo {
+	mock := &QuizRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,262 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	quizRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/quiz"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+)
+
+type QuizRepo interface {
+	Save(ctx context.Context, in quizRepo.SaveQuizInput) (domain.Quiz, error)
+	List(ctx context.Context) ([]domain.Quiz, error)
+	QuizByVersion(ctx context.Context, version int) (domain.Quiz, error)
+	Active(ctx context.Context) (domain.Quiz, error)
+	Activate(ctx context.Context, version int) (domain.Quiz, error)
+	Remove(ctx context.Context, version int) error
+	SaveResult(ctx context.Context, in quizRepo.SaveResultInput) error
+	LastAttempt(ctx context.Context, userID int64) (domain.QuizAttempt, error)
+	AnswerCounts(ctx context.Context, version int) ([]quizRepo.AnswerCount, error)
+	LevelStats(ctx context.Context, from time.Time, version int) ([]domain.LevelStats, error)
+}
+
+type quizService struct {
+	logger   *slog.Logger
+	quizRepo QuizRepo
+	// cooldown is minimal time between attempts of one user, zero means no cooldown
+	cooldown time.Duration
+}
+
+func New(logger *slog.Logger, quizRepo QuizRepo, cooldown time.Duration) *quizService {
+	return &quizService{logger, quizRepo, cooldown}
+}
+
+// CreateQuiz saves level test as the next version, existing versions are never changed
+func (s *quizService) CreateQuiz(ctx context.Context, in domain.CreateQuizDTO) (domain.Quiz, error) {
+	const op = "quiz.CreateQuiz"
+	logger := s.logger.With(slog.String("op", op))
+
+	if err := (domain.Quiz{Questions: in.Questions, Thresholds: in.Thresholds}).Validate(); err != nil {
+		return domain.Quiz{}, err
+	}
+
+	quiz, err := s.quizRepo.Save(ctx, quizRepo.SaveQuizInput{
+		Questions:  in.Questions,
+		Thresholds: in.Thresholds,
+		Author:     auth.AdminLogin(ctx),
+	})
+	if err != nil {
+		logger.Error("failed to save quiz", "error", err)
+		return domain.Quiz{}, err
+	}
+	logger.Info("quiz created", "version", quiz.Version)
+
+	if !in.Activate {
+		return quiz, nil
+	}
+	return s.ActivateQuiz(ctx, quiz.Version)
+}
+
+func (s *quizService) Quizzes(ctx context.Context) ([]domain.Quiz, error) {
+	const op = "quiz.Quizzes"
+	logger := s.logger.With(slog.String("op", op))
+
+	quizzes, err := s.quizRepo.List(ctx)
+	if err != nil {
+		logger.Error("failed to get quizzes", "error", err)
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+func (s *quizService) Quiz(ctx context.Context, version int) (domain.Quiz, error) {
+	const op = "quiz.Quiz"
+	logger := s.logger.With(slog.String("op", op), slog.Int("version", version))
+
+	quiz, err := s.quizRepo.QuizByVersion(ctx, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			return domain.Quiz{}, err
+		}
+		logger.Error("failed to get quiz", "error", err)
+		return domain.Quiz{}, err
+	}
+	return quiz, nil
+}
+
+// ActiveQuiz returns version of level test which is given to users
+func (s *quizService) ActiveQuiz(ctx context.Context) (domain.Quiz, error) {
+	const op = "quiz.ActiveQuiz"
+	logger := s.logger.With(slog.String("op", op))
+
+	quiz, err := s.quizRepo.Active(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			return domain.Quiz{}, err
+		}
+		logger.Error("failed to get active quiz", "error", err)
+		return domain.Quiz{}, err
+	}
+	return quiz, nil
+}
+
+func (s *quizService) ActivateQuiz(ctx context.Context, version int) (domain.Quiz, error) {
+	const op = "quiz.ActivateQuiz"
+	logger := s.logger.With(slog.String("op", op), slog.Int("version", version))
+
+	quiz, err := s.quizRepo.Activate(ctx, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) {
+			return domain.Quiz{}, err
+		}
+		logger.Error("failed to activate quiz", "error", err)
+		return domain.Quiz{}, err
+	}
+
+	logger.Info("quiz activated")
+	return quiz, nil
+}
+
+// RemoveQuiz deletes version which is not active and was never taken
+func (s *quizService) RemoveQuiz(ctx context.Context, version int) error {
+	const op = "quiz.RemoveQuiz"
+	logger := s.logger.With(slog.String("op", op), slog.Int("version", version))
+
+	quiz, err := s.Quiz(ctx, version)
+	if err != nil {
+		return err
+	}
+	if quiz.Active {
+		return domain.ErrQuizActive
+	}
+
+	if err := s.quizRepo.Remove(ctx, version); err != nil {
+		if errors.Is(err, domain.ErrQuizNotFound) || errors.Is(err, domain.ErrQuizInUse) {
+			return err
+		}
+		logger.Error("failed to remove quiz", "error", err)
+		return err
+	}
+
+	logger.Info("quiz removed")
+	return nil
+}
+
+// CheckCooldown returns *domain.QuizCooldownError when user took the test too recently
+func (s *quizService) CheckCooldown(ctx context.Context, userID int64) error {
+	const op = "quiz.CheckCooldown"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	if s.cooldown <= 0 {
+		return nil
+	}
+	attempt, err := s.quizRepo.LastAttempt(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrQuizAttemptNotFound) {
+			return nil
+		}
+		logger.Error("failed to get last quiz attempt", "error", err)
+		return err
+	}
+	if retryAt := attempt.CreatedAt.Add(s.cooldown); time.Now().Before(retryAt) {
+		return &domain.QuizCooldownError{RetryAt: retryAt}
+	}
+	return nil
+}
+
+// CompleteQuiz scores answers with the version user was given, saves attempt and level
+func (s *quizService) CompleteQuiz(ctx context.Context, userID int64, version int, answers []int) (domain.QuizResult, error) {
+	const op = "quiz.CompleteQuiz"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID), slog.Int("version", version))
+
+	if err := s.CheckCooldown(ctx, userID); err != nil {
+		return domain.QuizResult{}, err
+	}
+	quiz, err := s.Quiz(ctx, version)
+	if err != nil {
+		return domain.QuizResult{}, err
+	}
+	score, err := quiz.Score(answers)
+	if err != nil {
+		return domain.QuizResult{}, err
+	}
+
+	result := domain.QuizResult{Version: version, Score: score, Level: quiz.Level(score)}
+	if err := s.quizRepo.SaveResult(ctx, quizRepo.SaveResultInput{
+		UserID:  userID,
+		Version: version,
+		Answers: answers,
+		Score:   score,
+		Level:   result.Level,
+	}); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.QuizResult{}, err
+		}
+		logger.Error("failed to save quiz result", "error", err)
+		return domain.QuizResult{}, err
+	}
+
+	logger.Info("quiz completed", "score", score, "level", result.Level)
+	return result, nil
+}
+
+// QuizStats returns how often every option of quiz version was chosen
+func (s *quizService) QuizStats(ctx context.Context, version int) (domain.QuizStats, error) {
+	const op = "quiz.QuizStats"
+	logger := s.logger.With(slog.String("op", op), slog.Int("version", version))
+
+	quiz, err := s.Quiz(ctx, version)
+	if err != nil {
+		return domain.QuizStats{}, err
+	}
+	counts, err := s.quizRepo.AnswerCounts(ctx, version)
+	if err != nil {
+		logger.Error("failed to count answers", "error", err)
+		return domain.QuizStats{}, err
+	}
+
+	stats := domain.QuizStats{Version: version, Questions: make([]domain.QuizQuestionStats, 0, len(quiz.Questions))}
+	for _, question := range quiz.Questions {
+		options := make([]domain.QuizOptionStats, 0, len(question.Options))
+		for _, option := range question.Options {
+			options = append(options, domain.QuizOptionStats{Text: option.Text, Weight: option.Weight})
+		}
+		stats.Questions = append(stats.Questions, domain.QuizQuestionStats{Text: question.Text, Options: options})
+	}
+	for _, c := range counts {
+		if c.Question >= len(stats.Questions) || c.Option >= len(stats.Questions[c.Question].Options) {
+			continue
+		}
+		stats.Questions[c.Question].Options[c.Option].Count = c.Count
+		if c.Question == 0 {
+			stats.Attempts += c.Count
+		}
+	}
+	if stats.Attempts > 0 {
+		for i := range stats.Questions {
+			for j := range stats.Questions[i].Options {
+				option := &stats.Questions[i].Options[j]
+				option.Share = float64(option.Count) / float64(stats.Attempts)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// LevelStats returns resulting levels of attempts by day for last days, version 0 means all versions
+func (s *quizService) LevelStats(ctx context.Context, days, version int) ([]domain.LevelStats, error) {
+	const op = "quiz.LevelStats"
+	logger := s.logger.With(slog.String("op", op), slog.Int("version", version))
+
+	from := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -days+1)
+	stats, err := s.quizRepo.LevelStats(ctx, from, version)
+	if err != nil {
+		logger.Error("failed to get level stats", "error", err)
+		return nil, err
+	}
+	return stats, nil
+}
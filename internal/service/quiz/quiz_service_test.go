@@ -0,0 +1,316 @@
+package quiz_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	quizRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/quiz"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/quiz"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/quiz/mocks"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testQuestions = []domain.QuizQuestion{
+		{Text: "q1", Options: []domain.QuizOption{{Text: "a", Weight: 1}, {Text: "b", Weight: 2}, {Text: "c", Weight: 3}}},
+		{Text: "q2", Options: []domain.QuizOption{{Text: "a", Weight: 1}, {Text: "b", Weight: 2}, {Text: "c", Weight: 3}}},
+	}
+	testThresholds = []domain.QuizThreshold{
+		{Level: domain.UserLvlBeginner, MinScore: 0},
+		{Level: domain.UserLvlIntermediate, MinScore: 3},
+		{Level: domain.UserLvlAdvanced, MinScore: 5},
+	}
+	testQuiz = domain.Quiz{Version: 2, Questions: testQuestions, Thresholds: testThresholds}
+)
+
+func TestQuizService_CreateQuiz(t *testing.T) {
+	type MockBehavior func(repo *mocks.QuizRepo)
+
+	testCases := []struct {
+		name         string
+		in           domain.CreateQuizDTO
+		mockBehavior MockBehavior
+		want         domain.Quiz
+		wantErr      error
+	}{
+		{
+			name: "success",
+			in:   domain.CreateQuizDTO{Questions: testQuestions, Thresholds: testThresholds},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().Save(mock.Anything, quizRepo.SaveQuizInput{Questions: testQuestions, Thresholds: testThresholds}).
+					Return(testQuiz, nil).Once()
+			},
+			want: testQuiz,
+		},
+		{
+			name: "activate",
+			in:   domain.CreateQuizDTO{Questions: testQuestions, Thresholds: testThresholds, Activate: true},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(testQuiz, nil).Once()
+				repo.EXPECT().Activate(mock.Anything, 2).Return(domain.Quiz{Version: 2, Active: true}, nil).Once()
+			},
+			want: domain.Quiz{Version: 2, Active: true},
+		},
+		{
+			name: "duplicate threshold",
+			in: domain.CreateQuizDTO{Questions: testQuestions, Thresholds: []domain.QuizThreshold{
+				{Level: domain.UserLvlBeginner, MinScore: 0},
+				{Level: domain.UserLvlBeginner, MinScore: 3},
+			}},
+			mockBehavior: func(repo *mocks.QuizRepo) {},
+			wantErr:      domain.ErrInvalidQuiz,
+		},
+		{
+			name: "duplicate option",
+			in: domain.CreateQuizDTO{Questions: []domain.QuizQuestion{
+				{Text: "q1", Options: []domain.QuizOption{{Text: "a", Weight: 1}, {Text: "a", Weight: 2}}},
+			}, Thresholds: []domain.QuizThreshold{{Level: domain.UserLvlBeginner, MinScore: 0}}},
+			mockBehavior: func(repo *mocks.QuizRepo) {},
+			wantErr:      domain.ErrInvalidQuiz,
+		},
+		{
+			name: "unreachable threshold",
+			in: domain.CreateQuizDTO{Questions: testQuestions, Thresholds: []domain.QuizThreshold{
+				{Level: domain.UserLvlAdvanced, MinScore: 7},
+			}},
+			mockBehavior: func(repo *mocks.QuizRepo) {},
+			wantErr:      domain.ErrInvalidQuiz,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewQuizRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := quiz.New(testutils.NewTestLogger(), repo, time.Hour)
+			got, err := svc.CreateQuiz(context.Background(), tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestQuizService_RemoveQuiz(t *testing.T) {
+	type MockBehavior func(repo *mocks.QuizRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().Remove(mock.Anything, 2).Return(nil).Once()
+			},
+		},
+		{
+			name: "active",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(domain.Quiz{Version: 2, Active: true}, nil).Once()
+			},
+			wantErr: domain.ErrQuizActive,
+		},
+		{
+			name: "in use",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().Remove(mock.Anything, 2).Return(domain.ErrQuizInUse).Once()
+			},
+			wantErr: domain.ErrQuizInUse,
+		},
+		{
+			name: "not found",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(domain.Quiz{}, domain.ErrQuizNotFound).Once()
+			},
+			wantErr: domain.ErrQuizNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewQuizRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := quiz.New(testutils.NewTestLogger(), repo, time.Hour)
+			err := svc.RemoveQuiz(context.Background(), 2)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestQuizService_CompleteQuiz(t *testing.T) {
+	type MockBehavior func(repo *mocks.QuizRepo)
+
+	testCases := []struct {
+		name         string
+		answers      []int
+		mockBehavior MockBehavior
+		want         domain.QuizResult
+		wantErr      error
+	}{
+		{
+			name:    "intermediate",
+			answers: []int{0, 2},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).Return(domain.QuizAttempt{}, domain.ErrQuizAttemptNotFound).Once()
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().SaveResult(mock.Anything, quizRepo.SaveResultInput{
+					UserID:  1,
+					Version: 2,
+					Answers: []int{0, 2},
+					Score:   4,
+					Level:   domain.UserLvlIntermediate,
+				}).Return(nil).Once()
+			},
+			want: domain.QuizResult{Version: 2, Score: 4, Level: domain.UserLvlIntermediate},
+		},
+		{
+			name:    "advanced",
+			answers: []int{2, 2},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).
+					Return(domain.QuizAttempt{CreatedAt: time.Now().Add(-2 * time.Hour)}, nil).Once()
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().SaveResult(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: domain.QuizResult{Version: 2, Score: 6, Level: domain.UserLvlAdvanced},
+		},
+		{
+			name:    "cooldown",
+			answers: []int{2, 2},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).
+					Return(domain.QuizAttempt{CreatedAt: time.Now().Add(-10 * time.Minute)}, nil).Once()
+			},
+			wantErr: domain.ErrQuizCooldown,
+		},
+		{
+			name:    "missing answer",
+			answers: []int{1},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).Return(domain.QuizAttempt{}, domain.ErrQuizAttemptNotFound).Once()
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+			},
+			wantErr: domain.ErrInvalidQuizAnswers,
+		},
+		{
+			name:    "unknown option",
+			answers: []int{1, 3},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).Return(domain.QuizAttempt{}, domain.ErrQuizAttemptNotFound).Once()
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+			},
+			wantErr: domain.ErrInvalidQuizAnswers,
+		},
+		{
+			name:    "user not found",
+			answers: []int{0, 0},
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().LastAttempt(mock.Anything, int64(1)).Return(domain.QuizAttempt{}, domain.ErrQuizAttemptNotFound).Once()
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().SaveResult(mock.Anything, mock.Anything).Return(domain.ErrUserNotFound).Once()
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewQuizRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := quiz.New(testutils.NewTestLogger(), repo, time.Hour)
+			got, err := svc.CompleteQuiz(context.Background(), 1, 2, tc.answers)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestQuizService_QuizStats(t *testing.T) {
+	type MockBehavior func(repo *mocks.QuizRepo)
+
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		want         domain.QuizStats
+		wantErr      error
+	}{
+		{
+			name: "success",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(testQuiz, nil).Once()
+				repo.EXPECT().AnswerCounts(mock.Anything, 2).Return([]quizRepo.AnswerCount{
+					{Question: 0, Option: 0, Count: 3},
+					{Question: 0, Option: 2, Count: 1},
+					{Question: 1, Option: 1, Count: 4},
+				}, nil).Once()
+			},
+			want: domain.QuizStats{Version: 2, Attempts: 4, Questions: []domain.QuizQuestionStats{
+				{Text: "q1", Options: []domain.QuizOptionStats{
+					{Text: "a", Weight: 1, Count: 3, Share: 0.75},
+					{Text: "b", Weight: 2},
+					{Text: "c", Weight: 3, Count: 1, Share: 0.25},
+				}},
+				{Text: "q2", Options: []domain.QuizOptionStats{
+					{Text: "a", Weight: 1},
+					{Text: "b", Weight: 2, Count: 4, Share: 1},
+					{Text: "c", Weight: 3},
+				}},
+			}},
+		},
+		{
+			name: "no attempts",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(domain.Quiz{Version: 2, Questions: testQuestions[:1]}, nil).Once()
+				repo.EXPECT().AnswerCounts(mock.Anything, 2).Return(nil, nil).Once()
+			},
+			want: domain.QuizStats{Version: 2, Questions: []domain.QuizQuestionStats{
+				{Text: "q1", Options: []domain.QuizOptionStats{{Text: "a", Weight: 1}, {Text: "b", Weight: 2}, {Text: "c", Weight: 3}}},
+			}},
+		},
+		{
+			name: "not found",
+			mockBehavior: func(repo *mocks.QuizRepo) {
+				repo.EXPECT().QuizByVersion(mock.Anything, 2).Return(domain.Quiz{}, domain.ErrQuizNotFound).Once()
+			},
+			wantErr: domain.ErrQuizNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewQuizRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := quiz.New(testutils.NewTestLogger(), repo, time.Hour)
+			got, err := svc.QuizStats(context.Background(), 2)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
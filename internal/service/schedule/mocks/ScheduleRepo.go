@@ -0,0 +1,258 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	reposchedule "github.com/SergeyBogomolovv/fitflow/internal/repo/schedule"
+)
+
+// ScheduleRepo is an autogenerated mock type for the ScheduleRepo type
+type ScheduleRepo struct {
+	mock.Mock
+}
+
+type ScheduleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScheduleRepo) EXPECT() *ScheduleRepo_Expecter {
+	return &ScheduleRepo_Expecter{mock: &_m.Mock}
+}
+
+// ByAudience provides a mock function with given fields: ctx, audience
+func (_m *ScheduleRepo) ByAudience(ctx context.Context, audience domain.UserLvl) (domain.Schedule, error) {
+	ret := _m.Called(ctx, audience)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ByAudience")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl) (domain.Schedule, error)); ok {
+		return rf(ctx, audience)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserLvl) domain.Schedule); ok {
+		r0 = rf(ctx, audience)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserLvl) error); ok {
+		r1 = rf(ctx, audience)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRepo_ByAudience_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ByAudience'
+type ScheduleRepo_ByAudience_Call struct {
+	*mock.Call
+}
+
+// ByAudience is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audience domain.UserLvl
+func (_e *ScheduleRepo_Expecter) ByAudience(ctx interface{}, audience interface{}) *ScheduleRepo_ByAudience_Call {
+	return &ScheduleRepo_ByAudience_Call{Call: _e.mock.On("ByAudience", ctx, audience)}
+}
+
+func (_c *ScheduleRepo_ByAudience_Call) Run(run func(ctx context.Context, audience domain.UserLvl)) *ScheduleRepo_ByAudience_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.UserLvl))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_ByAudience_Call) Return(_a0 domain.Schedule, _a1 error) *ScheduleRepo_ByAudience_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleRepo_ByAudience_Call) RunAndReturn(run func(context.Context, domain.UserLvl) (domain.Schedule, error)) *ScheduleRepo_ByAudience_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx
+func (_m *ScheduleRepo) List(ctx context.Context) ([]domain.Schedule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Schedule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Schedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Schedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ScheduleRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ScheduleRepo_Expecter) List(ctx interface{}) *ScheduleRepo_List_Call {
+	return &ScheduleRepo_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *ScheduleRepo_List_Call) Run(run func(ctx context.Context)) *ScheduleRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_List_Call) Return(_a0 []domain.Schedule, _a1 error) *ScheduleRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleRepo_List_Call) RunAndReturn(run func(context.Context) ([]domain.Schedule, error)) *ScheduleRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *ScheduleRepo) Save(ctx context.Context, in reposchedule.SaveScheduleInput) (domain.Schedule, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.Schedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, reposchedule.SaveScheduleInput) (domain.Schedule, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, reposchedule.SaveScheduleInput) domain.Schedule); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.Schedule)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, reposchedule.SaveScheduleInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type ScheduleRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in reposchedule.SaveScheduleInput
+func (_e *ScheduleRepo_Expecter) Save(ctx interface{}, in interface{}) *ScheduleRepo_Save_Call {
+	return &ScheduleRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *ScheduleRepo_Save_Call) Run(run func(ctx context.Context, in reposchedule.SaveScheduleInput)) *ScheduleRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(reposchedule.SaveScheduleInput))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_Save_Call) Return(_a0 domain.Schedule, _a1 error) *ScheduleRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScheduleRepo_Save_Call) RunAndReturn(run func(context.Context, reposchedule.SaveScheduleInput) (domain.Schedule, error)) *ScheduleRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveMissing provides a mock function with given fields: ctx, specs
+func (_m *ScheduleRepo) SaveMissing(ctx context.Context, specs map[domain.UserLvl]string) error {
+	ret := _m.Called(ctx, specs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMissing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[domain.UserLvl]string) error); ok {
+		r0 = rf(ctx, specs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleRepo_SaveMissing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMissing'
+type ScheduleRepo_SaveMissing_Call struct {
+	*mock.Call
+}
+
+// SaveMissing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - specs map[domain.UserLvl]string
+func (_e *ScheduleRepo_Expecter) SaveMissing(ctx interface{}, specs interface{}) *ScheduleRepo_SaveMissing_Call {
+	return &ScheduleRepo_SaveMissing_Call{Call: _e.mock.On("SaveMissing", ctx, specs)}
+}
+
+func (_c *ScheduleRepo_SaveMissing_Call) Run(run func(ctx context.Context, specs map[domain.UserLvl]string)) *ScheduleRepo_SaveMissing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(map[domain.UserLvl]string))
+	})
+	return _c
+}
+
+func (_c *ScheduleRepo_SaveMissing_Call) Return(_a0 error) *ScheduleRepo_SaveMissing_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScheduleRepo_SaveMissing_Call) RunAndReturn(run func(context.Context, map[domain.UserLvl]string) error) *ScheduleRepo_SaveMissing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScheduleRepo creates a new instance of ScheduleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScheduleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScheduleRepo {
+	mock := &ScheduleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
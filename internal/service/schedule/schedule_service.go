@@ -0,0 +1,132 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	scheduleRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/schedule"
+	"github.com/SergeyBogomolovv/fitflow/pkg/auth"
+	"github.com/robfig/cron/v3"
+)
+
+type ScheduleRepo interface {
+	List(ctx context.Context) ([]domain.Schedule, error)
+	ByAudience(ctx context.Context, audience domain.UserLvl) (domain.Schedule, error)
+	Save(ctx context.Context, in scheduleRepo.SaveScheduleInput) (domain.Schedule, error)
+	SaveMissing(ctx context.Context, specs map[domain.UserLvl]string) error
+}
+
+// parser accepts the same specs as bot scheduler: with seconds field and descriptors like @daily
+var parser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+type service struct {
+	logger       *slog.Logger
+	scheduleRepo ScheduleRepo
+}
+
+func New(logger *slog.Logger, scheduleRepo ScheduleRepo) *service {
+	return &service{logger, scheduleRepo}
+}
+
+// Schedules returns schedules of all audiences with runs upcoming run times
+func (s *service) Schedules(ctx context.Context, runs int) ([]domain.Schedule, error) {
+	const op = "schedule.Schedules"
+	logger := s.logger.With(slog.String("op", op))
+
+	schedules, err := s.scheduleRepo.List(ctx)
+	if err != nil {
+		logger.Error("failed to get schedules", "error", err)
+		return nil, err
+	}
+	for i := range schedules {
+		schedules[i].NextRuns = nextRuns(schedules[i], time.Now(), runs)
+	}
+	return schedules, nil
+}
+
+func (s *service) Schedule(ctx context.Context, audience domain.UserLvl, runs int) (domain.Schedule, error) {
+	const op = "schedule.Schedule"
+	logger := s.logger.With(slog.String("op", op), slog.String("audience", string(audience)))
+
+	schedule, err := s.scheduleRepo.ByAudience(ctx, audience)
+	if err != nil {
+		if !errors.Is(err, domain.ErrScheduleNotFound) {
+			logger.Error("failed to get schedule", "error", err)
+		}
+		return domain.Schedule{}, err
+	}
+	schedule.NextRuns = nextRuns(schedule, time.Now(), runs)
+	return schedule, nil
+}
+
+// UpdateSchedule validates cron spec and saves it, bot picks it up on the next reload
+func (s *service) UpdateSchedule(ctx context.Context, audience domain.UserLvl, in domain.UpdateScheduleDTO, runs int) (domain.Schedule, error) {
+	const op = "schedule.UpdateSchedule"
+	logger := s.logger.With(slog.String("op", op), slog.String("audience", string(audience)))
+
+	if !audience.Valid() {
+		return domain.Schedule{}, fmt.Errorf("%w: unknown audience %s", domain.ErrInvalidSchedule, audience)
+	}
+	if err := Validate(in.Spec); err != nil {
+		return domain.Schedule{}, err
+	}
+
+	schedule, err := s.scheduleRepo.Save(ctx, scheduleRepo.SaveScheduleInput{
+		Audience:  audience,
+		Spec:      in.Spec,
+		Enabled:   in.Enabled,
+		UpdatedBy: auth.AdminLogin(ctx),
+	})
+	if err != nil {
+		logger.Error("failed to save schedule", "error", err)
+		return domain.Schedule{}, err
+	}
+
+	logger.Info("schedule updated", "spec", schedule.Spec, "enabled", schedule.Enabled)
+	schedule.NextRuns = nextRuns(schedule, time.Now(), runs)
+	return schedule, nil
+}
+
+// EnsureSchedules saves specs for audiences without schedule, so initial schedules can come from config
+func (s *service) EnsureSchedules(ctx context.Context, specs map[domain.UserLvl]string) error {
+	const op = "schedule.EnsureSchedules"
+	logger := s.logger.With(slog.String("op", op))
+
+	for audience, spec := range specs {
+		if err := Validate(spec); err != nil {
+			return fmt.Errorf("%s schedule: %w", audience, err)
+		}
+	}
+	if err := s.scheduleRepo.SaveMissing(ctx, specs); err != nil {
+		logger.Error("failed to save default schedules", "error", err)
+		return err
+	}
+	return nil
+}
+
+// Validate checks cron spec, error wraps domain.ErrInvalidSchedule with parser message
+func Validate(spec string) error {
+	if _, err := parser.Parse(spec); err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidSchedule, err)
+	}
+	return nil
+}
+
+func nextRuns(schedule domain.Schedule, from time.Time, n int) []time.Time {
+	if !schedule.Enabled || n <= 0 {
+		return nil
+	}
+	sched, err := parser.Parse(schedule.Spec)
+	if err != nil {
+		return nil
+	}
+	runs := make([]time.Time, 0, n)
+	for next := sched.Next(from); len(runs) < n && !next.IsZero(); next = sched.Next(next) {
+		runs = append(runs, next)
+	}
+	return runs
+}
@@ -0,0 +1,101 @@
+package schedule_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	scheduleRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/schedule"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/schedule"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/schedule/mocks"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduleService_UpdateSchedule(t *testing.T) {
+	type MockBehavior func(repo *mocks.ScheduleRepo)
+
+	testCases := []struct {
+		name         string
+		audience     domain.UserLvl
+		in           domain.UpdateScheduleDTO
+		mockBehavior MockBehavior
+		wantRuns     int
+		wantErr      error
+	}{
+		{
+			name:     "success",
+			audience: domain.UserLvlBeginner,
+			in:       domain.UpdateScheduleDTO{Spec: "0 0 10 * * *", Enabled: true},
+			mockBehavior: func(repo *mocks.ScheduleRepo) {
+				repo.EXPECT().Save(mock.Anything, scheduleRepo.SaveScheduleInput{
+					Audience: domain.UserLvlBeginner,
+					Spec:     "0 0 10 * * *",
+					Enabled:  true,
+				}).Return(domain.Schedule{Audience: domain.UserLvlBeginner, Spec: "0 0 10 * * *", Enabled: true}, nil).Once()
+			},
+			wantRuns: 3,
+		},
+		{
+			name:     "disabled has no runs",
+			audience: domain.UserLvlDefault,
+			in:       domain.UpdateScheduleDTO{Spec: "@daily"},
+			mockBehavior: func(repo *mocks.ScheduleRepo) {
+				repo.EXPECT().Save(mock.Anything, mock.Anything).Return(domain.Schedule{Audience: domain.UserLvlDefault, Spec: "@daily"}, nil).Once()
+			},
+		},
+		{
+			name:         "spec without seconds",
+			audience:     domain.UserLvlBeginner,
+			in:           domain.UpdateScheduleDTO{Spec: "0 10 * * *", Enabled: true},
+			mockBehavior: func(repo *mocks.ScheduleRepo) {},
+			wantErr:      domain.ErrInvalidSchedule,
+		},
+		{
+			name:         "unknown audience",
+			audience:     "expert",
+			in:           domain.UpdateScheduleDTO{Spec: "0 0 10 * * *", Enabled: true},
+			mockBehavior: func(repo *mocks.ScheduleRepo) {},
+			wantErr:      domain.ErrInvalidSchedule,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewScheduleRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := schedule.New(testutils.NewTestLogger(), repo)
+			got, err := svc.UpdateSchedule(context.Background(), tc.audience, tc.in, 3)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.NextRuns, tc.wantRuns)
+			for _, run := range got.NextRuns {
+				assert.Equal(t, 10, run.Hour())
+			}
+		})
+	}
+}
+
+func TestScheduleService_EnsureSchedules(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		specs := map[domain.UserLvl]string{domain.UserLvlDefault: "0 0 10 * * *", domain.UserLvlBeginner: "0 0 18 * * *"}
+		repo := mocks.NewScheduleRepo(t)
+		repo.EXPECT().SaveMissing(mock.Anything, specs).Return(nil).Once()
+
+		svc := schedule.New(testutils.NewTestLogger(), repo)
+		assert.NoError(t, svc.EnsureSchedules(context.Background(), specs))
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		repo := mocks.NewScheduleRepo(t)
+
+		svc := schedule.New(testutils.NewTestLogger(), repo)
+		err := svc.EnsureSchedules(context.Background(), map[domain.UserLvl]string{domain.UserLvlDefault: "every day"})
+		assert.ErrorIs(t, err, domain.ErrInvalidSchedule)
+	})
+}
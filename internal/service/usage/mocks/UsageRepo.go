@@ -0,0 +1,265 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// UsageRepo is an autogenerated mock type for the UsageRepo type
+type UsageRepo struct {
+	mock.Mock
+}
+
+type UsageRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UsageRepo) EXPECT() *UsageRepo_Expecter {
+	return &UsageRepo_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, admin, day, tokens
+func (_m *UsageRepo) Add(ctx context.Context, admin string, day time.Time, tokens domain.TokenUsage) error {
+	ret := _m.Called(ctx, admin, day, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, domain.TokenUsage) error); ok {
+		r0 = rf(ctx, admin, day, tokens)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsageRepo_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type UsageRepo_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - day time.Time
+//   - tokens domain.TokenUsage
+func (_e *UsageRepo_Expecter) Add(ctx interface{}, admin interface{}, day interface{}, tokens interface{}) *UsageRepo_Add_Call {
+	return &UsageRepo_Add_Call{Call: _e.mock.On("Add", ctx, admin, day, tokens)}
+}
+
+func (_c *UsageRepo_Add_Call) Run(run func(ctx context.Context, admin string, day time.Time, tokens domain.TokenUsage)) *UsageRepo_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(domain.TokenUsage))
+	})
+	return _c
+}
+
+func (_c *UsageRepo_Add_Call) Return(_a0 error) *UsageRepo_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UsageRepo_Add_Call) RunAndReturn(run func(context.Context, string, time.Time, domain.TokenUsage) error) *UsageRepo_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function with given fields: ctx, admin, from
+func (_m *UsageRepo) History(ctx context.Context, admin string, from time.Time) ([]domain.Usage, error) {
+	ret := _m.Called(ctx, admin, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []domain.Usage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.Usage, error)); ok {
+		return rf(ctx, admin, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.Usage); ok {
+		r0 = rf(ctx, admin, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Usage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, admin, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsageRepo_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type UsageRepo_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - from time.Time
+func (_e *UsageRepo_Expecter) History(ctx interface{}, admin interface{}, from interface{}) *UsageRepo_History_Call {
+	return &UsageRepo_History_Call{Call: _e.mock.On("History", ctx, admin, from)}
+}
+
+func (_c *UsageRepo_History_Call) Run(run func(ctx context.Context, admin string, from time.Time)) *UsageRepo_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UsageRepo_History_Call) Return(_a0 []domain.Usage, _a1 error) *UsageRepo_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsageRepo_History_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]domain.Usage, error)) *UsageRepo_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, admin, day, quota
+func (_m *UsageRepo) Reserve(ctx context.Context, admin string, day time.Time, quota domain.Quota) (bool, error) {
+	ret := _m.Called(ctx, admin, day, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, domain.Quota) (bool, error)); ok {
+		return rf(ctx, admin, day, quota)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, domain.Quota) bool); ok {
+		r0 = rf(ctx, admin, day, quota)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, domain.Quota) error); ok {
+		r1 = rf(ctx, admin, day, quota)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsageRepo_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type UsageRepo_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - day time.Time
+//   - quota domain.Quota
+func (_e *UsageRepo_Expecter) Reserve(ctx interface{}, admin interface{}, day interface{}, quota interface{}) *UsageRepo_Reserve_Call {
+	return &UsageRepo_Reserve_Call{Call: _e.mock.On("Reserve", ctx, admin, day, quota)}
+}
+
+func (_c *UsageRepo_Reserve_Call) Run(run func(ctx context.Context, admin string, day time.Time, quota domain.Quota)) *UsageRepo_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(domain.Quota))
+	})
+	return _c
+}
+
+func (_c *UsageRepo_Reserve_Call) Return(_a0 bool, _a1 error) *UsageRepo_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsageRepo_Reserve_Call) RunAndReturn(run func(context.Context, string, time.Time, domain.Quota) (bool, error)) *UsageRepo_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Usage provides a mock function with given fields: ctx, admin, day
+func (_m *UsageRepo) Usage(ctx context.Context, admin string, day time.Time) (domain.Usage, error) {
+	ret := _m.Called(ctx, admin, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 domain.Usage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (domain.Usage, error)); ok {
+		return rf(ctx, admin, day)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) domain.Usage); ok {
+		r0 = rf(ctx, admin, day)
+	} else {
+		r0 = ret.Get(0).(domain.Usage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, admin, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UsageRepo_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type UsageRepo_Usage_Call struct {
+	*mock.Call
+}
+
+// Usage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - admin string
+//   - day time.Time
+func (_e *UsageRepo_Expecter) Usage(ctx interface{}, admin interface{}, day interface{}) *UsageRepo_Usage_Call {
+	return &UsageRepo_Usage_Call{Call: _e.mock.On("Usage", ctx, admin, day)}
+}
+
+func (_c *UsageRepo_Usage_Call) Run(run func(ctx context.Context, admin string, day time.Time)) *UsageRepo_Usage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UsageRepo_Usage_Call) Return(_a0 domain.Usage, _a1 error) *UsageRepo_Usage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UsageRepo_Usage_Call) RunAndReturn(run func(context.Context, string, time.Time) (domain.Usage, error)) *UsageRepo_Usage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUsageRepo creates a new instance of UsageRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsageRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsageRepo {
+	mock := &UsageRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
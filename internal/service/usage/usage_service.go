@@ -0,0 +1,95 @@
+package usage
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+)
+
+type UsageRepo interface {
+	Reserve(ctx context.Context, admin string, day time.Time, quota domain.Quota) (bool, error)
+	Add(ctx context.Context, admin string, day time.Time, tokens domain.TokenUsage) error
+	Usage(ctx context.Context, admin string, day time.Time) (domain.Usage, error)
+	History(ctx context.Context, admin string, from time.Time) ([]domain.Usage, error)
+}
+
+type usageService struct {
+	logger    *slog.Logger
+	usageRepo UsageRepo
+	quota     domain.Quota
+}
+
+func New(logger *slog.Logger, usageRepo UsageRepo, quota domain.Quota) *usageService {
+	return &usageService{logger, usageRepo, quota}
+}
+
+// today is the accounting day, days are counted in UTC
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// Reserve counts generation of admin before model is called, it returns ErrQuotaExceeded when daily quota is used.
+// Requests are reserved atomically, tokens are known only after generation, so concurrent generations
+// may exceed token quota by their own tokens.
+func (s *usageService) Reserve(ctx context.Context, admin string) error {
+	const op = "usage.Reserve"
+	logger := s.logger.With(slog.String("op", op), slog.String("admin", admin))
+
+	ok, err := s.usageRepo.Reserve(ctx, admin, today(), s.quota)
+	if err != nil {
+		logger.Error("failed to reserve usage", "error", err)
+		return err
+	}
+	if !ok {
+		return domain.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Record adds tokens of model call, request of generation is already counted by Reserve
+func (s *usageService) Record(ctx context.Context, admin string, tokens domain.TokenUsage) error {
+	const op = "usage.Record"
+	logger := s.logger.With(slog.String("op", op), slog.String("admin", admin))
+
+	if err := s.usageRepo.Add(ctx, admin, today(), tokens); err != nil {
+		logger.Error("failed to record usage", "error", err)
+		return err
+	}
+	return nil
+}
+
+// Report returns today usage with remaining quota and usage history for last days
+func (s *usageService) Report(ctx context.Context, admin string, days int) (domain.UsageReport, error) {
+	const op = "usage.Report"
+	logger := s.logger.With(slog.String("op", op), slog.String("admin", admin))
+
+	day := today()
+	history, err := s.usageRepo.History(ctx, admin, day.AddDate(0, 0, -days+1))
+	if err != nil {
+		logger.Error("failed to get usage history", "error", err)
+		return domain.UsageReport{}, err
+	}
+
+	report := domain.UsageReport{
+		Admin:   admin,
+		Today:   domain.Usage{Day: day},
+		Quota:   s.quota,
+		History: history,
+	}
+	for _, usage := range history {
+		if usage.Day.Equal(day) {
+			report.Today = usage
+		}
+	}
+	if s.quota.Tokens > 0 {
+		tokens := max(s.quota.Tokens-report.Today.TotalTokens, 0)
+		report.Remaining.Tokens = &tokens
+	}
+	if s.quota.Requests > 0 {
+		requests := max(s.quota.Requests-report.Today.Requests, 0)
+		report.Remaining.Requests = &requests
+	}
+	return report, nil
+}
@@ -0,0 +1,78 @@
+package usage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/usage"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/usage/mocks"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUsageService_Reserve(t *testing.T) {
+	type MockBehavior func(repo *mocks.UsageRepo)
+
+	quota := domain.Quota{Tokens: 1000, Requests: 10}
+	testCases := []struct {
+		name         string
+		mockBehavior MockBehavior
+		wantErr      error
+	}{
+		{
+			name: "within quota",
+			mockBehavior: func(repo *mocks.UsageRepo) {
+				repo.EXPECT().Reserve(mock.Anything, "admin", mock.Anything, quota).Return(true, nil).Once()
+			},
+		},
+		{
+			name: "quota exceeded",
+			mockBehavior: func(repo *mocks.UsageRepo) {
+				repo.EXPECT().Reserve(mock.Anything, "admin", mock.Anything, quota).Return(false, nil).Once()
+			},
+			wantErr: domain.ErrQuotaExceeded,
+		},
+		{
+			name: "repo error",
+			mockBehavior: func(repo *mocks.UsageRepo) {
+				repo.EXPECT().Reserve(mock.Anything, "admin", mock.Anything, quota).Return(false, assert.AnError).Once()
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewUsageRepo(t)
+			tc.mockBehavior(repo)
+
+			svc := usage.New(testutils.NewTestLogger(), repo, quota)
+			err := svc.Reserve(context.Background(), "admin")
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestUsageService_Report(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	repo := mocks.NewUsageRepo(t)
+	repo.EXPECT().History(mock.Anything, "admin", today.AddDate(0, 0, -6)).Return([]domain.Usage{
+		{Day: today, Requests: 4, TokenUsage: domain.TokenUsage{TotalTokens: 400}},
+		{Day: yesterday, Requests: 20, TokenUsage: domain.TokenUsage{TotalTokens: 2000}},
+	}, nil).Once()
+
+	svc := usage.New(testutils.NewTestLogger(), repo, domain.Quota{Tokens: 1000})
+	report, err := svc.Report(context.Background(), "admin", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Today.Requests)
+	assert.Len(t, report.History, 2)
+	if assert.NotNil(t, report.Remaining.Tokens) {
+		assert.Equal(t, int64(600), *report.Remaining.Tokens)
+	}
+	assert.Nil(t, report.Remaining.Requests)
+}
@@ -22,17 +22,17 @@ func (_m *UserRepo) EXPECT() *UserRepo_Expecter {
 	return &UserRepo_Expecter{mock: &_m.Mock}
 }
 
-// SaveUser provides a mock function with given fields: ctx, id, lvl
-func (_m *UserRepo) SaveUser(ctx context.Context, id int64, lvl domain.UserLvl) error {
-	ret := _m.Called(ctx, id, lvl)
+// SaveUser provides a mock function with given fields: ctx, id, lvl, timeZone
+func (_m *UserRepo) SaveUser(ctx context.Context, id int64, lvl domain.UserLvl, timeZone string) error {
+	ret := _m.Called(ctx, id, lvl, timeZone)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UserLvl) error); ok {
-		r0 = rf(ctx, id, lvl)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.UserLvl, string) error); ok {
+		r0 = rf(ctx, id, lvl, timeZone)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,13 +49,14 @@ type UserRepo_SaveUser_Call struct {
 //   - ctx context.Context
 //   - id int64
 //   - lvl domain.UserLvl
-func (_e *UserRepo_Expecter) SaveUser(ctx interface{}, id interface{}, lvl interface{}) *UserRepo_SaveUser_Call {
-	return &UserRepo_SaveUser_Call{Call: _e.mock.On("SaveUser", ctx, id, lvl)}
+//   - timeZone string
+func (_e *UserRepo_Expecter) SaveUser(ctx interface{}, id interface{}, lvl interface{}, timeZone interface{}) *UserRepo_SaveUser_Call {
+	return &UserRepo_SaveUser_Call{Call: _e.mock.On("SaveUser", ctx, id, lvl, timeZone)}
 }
 
-func (_c *UserRepo_SaveUser_Call) Run(run func(ctx context.Context, id int64, lvl domain.UserLvl)) *UserRepo_SaveUser_Call {
+func (_c *UserRepo_SaveUser_Call) Run(run func(ctx context.Context, id int64, lvl domain.UserLvl, timeZone string)) *UserRepo_SaveUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].(domain.UserLvl))
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.UserLvl), args[3].(string))
 	})
 	return _c
 }
@@ -65,7 +66,7 @@ func (_c *UserRepo_SaveUser_Call) Return(_a0 error) *UserRepo_SaveUser_Call {
 	return _c
 }
 
-func (_c *UserRepo_SaveUser_Call) RunAndReturn(run func(context.Context, int64, domain.UserLvl) error) *UserRepo_SaveUser_Call {
+func (_c *UserRepo_SaveUser_Call) RunAndReturn(run func(context.Context, int64, domain.UserLvl, string) error) *UserRepo_SaveUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -130,6 +131,54 @@ func (_c *UserRepo_Subscribers_Call) RunAndReturn(run func(context.Context, doma
 	return _c
 }
 
+// UpdateFrequency provides a mock function with given fields: ctx, id, frequency
+func (_m *UserRepo) UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error {
+	ret := _m.Called(ctx, id, frequency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFrequency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.DeliveryFrequency) error); ok {
+		r0 = rf(ctx, id, frequency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateFrequency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateFrequency'
+type UserRepo_UpdateFrequency_Call struct {
+	*mock.Call
+}
+
+// UpdateFrequency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - frequency domain.DeliveryFrequency
+func (_e *UserRepo_Expecter) UpdateFrequency(ctx interface{}, id interface{}, frequency interface{}) *UserRepo_UpdateFrequency_Call {
+	return &UserRepo_UpdateFrequency_Call{Call: _e.mock.On("UpdateFrequency", ctx, id, frequency)}
+}
+
+func (_c *UserRepo_UpdateFrequency_Call) Run(run func(ctx context.Context, id int64, frequency domain.DeliveryFrequency)) *UserRepo_UpdateFrequency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.DeliveryFrequency))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateFrequency_Call) Return(_a0 error) *UserRepo_UpdateFrequency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateFrequency_Call) RunAndReturn(run func(context.Context, int64, domain.DeliveryFrequency) error) *UserRepo_UpdateFrequency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateSubscribed provides a mock function with given fields: ctx, id, subscribed
 func (_m *UserRepo) UpdateSubscribed(ctx context.Context, id int64, subscribed bool) error {
 	ret := _m.Called(ctx, id, subscribed)
@@ -178,6 +227,54 @@ func (_c *UserRepo_UpdateSubscribed_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// UpdateTimeZone provides a mock function with given fields: ctx, id, timeZone
+func (_m *UserRepo) UpdateTimeZone(ctx context.Context, id int64, timeZone string) error {
+	ret := _m.Called(ctx, id, timeZone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTimeZone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, timeZone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateTimeZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTimeZone'
+type UserRepo_UpdateTimeZone_Call struct {
+	*mock.Call
+}
+
+// UpdateTimeZone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - timeZone string
+func (_e *UserRepo_Expecter) UpdateTimeZone(ctx interface{}, id interface{}, timeZone interface{}) *UserRepo_UpdateTimeZone_Call {
+	return &UserRepo_UpdateTimeZone_Call{Call: _e.mock.On("UpdateTimeZone", ctx, id, timeZone)}
+}
+
+func (_c *UserRepo_UpdateTimeZone_Call) Run(run func(ctx context.Context, id int64, timeZone string)) *UserRepo_UpdateTimeZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateTimeZone_Call) Return(_a0 error) *UserRepo_UpdateTimeZone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateTimeZone_Call) RunAndReturn(run func(context.Context, int64, string) error) *UserRepo_UpdateTimeZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUserLvl provides a mock function with given fields: ctx, id, lvl
 func (_m *UserRepo) UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserLvl) error {
 	ret := _m.Called(ctx, id, lvl)
@@ -226,6 +323,111 @@ func (_c *UserRepo_UpdateUserLvl_Call) RunAndReturn(run func(context.Context, in
 	return _c
 }
 
+// UpdateWindow provides a mock function with given fields: ctx, id, window
+func (_m *UserRepo) UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error {
+	ret := _m.Called(ctx, id, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, domain.DeliveryWindow) error); ok {
+		r0 = rf(ctx, id, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWindow'
+type UserRepo_UpdateWindow_Call struct {
+	*mock.Call
+}
+
+// UpdateWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - window domain.DeliveryWindow
+func (_e *UserRepo_Expecter) UpdateWindow(ctx interface{}, id interface{}, window interface{}) *UserRepo_UpdateWindow_Call {
+	return &UserRepo_UpdateWindow_Call{Call: _e.mock.On("UpdateWindow", ctx, id, window)}
+}
+
+func (_c *UserRepo_UpdateWindow_Call) Run(run func(ctx context.Context, id int64, window domain.DeliveryWindow)) *UserRepo_UpdateWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(domain.DeliveryWindow))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateWindow_Call) Return(_a0 error) *UserRepo_UpdateWindow_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateWindow_Call) RunAndReturn(run func(context.Context, int64, domain.DeliveryWindow) error) *UserRepo_UpdateWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserByID provides a mock function with given fields: ctx, id
+func (_m *UserRepo) UserByID(ctx context.Context, id int64) (domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_UserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByID'
+type UserRepo_UserByID_Call struct {
+	*mock.Call
+}
+
+// UserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *UserRepo_Expecter) UserByID(ctx interface{}, id interface{}) *UserRepo_UserByID_Call {
+	return &UserRepo_UserByID_Call{Call: _e.mock.On("UserByID", ctx, id)}
+}
+
+func (_c *UserRepo_UserByID_Call) Run(run func(ctx context.Context, id int64)) *UserRepo_UserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UserByID_Call) Return(_a0 domain.User, _a1 error) *UserRepo_UserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_UserByID_Call) RunAndReturn(run func(context.Context, int64) (domain.User, error)) *UserRepo_UserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UserExists provides a mock function with given fields: ctx, id
 func (_m *UserRepo) UserExists(ctx context.Context, id int64) (bool, error) {
 	ret := _m.Called(ctx, id)
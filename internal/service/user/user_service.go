@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/SergeyBogomolovv/fitflow/internal/domain"
 )
 
 type UserRepo interface {
-	SaveUser(ctx context.Context, id int64, lvl domain.UserLvl) error
+	SaveUser(ctx context.Context, id int64, lvl domain.UserLvl, timeZone string) error
 	UserExists(ctx context.Context, id int64) (bool, error)
 	UpdateSubscribed(ctx context.Context, id int64, subscribed bool) error
 	UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserLvl) error
+	UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error
+	UpdateTimeZone(ctx context.Context, id int64, timeZone string) error
+	UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error
 	Subscribers(ctx context.Context, lvl domain.UserLvl, all bool) ([]domain.User, error)
+	UserByID(ctx context.Context, id int64) (domain.User, error)
 }
 
 type service struct {
@@ -25,7 +31,8 @@ func New(logger *slog.Logger, userRepo UserRepo) *service {
 	return &service{logger, userRepo}
 }
 
-func (s *service) EnsureUserExists(ctx context.Context, id int64) error {
+// EnsureUserExists saves new user with time zone guessed from telegram language code
+func (s *service) EnsureUserExists(ctx context.Context, id int64, languageCode string) error {
 	const op = "user.EnsureUserExists"
 	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
 
@@ -38,7 +45,7 @@ func (s *service) EnsureUserExists(ctx context.Context, id int64) error {
 		return nil
 	}
 
-	if err := s.userRepo.SaveUser(ctx, id, domain.UserLvlDefault); err != nil {
+	if err := s.userRepo.SaveUser(ctx, id, domain.UserLvlDefault, GuessTimeZone(languageCode)); err != nil {
 		logger.Error("failed to save user", "error", err)
 		return err
 	}
@@ -74,6 +81,71 @@ func (s *service) UpdateUserLvl(ctx context.Context, id int64, lvl domain.UserLv
 	return nil
 }
 
+func (s *service) User(ctx context.Context, id int64) (domain.User, error) {
+	const op = "user.User"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	user, err := s.userRepo.UserByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			logger.Error("failed to get user", "error", err)
+		}
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
+func (s *service) UpdateFrequency(ctx context.Context, id int64, frequency domain.DeliveryFrequency) error {
+	const op = "user.UpdateFrequency"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if !frequency.Valid() {
+		return domain.ErrInvalidDeliveryFrequency
+	}
+	if err := s.userRepo.UpdateFrequency(ctx, id, frequency); err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			logger.Error("failed to update user frequency", "error", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) UpdateTimeZone(ctx context.Context, id int64, timeZone string) error {
+	const op = "user.UpdateTimeZone"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "" || timeZone == "Local" {
+		return domain.ErrInvalidTimeZone
+	}
+	if err := s.userRepo.UpdateTimeZone(ctx, id, timeZone); err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			logger.Error("failed to update user time zone", "error", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) UpdateWindow(ctx context.Context, id int64, window domain.DeliveryWindow) error {
+	const op = "user.UpdateWindow"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("id", id))
+
+	if !window.Valid() {
+		return domain.ErrInvalidDeliveryWindow
+	}
+	if err := s.userRepo.UpdateWindow(ctx, id, window); err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			logger.Error("failed to update user delivery window", "error", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // if lvl is default it returns all subscribers ids
 func (s *service) SubscribersIds(ctx context.Context, lvl domain.UserLvl) ([]int64, error) {
 	const op = "user.SubscribersIds"
@@ -95,3 +167,32 @@ func (s *service) SubscribersIds(ctx context.Context, lvl domain.UserLvl) ([]int
 	}
 	return res, nil
 }
+
+// timeZones are the most common zones of language speakers, users can change them in settings
+var timeZones = map[string]string{
+	"ru": "Europe/Moscow",
+	"uk": "Europe/Kyiv",
+	"be": "Europe/Minsk",
+	"kk": "Asia/Almaty",
+	"uz": "Asia/Tashkent",
+	"ky": "Asia/Bishkek",
+	"hy": "Asia/Yerevan",
+	"ka": "Asia/Tbilisi",
+	"az": "Asia/Baku",
+	"tr": "Europe/Istanbul",
+	"de": "Europe/Berlin",
+	"fr": "Europe/Paris",
+	"es": "Europe/Madrid",
+	"it": "Europe/Rome",
+	"pl": "Europe/Warsaw",
+	"en": "UTC",
+}
+
+// GuessTimeZone returns time zone for IETF language tag like "ru" or "pt-br"
+func GuessTimeZone(languageCode string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(languageCode), "-")
+	if zone, ok := timeZones[lang]; ok {
+		return zone
+	}
+	return domain.DefaultTimeZone
+}
@@ -9,12 +9,14 @@ import (
 	"github.com/SergeyBogomolovv/fitflow/internal/service/user/mocks"
 	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestUserService_EnsureUserExists(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  int64
+		ctx          context.Context
+		id           int64
+		languageCode string
 	}
 
 	type MockBehavior func(repo *mocks.UserRepo, args args)
@@ -27,13 +29,39 @@ func TestUserService_EnsureUserExists(t *testing.T) {
 	}{
 		{
 			name: "not exists, need to save",
+			args: args{
+				ctx:          context.Background(),
+				id:           1,
+				languageCode: "ru",
+			},
+			mockBehavior: func(repo *mocks.UserRepo, args args) {
+				repo.EXPECT().UserExists(args.ctx, args.id).Return(false, nil).Once()
+				repo.EXPECT().SaveUser(args.ctx, args.id, domain.UserLvlDefault, "Europe/Moscow").Return(nil).Once()
+			},
+			want: nil,
+		},
+		{
+			name: "time zone from language with region",
+			args: args{
+				ctx:          context.Background(),
+				id:           1,
+				languageCode: "uk-UA",
+			},
+			mockBehavior: func(repo *mocks.UserRepo, args args) {
+				repo.EXPECT().UserExists(args.ctx, args.id).Return(false, nil).Once()
+				repo.EXPECT().SaveUser(args.ctx, args.id, domain.UserLvlDefault, "Europe/Kyiv").Return(nil).Once()
+			},
+			want: nil,
+		},
+		{
+			name: "unknown language",
 			args: args{
 				ctx: context.Background(),
 				id:  1,
 			},
 			mockBehavior: func(repo *mocks.UserRepo, args args) {
 				repo.EXPECT().UserExists(args.ctx, args.id).Return(false, nil).Once()
-				repo.EXPECT().SaveUser(args.ctx, args.id, domain.UserLvlDefault).Return(nil).Once()
+				repo.EXPECT().SaveUser(args.ctx, args.id, domain.UserLvlDefault, domain.DefaultTimeZone).Return(nil).Once()
 			},
 			want: nil,
 		},
@@ -55,7 +83,7 @@ func TestUserService_EnsureUserExists(t *testing.T) {
 			repo := mocks.NewUserRepo(t)
 			tc.mockBehavior(repo, tc.args)
 			svc := userSvc.New(testutils.NewTestLogger(), repo)
-			err := svc.EnsureUserExists(tc.args.ctx, tc.args.id)
+			err := svc.EnsureUserExists(tc.args.ctx, tc.args.id, tc.args.languageCode)
 
 			if tc.want == nil {
 				assert.NoError(t, err)
@@ -192,6 +220,168 @@ func TestUserService_UpdateUserLvl(t *testing.T) {
 	}
 }
 
+func TestUserService_UpdateFrequency(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		id        int64
+		frequency domain.DeliveryFrequency
+	}
+
+	type MockBehavior func(repo *mocks.UserRepo, args args)
+
+	testCases := []struct {
+		name         string
+		args         args
+		mockBehavior MockBehavior
+		want         error
+	}{
+		{
+			name: "success",
+			args: args{
+				ctx:       context.Background(),
+				id:        1,
+				frequency: domain.DeliveryFrequencyWeekly,
+			},
+			mockBehavior: func(repo *mocks.UserRepo, args args) {
+				repo.EXPECT().UpdateFrequency(args.ctx, args.id, args.frequency).Return(nil).Once()
+			},
+			want: nil,
+		},
+		{
+			name: "invalid frequency",
+			args: args{
+				ctx:       context.Background(),
+				id:        1,
+				frequency: "hourly",
+			},
+			mockBehavior: func(repo *mocks.UserRepo, args args) {},
+			want:         domain.ErrInvalidDeliveryFrequency,
+		},
+		{
+			name: "user not found",
+			args: args{
+				ctx:       context.Background(),
+				id:        1,
+				frequency: domain.DeliveryFrequencyDaily,
+			},
+			mockBehavior: func(repo *mocks.UserRepo, args args) {
+				repo.EXPECT().UpdateFrequency(args.ctx, args.id, args.frequency).Return(domain.ErrUserNotFound).Once()
+			},
+			want: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewUserRepo(t)
+			tc.mockBehavior(repo, tc.args)
+			svc := userSvc.New(testutils.NewTestLogger(), repo)
+			err := svc.UpdateFrequency(tc.args.ctx, tc.args.id, tc.args.frequency)
+
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestUserService_UpdateTimeZone(t *testing.T) {
+	testCases := []struct {
+		name         string
+		timeZone     string
+		mockBehavior func(repo *mocks.UserRepo)
+		want         error
+	}{
+		{
+			name:     "success",
+			timeZone: "Asia/Novosibirsk",
+			mockBehavior: func(repo *mocks.UserRepo) {
+				repo.EXPECT().UpdateTimeZone(mock.Anything, int64(1), "Asia/Novosibirsk").Return(nil).Once()
+			},
+		},
+		{
+			name:         "unknown zone",
+			timeZone:     "Mars/Olympus",
+			mockBehavior: func(repo *mocks.UserRepo) {},
+			want:         domain.ErrInvalidTimeZone,
+		},
+		{
+			name:         "local zone",
+			timeZone:     "Local",
+			mockBehavior: func(repo *mocks.UserRepo) {},
+			want:         domain.ErrInvalidTimeZone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewUserRepo(t)
+			tc.mockBehavior(repo)
+			svc := userSvc.New(testutils.NewTestLogger(), repo)
+			err := svc.UpdateTimeZone(context.Background(), 1, tc.timeZone)
+
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestUserService_UpdateWindow(t *testing.T) {
+	testCases := []struct {
+		name         string
+		window       domain.DeliveryWindow
+		mockBehavior func(repo *mocks.UserRepo)
+		want         error
+	}{
+		{
+			name:   "success",
+			window: domain.DeliveryWindow{Start: 17, End: 22},
+			mockBehavior: func(repo *mocks.UserRepo) {
+				repo.EXPECT().UpdateWindow(mock.Anything, int64(1), domain.DeliveryWindow{Start: 17, End: 22}).Return(nil).Once()
+			},
+		},
+		{
+			name:   "wraps midnight",
+			window: domain.DeliveryWindow{Start: 22, End: 2},
+			mockBehavior: func(repo *mocks.UserRepo) {
+				repo.EXPECT().UpdateWindow(mock.Anything, int64(1), domain.DeliveryWindow{Start: 22, End: 2}).Return(nil).Once()
+			},
+		},
+		{
+			name:         "empty window",
+			window:       domain.DeliveryWindow{Start: 9, End: 9},
+			mockBehavior: func(repo *mocks.UserRepo) {},
+			want:         domain.ErrInvalidDeliveryWindow,
+		},
+		{
+			name:         "hour out of range",
+			window:       domain.DeliveryWindow{Start: 9, End: 25},
+			mockBehavior: func(repo *mocks.UserRepo) {},
+			want:         domain.ErrInvalidDeliveryWindow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewUserRepo(t)
+			tc.mockBehavior(repo)
+			svc := userSvc.New(testutils.NewTestLogger(), repo)
+			err := svc.UpdateWindow(context.Background(), 1, tc.window)
+
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
 func TestUserService_SubscribersIds(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserRepo is an autogenerated mock type for the UserRepo type
+type UserRepo struct {
+	mock.Mock
+}
+
+type UserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserRepo) EXPECT() *UserRepo_Expecter {
+	return &UserRepo_Expecter{mock: &_m.Mock}
+}
+
+// UserByID provides a mock function with given fields: ctx, id
+func (_m *UserRepo) UserByID(ctx context.Context, id int64) (domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_UserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserByID'
+type UserRepo_UserByID_Call struct {
+	*mock.Call
+}
+
+// UserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *UserRepo_Expecter) UserByID(ctx interface{}, id interface{}) *UserRepo_UserByID_Call {
+	return &UserRepo_UserByID_Call{Call: _e.mock.On("UserByID", ctx, id)}
+}
+
+func (_c *UserRepo_UserByID_Call) Run(run func(ctx context.Context, id int64)) *UserRepo_UserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UserByID_Call) Return(_a0 domain.User, _a1 error) *UserRepo_UserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_UserByID_Call) RunAndReturn(run func(context.Context, int64) (domain.User, error)) *UserRepo_UserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserRepo creates a new instance of UserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserRepo {
+	mock := &UserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
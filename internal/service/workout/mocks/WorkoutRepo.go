@@ -0,0 +1,213 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/SergeyBogomolovv/fitflow/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	repoworkout "github.com/SergeyBogomolovv/fitflow/internal/repo/workout"
+
+	time "time"
+)
+
+// WorkoutRepo is an autogenerated mock type for the WorkoutRepo type
+type WorkoutRepo struct {
+	mock.Mock
+}
+
+type WorkoutRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WorkoutRepo) EXPECT() *WorkoutRepo_Expecter {
+	return &WorkoutRepo_Expecter{mock: &_m.Mock}
+}
+
+// CountSince provides a mock function with given fields: ctx, userID, since
+func (_m *WorkoutRepo) CountSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	ret := _m.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSince")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (int, error)); ok {
+		return rf(ctx, userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) int); ok {
+		r0 = rf(ctx, userID, since)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkoutRepo_CountSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSince'
+type WorkoutRepo_CountSince_Call struct {
+	*mock.Call
+}
+
+// CountSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - since time.Time
+func (_e *WorkoutRepo_Expecter) CountSince(ctx interface{}, userID interface{}, since interface{}) *WorkoutRepo_CountSince_Call {
+	return &WorkoutRepo_CountSince_Call{Call: _e.mock.On("CountSince", ctx, userID, since)}
+}
+
+func (_c *WorkoutRepo_CountSince_Call) Run(run func(ctx context.Context, userID int64, since time.Time)) *WorkoutRepo_CountSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WorkoutRepo_CountSince_Call) Return(_a0 int, _a1 error) *WorkoutRepo_CountSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkoutRepo_CountSince_Call) RunAndReturn(run func(context.Context, int64, time.Time) (int, error)) *WorkoutRepo_CountSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Latest provides a mock function with given fields: ctx, userID
+func (_m *WorkoutRepo) Latest(ctx context.Context, userID int64) (domain.WorkoutPlan, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Latest")
+	}
+
+	var r0 domain.WorkoutPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (domain.WorkoutPlan, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) domain.WorkoutPlan); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.WorkoutPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkoutRepo_Latest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Latest'
+type WorkoutRepo_Latest_Call struct {
+	*mock.Call
+}
+
+// Latest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *WorkoutRepo_Expecter) Latest(ctx interface{}, userID interface{}) *WorkoutRepo_Latest_Call {
+	return &WorkoutRepo_Latest_Call{Call: _e.mock.On("Latest", ctx, userID)}
+}
+
+func (_c *WorkoutRepo_Latest_Call) Run(run func(ctx context.Context, userID int64)) *WorkoutRepo_Latest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *WorkoutRepo_Latest_Call) Return(_a0 domain.WorkoutPlan, _a1 error) *WorkoutRepo_Latest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkoutRepo_Latest_Call) RunAndReturn(run func(context.Context, int64) (domain.WorkoutPlan, error)) *WorkoutRepo_Latest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, in
+func (_m *WorkoutRepo) Save(ctx context.Context, in repoworkout.SavePlanInput) (domain.WorkoutPlan, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 domain.WorkoutPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repoworkout.SavePlanInput) (domain.WorkoutPlan, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repoworkout.SavePlanInput) domain.WorkoutPlan); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(domain.WorkoutPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repoworkout.SavePlanInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WorkoutRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type WorkoutRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repoworkout.SavePlanInput
+func (_e *WorkoutRepo_Expecter) Save(ctx interface{}, in interface{}) *WorkoutRepo_Save_Call {
+	return &WorkoutRepo_Save_Call{Call: _e.mock.On("Save", ctx, in)}
+}
+
+func (_c *WorkoutRepo_Save_Call) Run(run func(ctx context.Context, in repoworkout.SavePlanInput)) *WorkoutRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repoworkout.SavePlanInput))
+	})
+	return _c
+}
+
+func (_c *WorkoutRepo_Save_Call) Return(_a0 domain.WorkoutPlan, _a1 error) *WorkoutRepo_Save_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WorkoutRepo_Save_Call) RunAndReturn(run func(context.Context, repoworkout.SavePlanInput) (domain.WorkoutPlan, error)) *WorkoutRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWorkoutRepo creates a new instance of WorkoutRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWorkoutRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WorkoutRepo {
+	mock := &WorkoutRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,204 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	workoutRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/workout"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+)
+
+type WorkoutRepo interface {
+	Save(ctx context.Context, in workoutRepo.SavePlanInput) (domain.WorkoutPlan, error)
+	Latest(ctx context.Context, userID int64) (domain.WorkoutPlan, error)
+	CountSince(ctx context.Context, userID int64, since time.Time) (int, error)
+}
+
+type UserRepo interface {
+	UserByID(ctx context.Context, id int64) (domain.User, error)
+}
+
+type AiGenerator interface {
+	GenerateContent(ctx context.Context, req ai.Request) (ai.Response, error)
+}
+
+type Options struct {
+	// DailyLimit is number of generated plans per user a day, zero means no limit
+	DailyLimit int
+	// Prompt is system prompt of plan generator
+	Prompt string
+}
+
+type service struct {
+	logger      *slog.Logger
+	workoutRepo WorkoutRepo
+	userRepo    UserRepo
+	ai          AiGenerator
+	opts        Options
+}
+
+const maxTextLength = 500
+
+func New(logger *slog.Logger, workoutRepo WorkoutRepo, userRepo UserRepo, ai AiGenerator, opts Options) *service {
+	return &service{logger, workoutRepo, userRepo, ai, opts}
+}
+
+// today is the limit day, days are counted in UTC
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// CreatePlan generates weekly plan for user level and questionnaire answers
+func (s *service) CreatePlan(ctx context.Context, userID int64, params domain.WorkoutParams) (domain.WorkoutPlan, error) {
+	const op = "workout.CreatePlan"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	if err := validateParams(params); err != nil {
+		return domain.WorkoutPlan{}, err
+	}
+	params.Injuries = truncate(strings.TrimSpace(params.Injuries))
+
+	return s.generate(ctx, logger, userID, params, "", "")
+}
+
+// AdjustPlan regenerates the latest plan with the same answers and user wishes
+func (s *service) AdjustPlan(ctx context.Context, userID int64, adjustments string) (domain.WorkoutPlan, error) {
+	const op = "workout.AdjustPlan"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	adjustments = truncate(strings.TrimSpace(adjustments))
+	if adjustments == "" {
+		return domain.WorkoutPlan{}, domain.ErrEmptyWorkoutAdjustment
+	}
+
+	latest, err := s.LatestPlan(ctx, userID)
+	if err != nil {
+		return domain.WorkoutPlan{}, err
+	}
+	return s.generate(ctx, logger, userID, latest.Params, adjustments, latest.Content)
+}
+
+func (s *service) LatestPlan(ctx context.Context, userID int64) (domain.WorkoutPlan, error) {
+	const op = "workout.LatestPlan"
+	logger := s.logger.With(slog.String("op", op), slog.Int64("user_id", userID))
+
+	plan, err := s.workoutRepo.Latest(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrWorkoutPlanNotFound) {
+			logger.Error("failed to get workout plan", "error", err)
+		}
+		return domain.WorkoutPlan{}, err
+	}
+	return plan, nil
+}
+
+func (s *service) generate(ctx context.Context, logger *slog.Logger, userID int64, params domain.WorkoutParams, adjustments, previous string) (domain.WorkoutPlan, error) {
+	if s.opts.DailyLimit > 0 {
+		count, err := s.workoutRepo.CountSince(ctx, userID, today())
+		if err != nil {
+			logger.Error("failed to count workout plans", "error", err)
+			return domain.WorkoutPlan{}, err
+		}
+		if count >= s.opts.DailyLimit {
+			return domain.WorkoutPlan{}, domain.ErrWorkoutLimitExceeded
+		}
+	}
+
+	lvl := domain.UserLvlDefault
+	user, err := s.userRepo.UserByID(ctx, userID)
+	if err == nil {
+		lvl = user.Lvl
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		logger.Error("failed to get user", "error", err)
+		return domain.WorkoutPlan{}, err
+	}
+
+	resp, err := s.ai.GenerateContent(ctx, ai.Request{
+		Prompt: buildPlanPrompt(lvl, params, adjustments, previous),
+		System: s.opts.Prompt,
+	})
+	if err != nil {
+		logger.Error("failed to generate workout plan", "error", err)
+		return domain.WorkoutPlan{}, err
+	}
+
+	plan, err := s.workoutRepo.Save(ctx, workoutRepo.SavePlanInput{
+		UserID:      userID,
+		Level:       lvl,
+		Params:      params,
+		Adjustments: adjustments,
+		Content:     strings.TrimSpace(resp.Text),
+	})
+	if err != nil {
+		logger.Error("failed to save workout plan", "error", err)
+		return domain.WorkoutPlan{}, err
+	}
+
+	logger.Info("workout plan generated", "plan_id", plan.ID, "adjusted", adjustments != "")
+	return plan, nil
+}
+
+func validateParams(params domain.WorkoutParams) error {
+	if _, ok := goalPrompts[params.Goal]; !ok {
+		return domain.ErrInvalidWorkoutParams
+	}
+	if _, ok := equipmentPrompts[params.Equipment]; !ok {
+		return domain.ErrInvalidWorkoutParams
+	}
+	if params.DaysPerWeek < domain.MinWorkoutDays || params.DaysPerWeek > domain.MaxWorkoutDays {
+		return domain.ErrInvalidWorkoutParams
+	}
+	return nil
+}
+
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > maxTextLength {
+		return string(runes[:maxTextLength])
+	}
+	return s
+}
+
+var levelPrompts = map[domain.UserLvl]string{
+	domain.UserLvlDefault:      "неизвестен, составь план как для новичка",
+	domain.UserLvlBeginner:     "новичок, тренируется меньше полугода",
+	domain.UserLvlIntermediate: "средний, регулярно тренируется больше года",
+	domain.UserLvlAdvanced:     "продвинутый, тренируется несколько лет с большими весами",
+}
+
+var goalPrompts = map[domain.WorkoutGoal]string{
+	domain.WorkoutGoalLoseWeight:  "снижение веса",
+	domain.WorkoutGoalBuildMuscle: "набор мышечной массы",
+	domain.WorkoutGoalEndurance:   "развитие выносливости",
+	domain.WorkoutGoalHealth:      "общее здоровье и тонус",
+}
+
+var equipmentPrompts = map[domain.Equipment]string{
+	domain.EquipmentNone: "без инвентаря, только собственный вес",
+	domain.EquipmentHome: "домашний инвентарь: гантели, резинки, турник",
+	domain.EquipmentGym:  "тренажерный зал",
+}
+
+func buildPlanPrompt(lvl domain.UserLvl, params domain.WorkoutParams, adjustments, previous string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Уровень подготовки: %s\n", levelPrompts[lvl])
+	fmt.Fprintf(&sb, "Цель: %s\n", goalPrompts[params.Goal])
+	fmt.Fprintf(&sb, "Тренировок в неделю: %d\n", params.DaysPerWeek)
+	fmt.Fprintf(&sb, "Инвентарь: %s\n", equipmentPrompts[params.Equipment])
+	if params.Injuries != "" {
+		fmt.Fprintf(&sb, "Травмы и ограничения: %s\n", params.Injuries)
+	} else {
+		sb.WriteString("Травмы и ограничения: нет\n")
+	}
+	if previous != "" {
+		fmt.Fprintf(&sb, "\nПредыдущий план:\n%s\n\nПожелания к изменению плана: %s\n", previous, adjustments)
+		sb.WriteString("\nСоставь новый план с учетом пожеланий.")
+	} else {
+		sb.WriteString("\nСоставь план тренировок на неделю.")
+	}
+	return sb.String()
+}
@@ -0,0 +1,203 @@
+package workout_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/internal/domain"
+	workoutRepo "github.com/SergeyBogomolovv/fitflow/internal/repo/workout"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/workout"
+	"github.com/SergeyBogomolovv/fitflow/internal/service/workout/mocks"
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWorkoutService_CreatePlan(t *testing.T) {
+	type MockBehavior func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator)
+
+	opts := workout.Options{DailyLimit: 2, Prompt: "plan prompt"}
+	params := domain.WorkoutParams{
+		Goal:        domain.WorkoutGoalBuildMuscle,
+		DaysPerWeek: 3,
+		Equipment:   domain.EquipmentGym,
+		Injuries:    "  knee  ",
+	}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		params       domain.WorkoutParams
+		opts         workout.Options
+		mockBehavior MockBehavior
+		want         domain.WorkoutPlan
+		wantErr      error
+	}{
+		{
+			name:   "success",
+			params: params,
+			opts:   opts,
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountSince(mock.Anything, int64(1), mock.Anything).Return(1, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{ID: 1, Lvl: domain.UserLvlIntermediate}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return req.System == "plan prompt" &&
+						strings.Contains(req.Prompt, "Уровень подготовки: средний") &&
+						strings.Contains(req.Prompt, "Цель: набор мышечной массы") &&
+						strings.Contains(req.Prompt, "Тренировок в неделю: 3") &&
+						strings.Contains(req.Prompt, "Травмы и ограничения: knee\n")
+				})).Return(ai.Response{Text: " Day 1: squats "}, nil).Once()
+				repo.EXPECT().Save(mock.Anything, workoutRepo.SavePlanInput{
+					UserID:  1,
+					Level:   domain.UserLvlIntermediate,
+					Params:  domain.WorkoutParams{Goal: domain.WorkoutGoalBuildMuscle, DaysPerWeek: 3, Equipment: domain.EquipmentGym, Injuries: "knee"},
+					Content: "Day 1: squats",
+				}).Return(domain.WorkoutPlan{ID: 5, UserID: 1, Content: "Day 1: squats", CreatedAt: createdAt}, nil).Once()
+			},
+			want: domain.WorkoutPlan{ID: 5, UserID: 1, Content: "Day 1: squats", CreatedAt: createdAt},
+		},
+		{
+			name:   "unknown user without limit",
+			params: domain.WorkoutParams{Goal: domain.WorkoutGoalHealth, DaysPerWeek: 1, Equipment: domain.EquipmentNone},
+			opts:   workout.Options{},
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{}, domain.ErrUserNotFound).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "Уровень подготовки: неизвестен") &&
+						strings.Contains(req.Prompt, "Травмы и ограничения: нет")
+				})).Return(ai.Response{Text: "Walk"}, nil).Once()
+				repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(in workoutRepo.SavePlanInput) bool {
+					return in.Level == domain.UserLvlDefault && in.Content == "Walk"
+				})).Return(domain.WorkoutPlan{ID: 6, Content: "Walk"}, nil).Once()
+			},
+			want: domain.WorkoutPlan{ID: 6, Content: "Walk"},
+		},
+		{
+			name:         "invalid days",
+			params:       domain.WorkoutParams{Goal: domain.WorkoutGoalHealth, DaysPerWeek: 7, Equipment: domain.EquipmentNone},
+			opts:         opts,
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {},
+			wantErr:      domain.ErrInvalidWorkoutParams,
+		},
+		{
+			name:         "invalid goal",
+			params:       domain.WorkoutParams{Goal: "fly", DaysPerWeek: 3, Equipment: domain.EquipmentNone},
+			opts:         opts,
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {},
+			wantErr:      domain.ErrInvalidWorkoutParams,
+		},
+		{
+			name:   "limit exceeded",
+			params: params,
+			opts:   opts,
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountSince(mock.Anything, int64(1), mock.Anything).Return(2, nil).Once()
+			},
+			wantErr: domain.ErrWorkoutLimitExceeded,
+		},
+		{
+			name:   "ai error",
+			params: params,
+			opts:   opts,
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().CountSince(mock.Anything, int64(1), mock.Anything).Return(0, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{ID: 1}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.Anything).Return(ai.Response{}, ai.ErrRateLimited).Once()
+			},
+			wantErr: ai.ErrRateLimited,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewWorkoutRepo(t)
+			users := mocks.NewUserRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, users, gen)
+
+			svc := workout.New(testutils.NewTestLogger(), repo, users, gen, tc.opts)
+			got, err := svc.CreatePlan(context.Background(), 1, tc.params)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWorkoutService_AdjustPlan(t *testing.T) {
+	type MockBehavior func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator)
+
+	latest := domain.WorkoutPlan{
+		ID:      5,
+		UserID:  1,
+		Params:  domain.WorkoutParams{Goal: domain.WorkoutGoalEndurance, DaysPerWeek: 4, Equipment: domain.EquipmentHome},
+		Content: "Day 1: run",
+	}
+
+	testCases := []struct {
+		name         string
+		adjustments  string
+		mockBehavior MockBehavior
+		want         domain.WorkoutPlan
+		wantErr      error
+	}{
+		{
+			name:        "success",
+			adjustments: " no running ",
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().Latest(mock.Anything, int64(1)).Return(latest, nil).Once()
+				users.EXPECT().UserByID(mock.Anything, int64(1)).Return(domain.User{ID: 1, Lvl: domain.UserLvlBeginner}, nil).Once()
+				gen.EXPECT().GenerateContent(mock.Anything, mock.MatchedBy(func(req ai.Request) bool {
+					return strings.Contains(req.Prompt, "Предыдущий план:\nDay 1: run") &&
+						strings.Contains(req.Prompt, "Пожелания к изменению плана: no running")
+				})).Return(ai.Response{Text: "Day 1: bike"}, nil).Once()
+				repo.EXPECT().Save(mock.Anything, workoutRepo.SavePlanInput{
+					UserID:      1,
+					Level:       domain.UserLvlBeginner,
+					Params:      latest.Params,
+					Adjustments: "no running",
+					Content:     "Day 1: bike",
+				}).Return(domain.WorkoutPlan{ID: 6, Content: "Day 1: bike"}, nil).Once()
+			},
+			want: domain.WorkoutPlan{ID: 6, Content: "Day 1: bike"},
+		},
+		{
+			name:         "empty adjustments",
+			adjustments:  "  ",
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {},
+			wantErr:      domain.ErrEmptyWorkoutAdjustment,
+		},
+		{
+			name:        "no plan",
+			adjustments: "no running",
+			mockBehavior: func(repo *mocks.WorkoutRepo, users *mocks.UserRepo, gen *mocks.AiGenerator) {
+				repo.EXPECT().Latest(mock.Anything, int64(1)).Return(domain.WorkoutPlan{}, domain.ErrWorkoutPlanNotFound).Once()
+			},
+			wantErr: domain.ErrWorkoutPlanNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := mocks.NewWorkoutRepo(t)
+			users := mocks.NewUserRepo(t)
+			gen := mocks.NewAiGenerator(t)
+			tc.mockBehavior(repo, users, gen)
+
+			svc := workout.New(testutils.NewTestLogger(), repo, users, gen, workout.Options{})
+			got, err := svc.AdjustPlan(context.Background(), 1, tc.adjustments)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
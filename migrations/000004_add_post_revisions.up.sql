@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS post_revisions
+(
+	post_id INT NOT NULL REFERENCES posts (post_id) ON DELETE CASCADE,
+	version INT NOT NULL,
+	content TEXT NOT NULL,
+	audience user_lvl NOT NULL,
+	images TEXT[] DEFAULT '{}',
+	author VARCHAR(25),
+	created_at TIMESTAMP DEFAULT NOW(),
+	PRIMARY KEY (post_id, version)
+);
+
+INSERT INTO post_revisions (post_id, version, content, audience, images, created_at)
+SELECT post_id, 1, content, audience, images, created_at FROM posts;
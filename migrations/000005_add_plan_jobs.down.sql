@@ -0,0 +1,8 @@
+ALTER TABLE posts
+	DROP COLUMN IF EXISTS job_id,
+	DROP COLUMN IF EXISTS scheduled_for,
+	DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS post_status;
+DROP TABLE IF EXISTS plan_jobs;
+DROP TYPE IF EXISTS job_status;
@@ -0,0 +1,22 @@
+CREATE TYPE job_status AS ENUM ('pending', 'running', 'done', 'failed');
+
+CREATE TABLE IF NOT EXISTS plan_jobs
+(
+	job_id UUID PRIMARY KEY,
+	status job_status NOT NULL DEFAULT 'pending',
+	params JSONB NOT NULL,
+	total INT NOT NULL,
+	completed INT NOT NULL DEFAULT 0,
+	failed INT NOT NULL DEFAULT 0,
+	error TEXT,
+	created_by VARCHAR(25),
+	created_at TIMESTAMP DEFAULT NOW(),
+	finished_at TIMESTAMP
+);
+
+CREATE TYPE post_status AS ENUM ('draft', 'approved');
+
+ALTER TABLE posts
+	ADD COLUMN status post_status NOT NULL DEFAULT 'approved',
+	ADD COLUMN scheduled_for TIMESTAMP,
+	ADD COLUMN job_id UUID REFERENCES plan_jobs (job_id) ON DELETE SET NULL;
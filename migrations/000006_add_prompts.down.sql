@@ -0,0 +1,6 @@
+ALTER TABLE posts
+	DROP COLUMN IF EXISTS prompt_version,
+	DROP COLUMN IF EXISTS prompt_id;
+
+DROP TABLE IF EXISTS prompt_versions;
+DROP TABLE IF EXISTS prompts;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS prompts
+(
+	prompt_id SERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	active_version INT NOT NULL DEFAULT 1,
+	created_at TIMESTAMP DEFAULT NOW(),
+	updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS prompt_versions
+(
+	prompt_id INT NOT NULL REFERENCES prompts (prompt_id) ON DELETE CASCADE,
+	version INT NOT NULL,
+	template TEXT NOT NULL,
+	author VARCHAR(25),
+	created_at TIMESTAMP DEFAULT NOW(),
+	PRIMARY KEY (prompt_id, version)
+);
+
+ALTER TABLE posts
+	ADD COLUMN prompt_id INT REFERENCES prompts (prompt_id) ON DELETE SET NULL,
+	ADD COLUMN prompt_version INT;
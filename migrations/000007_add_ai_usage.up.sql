@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS ai_usage
+(
+	admin VARCHAR(25) NOT NULL,
+	day DATE NOT NULL,
+	requests INT NOT NULL DEFAULT 0,
+	prompt_tokens BIGINT NOT NULL DEFAULT 0,
+	completion_tokens BIGINT NOT NULL DEFAULT 0,
+	total_tokens BIGINT NOT NULL DEFAULT 0,
+	PRIMARY KEY (admin, day)
+);
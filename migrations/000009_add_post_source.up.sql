@@ -0,0 +1 @@
+ALTER TABLE posts ADD COLUMN source_post_id INT REFERENCES posts (post_id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS assistant_messages
+(
+	message_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id BIGINT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+	role VARCHAR(10) NOT NULL,
+	content TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS assistant_messages_user_idx ON assistant_messages (user_id, created_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS workout_plans
+(
+	plan_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id BIGINT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+	lvl user_lvl NOT NULL,
+	goal VARCHAR(20) NOT NULL,
+	days_per_week INT NOT NULL,
+	equipment VARCHAR(10) NOT NULL,
+	injuries TEXT NOT NULL DEFAULT '',
+	adjustments TEXT NOT NULL DEFAULT '',
+	content TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS workout_plans_user_idx ON workout_plans (user_id, created_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS bot_states
+(
+	user_id BIGINT PRIMARY KEY,
+	kind VARCHAR(50) NOT NULL,
+	data JSONB NOT NULL,
+	expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS bot_states_expires_idx ON bot_states (expires_at);
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS quiz_version;
+
+DROP TABLE IF EXISTS quizzes;
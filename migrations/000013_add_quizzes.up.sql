@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS quizzes
+(
+	version SERIAL PRIMARY KEY,
+	questions JSONB NOT NULL,
+	thresholds JSONB NOT NULL,
+	active BOOLEAN NOT NULL DEFAULT FALSE,
+	author VARCHAR(25),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS quizzes_active_idx ON quizzes (active) WHERE active;
+
+INSERT INTO quizzes (questions, thresholds, active) VALUES (
+	'[
+		{"text": "Как часто вы тренируетесь в зале?", "options": [
+			{"text": "1 Раз в неделю", "weight": 1},
+			{"text": "2 Раза в неделю", "weight": 2},
+			{"text": "3 и более раз в неделю", "weight": 3}
+		]},
+		{"text": "Сколько лет вы занимаетесь силовыми тренировками?", "options": [
+			{"text": "Меньше 6 месяцев", "weight": 1},
+			{"text": "1-2 Года", "weight": 2},
+			{"text": "3 и более лет", "weight": 3}
+		]},
+		{"text": "Какие веса используете в базовых упражнениях?", "options": [
+			{"text": "Только с собственным весом или легкими гантелями", "weight": 1},
+			{"text": "Средние веса (50-70% от собственного веса)", "weight": 2},
+			{"text": "Тяжелые веса (больше 100% собственного веса)", "weight": 3}
+		]}
+	]',
+	'[
+		{"level": "beginner", "min_score": 0},
+		{"level": "intermediate", "min_score": 5},
+		{"level": "advanced", "min_score": 8}
+	]',
+	TRUE
+);
+
+ALTER TABLE users ADD COLUMN quiz_version INT REFERENCES quizzes (version) ON DELETE RESTRICT;
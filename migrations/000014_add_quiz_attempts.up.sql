@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS quiz_attempts
+(
+	attempt_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id BIGINT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+	version INT NOT NULL REFERENCES quizzes (version) ON DELETE RESTRICT,
+	answers INT[] NOT NULL,
+	score INT NOT NULL,
+	lvl user_lvl NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS quiz_attempts_user_idx ON quiz_attempts (user_id, created_at);
+CREATE INDEX IF NOT EXISTS quiz_attempts_version_idx ON quiz_attempts (version);
+CREATE INDEX IF NOT EXISTS quiz_attempts_created_idx ON quiz_attempts (created_at);
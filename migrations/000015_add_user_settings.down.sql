@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS delivered_at;
+ALTER TABLE users DROP COLUMN IF EXISTS frequency;
+
+DROP TYPE IF EXISTS delivery_frequency;
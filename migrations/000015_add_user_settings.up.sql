@@ -0,0 +1,4 @@
+CREATE TYPE delivery_frequency AS ENUM ('all', 'daily', 'weekly');
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS frequency delivery_frequency NOT NULL DEFAULT 'all';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMPTZ;
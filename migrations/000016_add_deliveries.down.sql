@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS deliveries;
+
+DROP TYPE IF EXISTS delivery_status;
+
+ALTER TABLE users DROP COLUMN IF EXISTS window_end;
+ALTER TABLE users DROP COLUMN IF EXISTS window_start;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,19 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Europe/Moscow';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS window_start SMALLINT NOT NULL DEFAULT 9;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS window_end SMALLINT NOT NULL DEFAULT 21;
+
+CREATE TYPE delivery_status AS ENUM ('pending', 'sent', 'failed', 'expired');
+
+CREATE TABLE IF NOT EXISTS deliveries
+(
+	delivery_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id BIGINT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+	post_id INT NOT NULL REFERENCES posts (post_id) ON DELETE CASCADE,
+	status delivery_status NOT NULL DEFAULT 'pending',
+	error TEXT,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS deliveries_pending_idx ON deliveries (created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS deliveries_user_idx ON deliveries (user_id, post_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS schedules
+(
+	audience user_lvl PRIMARY KEY,
+	spec TEXT NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT TRUE,
+	updated_by TEXT,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
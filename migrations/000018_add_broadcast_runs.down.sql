@@ -0,0 +1,5 @@
+ALTER TABLE deliveries DROP COLUMN IF EXISTS run_id;
+
+DROP TABLE IF EXISTS broadcast_runs;
+
+DROP TYPE IF EXISTS broadcast_outcome;
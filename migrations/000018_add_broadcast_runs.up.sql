@@ -0,0 +1,19 @@
+CREATE TYPE broadcast_outcome AS ENUM ('running', 'sent', 'no_posts', 'no_subscribers', 'failed');
+
+CREATE TABLE IF NOT EXISTS broadcast_runs
+(
+	run_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	audience user_lvl NOT NULL,
+	post_id INT REFERENCES posts (post_id) ON DELETE SET NULL,
+	recipients INT NOT NULL DEFAULT 0,
+	outcome broadcast_outcome NOT NULL DEFAULT 'running',
+	error TEXT,
+	started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS broadcast_runs_audience_idx ON broadcast_runs (audience, started_at DESC);
+
+ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS run_id BIGINT REFERENCES broadcast_runs (run_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS deliveries_run_idx ON deliveries (run_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS autopilot_themes;
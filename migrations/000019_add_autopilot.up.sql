@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS autopilot_themes
+(
+	audience user_lvl PRIMARY KEY,
+	theme_index INT NOT NULL DEFAULT 0,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
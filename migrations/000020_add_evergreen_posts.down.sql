@@ -0,0 +1,6 @@
+ALTER TABLE broadcast_runs DROP COLUMN IF EXISTS evergreen;
+
+DROP INDEX IF EXISTS posts_evergreen_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS last_sent_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS evergreen;
@@ -0,0 +1,10 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS evergreen BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS last_sent_at TIMESTAMPTZ;
+
+UPDATE posts p SET last_sent_at = d.sent_at
+FROM (SELECT post_id, MAX(COALESCE(sent_at, created_at)) AS sent_at FROM deliveries GROUP BY post_id) d
+WHERE d.post_id = p.post_id;
+
+CREATE INDEX IF NOT EXISTS posts_evergreen_idx ON posts (audience, last_sent_at) WHERE evergreen;
+
+ALTER TABLE broadcast_runs ADD COLUMN IF NOT EXISTS evergreen BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+ALTER TABLE posts ALTER COLUMN scheduled_for TYPE TIMESTAMP;
+
+ALTER TABLE plan_jobs
+	ALTER COLUMN created_at TYPE TIMESTAMP,
+	ALTER COLUMN finished_at TYPE TIMESTAMP;
@@ -0,0 +1,6 @@
+-- stored values are read in session time zone, the same one NOW() used to write them
+ALTER TABLE plan_jobs
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+	ALTER COLUMN finished_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE posts ALTER COLUMN scheduled_for TYPE TIMESTAMPTZ;
@@ -3,52 +3,71 @@ package ai
 import (
 	"context"
 	"log"
-	"strings"
-
-	"github.com/google/generative-ai-go/genai"
-	"google.golang.org/api/option"
+	"net/http"
+	"time"
 )
 
-func MustNewClient(ctx context.Context, key string) *genai.Client {
-	client, err := genai.NewClient(ctx, option.WithAPIKey(key))
-	if err != nil {
-		log.Fatalf("failed to init genai client: %s", err)
-	}
-	return client
+type ContentGenerator interface {
+	GenerateContent(ctx context.Context, req Request) (Response, error)
+	// StreamContent calls onChunk with text as soon as provider produces it and returns the whole response.
+	// Stream stops when ctx is cancelled or onChunk returns error.
+	StreamContent(ctx context.Context, req Request, onChunk func(chunk string) error) (Response, error)
 }
 
-type contentGenerator struct {
-	model *genai.GenerativeModel
+type Request struct {
+	Prompt string
+	// System replaces default system prompt when not empty
+	System string
+	// JSON asks the model to respond with a single json object
+	JSON bool
 }
 
-type ContentGenerator interface {
-	GenerateContent(ctx context.Context, prompt string) (string, error)
+type Response struct {
+	Text  string
+	Usage Usage
 }
 
-func NewContentGenerator(client *genai.Client, modelName string, defaultPrompt string) ContentGenerator {
-	model := client.GenerativeModel(modelName)
-	model.SystemInstruction = genai.NewUserContent(genai.Text(defaultPrompt))
-	return &contentGenerator{
-		model: model,
-	}
+// Usage is token count reported by provider for single request
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
 }
 
-func (c *contentGenerator) GenerateContent(ctx context.Context, prompt string) (string, error) {
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
-	if err != nil {
-		return "", err
-	}
-	var sb strings.Builder
-	for _, part := range resp.Candidates {
-		if part.Content != nil {
-			for _, msgPart := range part.Content.Parts {
-				if text, ok := msgPart.(genai.Text); ok {
-					sb.WriteString(string(text))
-					sb.WriteByte('\n')
-				}
-			}
+type Provider string
+
+const (
+	ProviderGemini Provider = "gemini"
+	ProviderOpenAI Provider = "openai"
+	ProviderLocal  Provider = "local"
+)
+
+type Options struct {
+	Provider      Provider
+	Key           string
+	BaseURL       string
+	Model         string
+	DefaultPrompt string
+}
+
+func MustNew(ctx context.Context, opts Options) ContentGenerator {
+	switch opts.Provider {
+	case ProviderGemini:
+		if opts.Key == "" {
+			log.Fatalf("ai key is required for %s provider", opts.Provider)
+		}
+		client := MustNewClient(ctx, opts.Key)
+		return NewGeminiGenerator(client, opts.Model, opts.DefaultPrompt)
+	case ProviderOpenAI:
+		if opts.BaseURL == "" {
+			log.Fatalf("ai base url is required for %s provider", opts.Provider)
 		}
+		client := &http.Client{Timeout: 2 * time.Minute}
+		return NewOpenAIGenerator(client, opts.BaseURL, opts.Key, opts.Model, opts.DefaultPrompt)
+	case ProviderLocal:
+		return NewLocalGenerator()
+	default:
+		log.Fatalf("unknown ai provider: %s", opts.Provider)
+		return nil
 	}
-
-	return strings.TrimSpace(sb.String()), nil
 }
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrRateLimited means provider rejected request because of rate limit or quota
+	ErrRateLimited = errors.New("ai provider rate limited")
+	// ErrUnavailable means provider is down, timed out or circuit breaker is open
+	ErrUnavailable = errors.New("ai provider unavailable")
+)
+
+// StatusError is non successful http response of provider
+type StatusError struct {
+	Code    int
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.Code, e.Message)
+}
+
+// classify maps provider specific error to ErrRateLimited or ErrUnavailable, nil means error is not retryable
+func classify(err error) error {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return classifyHTTP(statusErr.Code)
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return classifyHTTP(apiErr.Code)
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted:
+			return ErrRateLimited
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Internal:
+			return ErrUnavailable
+		}
+		return nil
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrUnavailable
+	}
+	return nil
+}
+
+func classifyHTTP(code int) error {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code == http.StatusInternalServerError, code == http.StatusBadGateway,
+		code == http.StatusServiceUnavailable, code == http.StatusGatewayTimeout:
+		return ErrUnavailable
+	default:
+		return nil
+	}
+}
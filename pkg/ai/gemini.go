@@ -0,0 +1,113 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+func MustNewClient(ctx context.Context, key string) *genai.Client {
+	client, err := genai.NewClient(ctx, option.WithAPIKey(key))
+	if err != nil {
+		log.Fatalf("failed to init genai client: %s", err)
+	}
+	return client
+}
+
+type geminiGenerator struct {
+	model *genai.GenerativeModel
+}
+
+func NewGeminiGenerator(client *genai.Client, modelName string, defaultPrompt string) ContentGenerator {
+	model := client.GenerativeModel(modelName)
+	model.SystemInstruction = genai.NewUserContent(genai.Text(defaultPrompt))
+	return &geminiGenerator{
+		model: model,
+	}
+}
+
+func (c *geminiGenerator) GenerateContent(ctx context.Context, req Request) (Response, error) {
+	model := c.modelFor(req)
+	resp, err := model.GenerateContent(ctx, genai.Text(req.Prompt))
+	if err != nil {
+		return Response{}, err
+	}
+	var sb strings.Builder
+	for _, part := range resp.Candidates {
+		if part.Content != nil {
+			for _, msgPart := range part.Content.Parts {
+				if text, ok := msgPart.(genai.Text); ok {
+					sb.WriteString(string(text))
+					sb.WriteByte('\n')
+				}
+			}
+		}
+	}
+
+	return Response{Text: strings.TrimSpace(sb.String()), Usage: geminiUsage(resp.UsageMetadata)}, nil
+}
+
+func (c *geminiGenerator) StreamContent(ctx context.Context, req Request, onChunk func(chunk string) error) (Response, error) {
+	model := c.modelFor(req)
+	iter := model.GenerateContentStream(ctx, genai.Text(req.Prompt))
+
+	var out Response
+	var sb strings.Builder
+	for {
+		resp, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return Response{}, err
+		}
+		// every streamed response carries usage counted so far, the last one is the total
+		if resp.UsageMetadata != nil {
+			out.Usage = geminiUsage(resp.UsageMetadata)
+		}
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			continue
+		}
+		for _, part := range resp.Candidates[0].Content.Parts {
+			text, ok := part.(genai.Text)
+			if !ok || text == "" {
+				continue
+			}
+			sb.WriteString(string(text))
+			if err := onChunk(string(text)); err != nil {
+				return Response{}, err
+			}
+		}
+	}
+
+	out.Text = strings.TrimSpace(sb.String())
+	return out, nil
+}
+
+// modelFor copies configured model, so request options do not leak between concurrent requests
+func (c *geminiGenerator) modelFor(req Request) genai.GenerativeModel {
+	model := *c.model
+	if req.JSON {
+		model.ResponseMIMEType = "application/json"
+	}
+	if req.System != "" {
+		model.SystemInstruction = genai.NewUserContent(genai.Text(req.System))
+	}
+	return model
+}
+
+func geminiUsage(meta *genai.UsageMetadata) Usage {
+	if meta == nil {
+		return Usage{}
+	}
+	return Usage{
+		PromptTokens:     int(meta.PromptTokenCount),
+		CompletionTokens: int(meta.CandidatesTokenCount),
+		TotalTokens:      int(meta.TotalTokenCount),
+	}
+}
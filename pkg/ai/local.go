@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// localGenerator returns deterministic content without calling any model, used for offline development
+type localGenerator struct{}
+
+func NewLocalGenerator() ContentGenerator {
+	return &localGenerator{}
+}
+
+const (
+	localTitle = "Совет дня"
+	localBody  = "Регулярность важнее интенсивности: начните с небольшой нагрузки и увеличивайте её постепенно.\n" +
+		"Следите за техникой, сном и питанием — без восстановления нет прогресса."
+	localHashtag = "#fitflow"
+)
+
+func (g *localGenerator) GenerateContent(ctx context.Context, req Request) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+	text := fmt.Sprintf("💪 *%s*\n\n%s\n\n%s", strings.TrimSpace(req.Prompt), localBody, localHashtag)
+	if req.JSON {
+		out, err := json.Marshal(map[string]any{
+			"title":        localTitle,
+			"body":         localBody,
+			"hashtags":     []string{localHashtag},
+			"image_prompt": "athlete training in a bright gym",
+		})
+		if err != nil {
+			return Response{}, err
+		}
+		text = string(out)
+	}
+	return Response{Text: text, Usage: estimateUsage(req.System+req.Prompt, text)}, nil
+}
+
+// estimateUsage approximates token count as four characters per token, so quotas work in development
+func estimateUsage(prompt, completion string) Usage {
+	usage := Usage{
+		PromptTokens:     utf8.RuneCountInString(prompt) / 4,
+		CompletionTokens: utf8.RuneCountInString(completion) / 4,
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage
+}
+
+// StreamContent splits local content into words to imitate streaming provider
+func (g *localGenerator) StreamContent(ctx context.Context, req Request, onChunk func(chunk string) error) (Response, error) {
+	resp, err := g.GenerateContent(ctx, req)
+	if err != nil {
+		return Response{}, err
+	}
+	for _, word := range strings.SplitAfter(resp.Text, " ") {
+		if err := ctx.Err(); err != nil {
+			return Response{}, err
+		}
+		if err := onChunk(word); err != nil {
+			return Response{}, err
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// openAIGenerator works with any server implementing OpenAI chat completions api (vLLM, Ollama, LocalAI, etc.)
+type openAIGenerator struct {
+	client        *http.Client
+	url           string
+	key           string
+	model         string
+	defaultPrompt string
+}
+
+func NewOpenAIGenerator(client *http.Client, baseURL, key, model, defaultPrompt string) ContentGenerator {
+	return &openAIGenerator{
+		client:        client,
+		url:           strings.TrimSuffix(baseURL, "/") + "/chat/completions",
+		key:           key,
+		model:         model,
+		defaultPrompt: defaultPrompt,
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string          `json:"model"`
+	Messages       []chatMessage   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+func (u *chatUsage) toUsage() Usage {
+	if u == nil {
+		return Usage{}
+	}
+	return Usage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens, TotalTokens: u.TotalTokens}
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage"`
+}
+
+type chatChunk struct {
+	Choices []struct {
+		Delta chatMessage `json:"delta"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage"`
+}
+
+func (g *openAIGenerator) GenerateContent(ctx context.Context, req Request) (Response, error) {
+	resp, err := g.do(ctx, req, false)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	var out chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Response{}, fmt.Errorf("openai: failed to decode response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return Response{}, errors.New("openai: empty response")
+	}
+
+	var sb strings.Builder
+	for _, choice := range out.Choices {
+		sb.WriteString(choice.Message.Content)
+		sb.WriteByte('\n')
+	}
+	return Response{Text: strings.TrimSpace(sb.String()), Usage: out.Usage.toUsage()}, nil
+}
+
+// StreamContent reads server-sent events of chat completions api until [DONE] message
+func (g *openAIGenerator) StreamContent(ctx context.Context, req Request, onChunk func(chunk string) error) (Response, error) {
+	resp, err := g.do(ctx, req, true)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	var out Response
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			out.Text = strings.TrimSpace(sb.String())
+			return out, nil
+		}
+
+		var chunk chatChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return Response{}, fmt.Errorf("openai: failed to decode chunk: %w", err)
+		}
+		if chunk.Usage != nil {
+			out.Usage = chunk.Usage.toUsage()
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			sb.WriteString(choice.Delta.Content)
+			if err := onChunk(choice.Delta.Content); err != nil {
+				return Response{}, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Response{}, err
+	}
+	return Response{}, errors.New("openai: stream ended without [DONE]")
+}
+
+func (g *openAIGenerator) do(ctx context.Context, req Request, stream bool) (*http.Response, error) {
+	system := g.defaultPrompt
+	if req.System != "" {
+		system = req.System
+	}
+	in := chatRequest{
+		Model: g.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: system},
+			{Role: "user", Content: req.Prompt},
+		},
+	}
+	if req.JSON {
+		in.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+	if stream {
+		in.Stream = true
+		in.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if g.key != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+g.key)
+	}
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("openai: %w", &StatusError{Code: resp.StatusCode, Message: string(bytes.TrimSpace(msg))})
+	}
+	return resp, nil
+}
@@ -0,0 +1,210 @@
+package ai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIGenerator_GenerateContent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		key        string
+		json       bool
+		system     string
+		wantSystem string
+		status     int
+		response   string
+		want       ai.Response
+		wantErr    bool
+		wantHeader string
+	}{
+		{
+			name:       "success",
+			key:        "secret",
+			json:       true,
+			status:     http.StatusOK,
+			response:   `{"choices":[{"message":{"role":"assistant","content":"  generated post "}}],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`,
+			want:       ai.Response{Text: "generated post", Usage: ai.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}},
+			wantHeader: "Bearer secret",
+			wantSystem: "system prompt",
+		},
+		{
+			name:       "without key",
+			status:     http.StatusOK,
+			response:   `{"choices":[{"message":{"role":"assistant","content":"generated post"}}]}`,
+			want:       ai.Response{Text: "generated post"},
+			wantSystem: "system prompt",
+		},
+		{
+			name:       "system override",
+			system:     "custom prompt",
+			status:     http.StatusOK,
+			response:   `{"choices":[{"message":{"role":"assistant","content":"generated post"}}]}`,
+			want:       ai.Response{Text: "generated post"},
+			wantSystem: "custom prompt",
+		},
+		{
+			name:       "empty choices",
+			status:     http.StatusOK,
+			response:   `{"choices":[]}`,
+			wantErr:    true,
+			wantSystem: "system prompt",
+		},
+		{
+			name:       "server error",
+			status:     http.StatusServiceUnavailable,
+			response:   `{"error":"overloaded"}`,
+			wantErr:    true,
+			wantSystem: "system prompt",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+				assert.Equal(t, tc.wantHeader, r.Header.Get("Authorization"))
+
+				var body struct {
+					Model    string `json:"model"`
+					Messages []struct {
+						Role    string `json:"role"`
+						Content string `json:"content"`
+					} `json:"messages"`
+					ResponseFormat *struct {
+						Type string `json:"type"`
+					} `json:"response_format"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "test-model", body.Model)
+				require.Len(t, body.Messages, 2)
+				assert.Equal(t, "system", body.Messages[0].Role)
+				assert.Equal(t, tc.wantSystem, body.Messages[0].Content)
+				assert.Equal(t, "user", body.Messages[1].Role)
+				assert.Equal(t, "theme", body.Messages[1].Content)
+				if tc.json {
+					require.NotNil(t, body.ResponseFormat)
+					assert.Equal(t, "json_object", body.ResponseFormat.Type)
+				} else {
+					assert.Nil(t, body.ResponseFormat)
+				}
+
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			gen := ai.NewOpenAIGenerator(srv.Client(), srv.URL+"/v1/", tc.key, "test-model", "system prompt")
+			got, err := gen.GenerateContent(context.Background(), ai.Request{Prompt: "theme", System: tc.system, JSON: tc.json})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOpenAIGenerator_StreamContent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     int
+		events     []string
+		stopAfter  int
+		wantChunks []string
+		want       ai.Response
+		wantErr    bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			events: []string{
+				`{"choices":[{"delta":{"role":"assistant"}}]}`,
+				`{"choices":[{"delta":{"content":"generated "}}]}`,
+				`{"choices":[{"delta":{"content":"post"}}]}`,
+				`{"choices":[],"usage":{"prompt_tokens":10,"completion_tokens":2,"total_tokens":12}}`,
+				`[DONE]`,
+			},
+			wantChunks: []string{"generated ", "post"},
+			want:       ai.Response{Text: "generated post", Usage: ai.Usage{PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12}},
+		},
+		{
+			name:   "stopped by consumer",
+			status: http.StatusOK,
+			events: []string{
+				`{"choices":[{"delta":{"content":"generated "}}]}`,
+				`{"choices":[{"delta":{"content":"post"}}]}`,
+				`[DONE]`,
+			},
+			stopAfter:  1,
+			wantChunks: []string{"generated "},
+			wantErr:    true,
+		},
+		{
+			name:   "stream without done",
+			status: http.StatusOK,
+			events: []string{
+				`{"choices":[{"delta":{"content":"generated "}}]}`,
+			},
+			wantChunks: []string{"generated "},
+			wantErr:    true,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusTooManyRequests,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Stream        bool `json:"stream"`
+					StreamOptions struct {
+						IncludeUsage bool `json:"include_usage"`
+					} `json:"stream_options"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.True(t, body.Stream)
+				assert.True(t, body.StreamOptions.IncludeUsage)
+
+				if tc.status != http.StatusOK {
+					w.WriteHeader(tc.status)
+					return
+				}
+				w.Header().Set("Content-Type", "text/event-stream")
+				for _, event := range tc.events {
+					w.Write([]byte("data: " + event + "\n\n"))
+				}
+			}))
+			defer srv.Close()
+
+			gen := ai.NewOpenAIGenerator(srv.Client(), srv.URL, "", "test-model", "system prompt")
+			var chunks []string
+			got, err := gen.StreamContent(context.Background(), ai.Request{Prompt: "theme"}, func(chunk string) error {
+				chunks = append(chunks, chunk)
+				if tc.stopAfter > 0 && len(chunks) == tc.stopAfter {
+					return context.Canceled
+				}
+				return nil
+			})
+			assert.Equal(t, tc.wantChunks, chunks)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
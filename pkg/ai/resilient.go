@@ -0,0 +1,188 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+type ResilienceOptions struct {
+	// Timeout limits single attempt, stream included
+	Timeout time.Duration
+	// Retries is number of additional attempts on rate limit and provider errors
+	Retries   int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// FailureThreshold consecutive provider failures open the circuit for OpenTimeout
+	FailureThreshold int
+	OpenTimeout      time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	// circuitHalfOpen lets single probe request through after OpenTimeout
+	circuitHalfOpen
+)
+
+var circuitStates = map[circuitState]string{
+	circuitClosed:   "closed",
+	circuitOpen:     "open",
+	circuitHalfOpen: "half-open",
+}
+
+// resilientGenerator retries rate limited and failed calls with exponential backoff
+// and fails fast while provider is down
+type resilientGenerator struct {
+	next   ContentGenerator
+	opts   ResilienceOptions
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+func NewResilientGenerator(logger *slog.Logger, next ContentGenerator, opts ResilienceOptions) ContentGenerator {
+	return &resilientGenerator{next: next, opts: opts, logger: logger, now: time.Now}
+}
+
+func (g *resilientGenerator) GenerateContent(ctx context.Context, req Request) (Response, error) {
+	return g.call(ctx, func(ctx context.Context) (Response, bool, error) {
+		resp, err := g.next.GenerateContent(ctx, req)
+		return resp, false, err
+	})
+}
+
+// StreamContent is retried only until the first chunk, sent text can not be taken back
+func (g *resilientGenerator) StreamContent(ctx context.Context, req Request, onChunk func(chunk string) error) (Response, error) {
+	return g.call(ctx, func(ctx context.Context) (Response, bool, error) {
+		started := false
+		resp, err := g.next.StreamContent(ctx, req, func(chunk string) error {
+			started = true
+			return onChunk(chunk)
+		})
+		return resp, started, err
+	})
+}
+
+func (g *resilientGenerator) call(ctx context.Context, attempt func(ctx context.Context) (Response, bool, error)) (Response, error) {
+	for i := 0; ; i++ {
+		if !g.allow() {
+			return Response{}, fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+		}
+
+		attemptCtx, cancel := g.withTimeout(ctx)
+		resp, started, err := attempt(attemptCtx)
+		cancel()
+		if err == nil {
+			g.success()
+			return resp, nil
+		}
+		// caller gave up, provider is not to blame
+		if ctx.Err() != nil {
+			g.release()
+			return Response{}, ctx.Err()
+		}
+
+		kind := classify(err)
+		if kind == ErrUnavailable {
+			g.failure()
+		} else {
+			g.release()
+		}
+		if kind == nil {
+			return Response{}, err
+		}
+		if started || i >= g.opts.Retries {
+			return Response{}, fmt.Errorf("%w: %w", kind, err)
+		}
+
+		delay := g.backoff(i)
+		g.logger.Warn("ai call failed, retrying", "error", err, "attempt", i+1, "delay", delay)
+		select {
+		case <-ctx.Done():
+			return Response{}, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (g *resilientGenerator) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.opts.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, g.opts.Timeout)
+}
+
+// backoff doubles delay on every attempt and adds up to 50% jitter
+func (g *resilientGenerator) backoff(attempt int) time.Duration {
+	delay := g.opts.BaseDelay << attempt
+	if delay <= 0 || (g.opts.MaxDelay > 0 && delay > g.opts.MaxDelay) {
+		delay = g.opts.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay + rand.N(delay/2+1)
+}
+
+func (g *resilientGenerator) allow() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.state {
+	case circuitOpen:
+		if g.now().Sub(g.openedAt) < g.opts.OpenTimeout {
+			return false
+		}
+		g.setState(circuitHalfOpen)
+		return true
+	case circuitHalfOpen:
+		// probe is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+func (g *resilientGenerator) success() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures = 0
+	g.setState(circuitClosed)
+}
+
+func (g *resilientGenerator) failure() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures++
+	if g.state == circuitHalfOpen || (g.opts.FailureThreshold > 0 && g.failures >= g.opts.FailureThreshold) {
+		g.openedAt = g.now()
+		g.setState(circuitOpen)
+	}
+}
+
+// release finishes half-open probe which did not prove provider is down
+func (g *resilientGenerator) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.state == circuitHalfOpen {
+		g.setState(circuitClosed)
+	}
+}
+
+func (g *resilientGenerator) setState(state circuitState) {
+	if g.state == state {
+		return
+	}
+	g.logger.Info("ai circuit breaker state changed", "from", circuitStates[g.state], "to", circuitStates[state], "failures", g.failures)
+	g.state = state
+}
@@ -0,0 +1,185 @@
+package ai_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/ai"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedGenerator returns errors from script one by one, then succeeds
+type scriptedGenerator struct {
+	script []error
+	calls  int
+	delay  time.Duration
+	chunks []string
+}
+
+func (g *scriptedGenerator) next(ctx context.Context) error {
+	g.calls++
+	if g.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(g.delay):
+		}
+	}
+	if len(g.script) == 0 {
+		return nil
+	}
+	err := g.script[0]
+	g.script = g.script[1:]
+	return err
+}
+
+func (g *scriptedGenerator) GenerateContent(ctx context.Context, req ai.Request) (ai.Response, error) {
+	if err := g.next(ctx); err != nil {
+		return ai.Response{}, err
+	}
+	return ai.Response{Text: "ok"}, nil
+}
+
+func (g *scriptedGenerator) StreamContent(ctx context.Context, req ai.Request, onChunk func(chunk string) error) (ai.Response, error) {
+	for _, chunk := range g.chunks {
+		onChunk(chunk)
+	}
+	if err := g.next(ctx); err != nil {
+		return ai.Response{}, err
+	}
+	return ai.Response{Text: "ok"}, nil
+}
+
+var (
+	errRateLimited = &ai.StatusError{Code: http.StatusTooManyRequests, Message: "slow down"}
+	errUnavailable = &ai.StatusError{Code: http.StatusServiceUnavailable, Message: "overloaded"}
+	errBadRequest  = &ai.StatusError{Code: http.StatusBadRequest, Message: "bad request"}
+)
+
+func TestResilientGenerator_GenerateContent(t *testing.T) {
+	opts := ai.ResilienceOptions{Timeout: 50 * time.Millisecond, Retries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	testCases := []struct {
+		name      string
+		gen       *scriptedGenerator
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			gen:       &scriptedGenerator{},
+			wantCalls: 1,
+		},
+		{
+			name:      "retry on rate limit",
+			gen:       &scriptedGenerator{script: []error{errRateLimited, errUnavailable}},
+			wantCalls: 3,
+		},
+		{
+			name:      "retries exhausted",
+			gen:       &scriptedGenerator{script: []error{errRateLimited, errRateLimited, errRateLimited}},
+			wantErr:   ai.ErrRateLimited,
+			wantCalls: 3,
+		},
+		{
+			name:      "not retryable",
+			gen:       &scriptedGenerator{script: []error{errBadRequest}},
+			wantErr:   errBadRequest,
+			wantCalls: 1,
+		},
+		{
+			name:      "timeout",
+			gen:       &scriptedGenerator{delay: time.Second},
+			wantErr:   ai.ErrUnavailable,
+			wantCalls: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := ai.NewResilientGenerator(testutils.NewTestLogger(), tc.gen, opts)
+			got, err := gen.GenerateContent(context.Background(), ai.Request{Prompt: "prompt"})
+			assert.Equal(t, tc.wantCalls, tc.gen.calls)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "ok", got.Text)
+		})
+	}
+}
+
+func TestResilientGenerator_CircuitBreaker(t *testing.T) {
+	inner := &scriptedGenerator{script: []error{errUnavailable, errUnavailable, errUnavailable}}
+	gen := ai.NewResilientGenerator(testutils.NewTestLogger(), inner, ai.ResilienceOptions{
+		FailureThreshold: 2,
+		OpenTimeout:      30 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := gen.GenerateContent(ctx, ai.Request{})
+		assert.ErrorIs(t, err, ai.ErrUnavailable)
+	}
+	assert.Equal(t, 2, inner.calls)
+
+	// open circuit fails fast without calling provider
+	_, err := gen.GenerateContent(ctx, ai.Request{})
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.Equal(t, 2, inner.calls)
+
+	// failed probe opens circuit again
+	time.Sleep(40 * time.Millisecond)
+	_, err = gen.GenerateContent(ctx, ai.Request{})
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	_, err = gen.GenerateContent(ctx, ai.Request{})
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.Equal(t, 3, inner.calls)
+
+	// successful probe closes circuit
+	time.Sleep(40 * time.Millisecond)
+	_, err = gen.GenerateContent(ctx, ai.Request{})
+	assert.NoError(t, err)
+	_, err = gen.GenerateContent(ctx, ai.Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, inner.calls)
+}
+
+func TestResilientGenerator_StreamContent(t *testing.T) {
+	opts := ai.ResilienceOptions{Retries: 2, BaseDelay: time.Millisecond}
+
+	t.Run("retry before first chunk", func(t *testing.T) {
+		inner := &scriptedGenerator{script: []error{errUnavailable}}
+		gen := ai.NewResilientGenerator(testutils.NewTestLogger(), inner, opts)
+		_, err := gen.StreamContent(context.Background(), ai.Request{}, func(chunk string) error { return nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 2, inner.calls)
+	})
+
+	t.Run("no retry after first chunk", func(t *testing.T) {
+		inner := &scriptedGenerator{script: []error{errUnavailable}, chunks: []string{"hello"}}
+		gen := ai.NewResilientGenerator(testutils.NewTestLogger(), inner, opts)
+		var got []string
+		_, err := gen.StreamContent(context.Background(), ai.Request{}, func(chunk string) error {
+			got = append(got, chunk)
+			return nil
+		})
+		assert.ErrorIs(t, err, ai.ErrUnavailable)
+		assert.Equal(t, 1, inner.calls)
+		assert.Equal(t, []string{"hello"}, got)
+	})
+
+	t.Run("cancelled by caller", func(t *testing.T) {
+		inner := &scriptedGenerator{delay: time.Second}
+		gen := ai.NewResilientGenerator(testutils.NewTestLogger(), inner, opts)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := gen.StreamContent(ctx, ai.Request{}, func(chunk string) error { return nil })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, inner.calls)
+	})
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -31,3 +32,8 @@ func VerifyJWT(tokenString string, secret []byte) (string, error) {
 }
 
 type AdminLoginKey struct{}
+
+func AdminLogin(ctx context.Context) string {
+	login, _ := ctx.Value(AdminLoginKey{}).(string)
+	return login
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type item[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// TTL is in-memory cache, expired items are removed on access and periodically on Set.
+// Cache with zero ttl is disabled and stores nothing.
+type TTL[K comparable, V any] struct {
+	mu        sync.Mutex
+	items     map[K]item[V]
+	ttl       time.Duration
+	lastPurge time.Time
+}
+
+func NewTTL[K comparable, V any](ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{items: make(map[K]item[V]), ttl: ttl, lastPurge: time.Now()}
+}
+
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	if c.ttl <= 0 {
+		var zero V
+		return zero, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if time.Now().After(it.expiresAt) {
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+	return it.value, true
+}
+
+func (c *TTL[K, V]) Set(key K, value V) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastPurge) > c.ttl {
+		for k, it := range c.items {
+			if now.After(it.expiresAt) {
+				delete(c.items, k)
+			}
+		}
+		c.lastPurge = now
+	}
+	c.items[key] = item[V]{value: value, expiresAt: now.Add(c.ttl)}
+}
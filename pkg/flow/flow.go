@@ -0,0 +1,440 @@
+package flow
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+	tele "gopkg.in/telebot.v4"
+)
+
+// Session is progress of user in a flow, it is kept in state between messages
+type Session struct {
+	// ID tells sessions apart, so buttons of previous sessions are ignored
+	ID   string `json:"id"`
+	Flow string `json:"flow"`
+	Step string `json:"step"`
+	// History is visited steps, the last one is returned to on back
+	History []string `json:"history"`
+	// Data holds validated answers by step name
+	Data      map[string]string `json:"data"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func init() {
+	state.Register("flow", &Session{})
+}
+
+type Prompt struct {
+	Text string
+	// Options are rows of buttons, in reply flows user can still type an answer
+	Options [][]string
+}
+
+type Step struct {
+	Name string
+	// Prompt is called with empty session on registration, so texts of its options are checked to be unique
+	Prompt func(s *Session) Prompt
+	// Validate converts answer to stored value, answer is rejected when ok is false
+	Validate func(s *Session, answer string) (value string, ok bool)
+	// Invalid is sent when answer is rejected
+	Invalid string
+	// Next returns name of the next step, empty name finishes the flow.
+	// Without Next flow goes to the following declared step.
+	Next func(s *Session) string
+}
+
+type Flow struct {
+	Name  string
+	Steps []Step
+	// Timeout drops session when user did not answer for this long, zero means no timeout
+	Timeout time.Duration
+	// Inline flows ask with inline buttons and edit the question message in place
+	Inline bool
+	// Progress prepends step number to questions, branches are counted by declared steps
+	Progress bool
+	OnFinish func(c tele.Context, s *Session) error
+}
+
+func (f *Flow) step(name string) (Step, int, bool) {
+	for i, step := range f.Steps {
+		if step.Name == name {
+			return step, i, true
+		}
+	}
+	return Step{}, 0, false
+}
+
+// validate checks declaration of flow, targets of Next are known only at runtime and are checked on answer
+func (f *Flow) validate() error {
+	if len(f.Steps) == 0 {
+		return fmt.Errorf("flow: %s has no steps", f.Name)
+	}
+	if f.OnFinish == nil {
+		return fmt.Errorf("flow: %s has no OnFinish", f.Name)
+	}
+	names := make(map[string]struct{}, len(f.Steps))
+	for _, step := range f.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("flow: %s has step without name", f.Name)
+		}
+		if _, ok := names[step.Name]; ok {
+			return fmt.Errorf("flow: %s has duplicate step %s", f.Name, step.Name)
+		}
+		names[step.Name] = struct{}{}
+		if step.Prompt == nil || step.Validate == nil {
+			return fmt.Errorf("flow: step %s of %s needs Prompt and Validate", step.Name, f.Name)
+		}
+		// answers are matched to options by text, so options with the same text can not be told apart
+		options := make(map[string]struct{})
+		for _, option := range flatten(step.Prompt(&Session{Data: make(map[string]string)}).Options) {
+			if _, ok := options[option]; ok {
+				return fmt.Errorf("flow: step %s of %s has duplicate option %s", step.Name, f.Name, option)
+			}
+			options[option] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (f *Flow) next(step Step, idx int, s *Session) string {
+	if step.Next != nil {
+		return step.Next(s)
+	}
+	if idx+1 < len(f.Steps) {
+		return f.Steps[idx+1].Name
+	}
+	return ""
+}
+
+type Options struct {
+	BackText   string
+	CancelText string
+	// Cancelled and Expired are sent with Keyboard when session ends without finishing
+	Cancelled string
+	Expired   string
+	Keyboard  *tele.ReplyMarkup
+	// Stale answers buttons of finished or replaced sessions
+	Stale string
+	// Load builds flow which is not registered, e.g. flow defined in database.
+	// Loaded flow is kept, so it must not change while it has sessions.
+	Load func(name string) (Flow, error)
+}
+
+// Callback is endpoint of inline flow buttons, it is handled with HandleCallback
+var Callback = &tele.Btn{Unique: "flow"}
+
+const (
+	answerBack   = "back"
+	answerCancel = "cancel"
+)
+
+// Engine drives declared flows, sessions are stored in state by user id
+type Engine struct {
+	logger *slog.Logger
+	state  state.State
+	opts   Options
+
+	mu    sync.RWMutex
+	flows map[string]*Flow
+}
+
+func NewEngine(logger *slog.Logger, state state.State, opts Options) *Engine {
+	return &Engine{logger: logger, state: state, opts: opts, flows: make(map[string]*Flow)}
+}
+
+func (e *Engine) Register(f Flow) {
+	if err := f.validate(); err != nil {
+		panic(err.Error())
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.flows[f.Name]; ok {
+		panic(fmt.Sprintf("flow: %s is already registered", f.Name))
+	}
+	e.flows[f.Name] = &f
+}
+
+func (e *Engine) flow(name string) (*Flow, error) {
+	e.mu.RLock()
+	f, ok := e.flows[name]
+	e.mu.RUnlock()
+	if ok {
+		return f, nil
+	}
+	if e.opts.Load == nil {
+		return nil, fmt.Errorf("flow: unknown flow %s", name)
+	}
+
+	loaded, err := e.opts.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("flow: failed to load %s: %w", name, err)
+	}
+	if err := loaded.validate(); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if f, ok := e.flows[name]; ok {
+		return f, nil
+	}
+	e.flows[name] = &loaded
+	return &loaded, nil
+}
+
+// Start begins flow from the first step, active session of user is replaced
+func (e *Engine) Start(c tele.Context, name string) error {
+	f, err := e.flow(name)
+	if err != nil {
+		return err
+	}
+
+	s := &Session{ID: newID(), Flow: name, Step: f.Steps[0].Name, Data: make(map[string]string)}
+	if err := e.save(c, s); err != nil {
+		return err
+	}
+	return e.ask(c, f, s)
+}
+
+// Handle processes text answer to the current step of session
+func (e *Engine) Handle(c tele.Context, s *Session) error {
+	f, step, idx, err := e.resolve(c, s)
+	if err != nil {
+		return err
+	}
+
+	if e.expired(f, s) {
+		e.finish(c)
+		return c.Send(e.opts.Expired, e.opts.Keyboard)
+	}
+	// inline flows are answered only with buttons
+	if f.Inline {
+		return c.Send(step.Invalid)
+	}
+
+	answer := strings.TrimSpace(c.Text())
+	switch answer {
+	case e.opts.CancelText:
+		e.finish(c)
+		return c.Send(e.opts.Cancelled, e.opts.Keyboard)
+	case e.opts.BackText:
+		return e.back(c, f, s)
+	}
+	return e.answer(c, f, s, step, idx, answer)
+}
+
+// HandleCallback processes inline button press. Buttons carry session id and step position,
+// presses of buttons which are not for the current question are ignored.
+func (e *Engine) HandleCallback(c tele.Context) error {
+	parts := strings.Split(c.Callback().Data, "|")
+	if len(parts) != 3 {
+		return c.Respond(&tele.CallbackResponse{Text: e.opts.Stale})
+	}
+
+	value, err := e.state.Get(context.TODO(), c.Sender().ID)
+	if err != nil {
+		c.Respond()
+		return fmt.Errorf("failed to get flow session: %w", err)
+	}
+	s, ok := value.(*Session)
+	if !ok || s.ID != parts[0] || strconv.Itoa(len(s.History)) != parts[1] {
+		return c.Respond(&tele.CallbackResponse{Text: e.opts.Stale})
+	}
+	c.Respond()
+
+	f, step, idx, err := e.resolve(c, s)
+	if err != nil {
+		return err
+	}
+	if e.expired(f, s) {
+		e.finish(c)
+		return c.Edit(e.opts.Expired)
+	}
+
+	switch parts[2] {
+	case answerCancel:
+		e.finish(c)
+		return c.Edit(e.opts.Cancelled)
+	case answerBack:
+		return e.back(c, f, s)
+	}
+
+	options := flatten(step.Prompt(s).Options)
+	i, err := strconv.Atoi(parts[2])
+	if err != nil || i < 0 || i >= len(options) {
+		return c.Send(step.Invalid)
+	}
+	return e.answer(c, f, s, step, idx, options[i])
+}
+
+func (e *Engine) resolve(c tele.Context, s *Session) (*Flow, Step, int, error) {
+	f, err := e.flow(s.Flow)
+	if err != nil {
+		e.finish(c)
+		return nil, Step{}, 0, err
+	}
+	step, idx, ok := f.step(s.Step)
+	if !ok {
+		e.finish(c)
+		return nil, Step{}, 0, fmt.Errorf("flow: unknown step %s of %s", s.Step, s.Flow)
+	}
+	return f, step, idx, nil
+}
+
+func (e *Engine) answer(c tele.Context, f *Flow, s *Session, step Step, idx int, answer string) error {
+	value, ok := step.Validate(s, answer)
+	if !ok {
+		return c.Send(step.Invalid)
+	}
+	s.Data[step.Name] = value
+
+	next := f.next(step, idx, s)
+	if next == "" {
+		e.finish(c)
+		if f.Inline {
+			// result is sent by OnFinish, question is not needed anymore
+			c.Delete()
+		}
+		return f.OnFinish(c, s)
+	}
+	if _, _, ok := f.step(next); !ok {
+		e.finish(c)
+		return fmt.Errorf("flow: step %s of %s goes to unknown step %s", step.Name, f.Name, next)
+	}
+
+	s.History = append(s.History, s.Step)
+	s.Step = next
+	if err := e.save(c, s); err != nil {
+		return err
+	}
+	return e.ask(c, f, s)
+}
+
+// back returns to previous step and forgets its answer
+func (e *Engine) back(c tele.Context, f *Flow, s *Session) error {
+	if n := len(s.History); n > 0 {
+		s.Step = s.History[n-1]
+		s.History = s.History[:n-1]
+		delete(s.Data, s.Step)
+		if err := e.save(c, s); err != nil {
+			return err
+		}
+	}
+	return e.ask(c, f, s)
+}
+
+func (e *Engine) ask(c tele.Context, f *Flow, s *Session) error {
+	step, _, ok := f.step(s.Step)
+	if !ok {
+		e.finish(c)
+		return fmt.Errorf("flow: unknown step %s of %s", s.Step, f.Name)
+	}
+	prompt := step.Prompt(s)
+
+	text := prompt.Text
+	if f.Progress {
+		text = fmt.Sprintf("%d/%d\n\n%s", len(s.History)+1, len(f.Steps), text)
+	}
+
+	if f.Inline {
+		markup := e.inlineMarkup(s, prompt)
+		// question of inline flow is edited in place after the first one
+		if c.Callback() != nil {
+			return c.Edit(text, markup)
+		}
+		return c.Send(text, markup)
+	}
+	return c.Send(text, e.replyMarkup(s, prompt))
+}
+
+func (e *Engine) replyMarkup(s *Session, prompt Prompt) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{ResizeKeyboard: true}
+	rows := make([]tele.Row, 0, len(prompt.Options)+1)
+	for _, options := range prompt.Options {
+		row := make(tele.Row, 0, len(options))
+		for _, option := range options {
+			row = append(row, markup.Text(option))
+		}
+		rows = append(rows, row)
+	}
+
+	controls := tele.Row{}
+	if len(s.History) > 0 {
+		controls = append(controls, markup.Text(e.opts.BackText))
+	}
+	controls = append(controls, markup.Text(e.opts.CancelText))
+	rows = append(rows, controls)
+
+	markup.Reply(rows...)
+	return markup
+}
+
+// inlineMarkup buttons carry option index, so answer does not depend on button text
+func (e *Engine) inlineMarkup(s *Session, prompt Prompt) *tele.ReplyMarkup {
+	markup := &tele.ReplyMarkup{}
+	pos := strconv.Itoa(len(s.History))
+	button := func(text, answer string) tele.Btn {
+		return markup.Data(text, Callback.Unique, s.ID, pos, answer)
+	}
+
+	rows := make([]tele.Row, 0, len(prompt.Options)+1)
+	i := 0
+	for _, options := range prompt.Options {
+		row := make(tele.Row, 0, len(options))
+		for _, option := range options {
+			row = append(row, button(option, strconv.Itoa(i)))
+			i++
+		}
+		rows = append(rows, row)
+	}
+
+	controls := tele.Row{}
+	if len(s.History) > 0 {
+		controls = append(controls, button(e.opts.BackText, answerBack))
+	}
+	controls = append(controls, button(e.opts.CancelText, answerCancel))
+	rows = append(rows, controls)
+
+	markup.Inline(rows...)
+	return markup
+}
+
+func (e *Engine) expired(f *Flow, s *Session) bool {
+	return f.Timeout > 0 && time.Since(s.UpdatedAt) > f.Timeout
+}
+
+func (e *Engine) save(c tele.Context, s *Session) error {
+	s.UpdatedAt = time.Now()
+	if err := e.state.Set(context.TODO(), c.Sender().ID, s); err != nil {
+		return fmt.Errorf("failed to save flow session: %w", err)
+	}
+	return nil
+}
+
+func (e *Engine) finish(c tele.Context) {
+	if err := e.state.Delete(context.TODO(), c.Sender().ID); err != nil {
+		e.logger.Error("failed to delete flow session", "user_id", c.Sender().ID, "error", err)
+	}
+}
+
+func flatten(rows [][]string) []string {
+	var res []string
+	for _, row := range rows {
+		res = append(res, row...)
+	}
+	return res
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,415 @@
+package flow_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/flow"
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tele "gopkg.in/telebot.v4"
+)
+
+const userID = 1
+
+// fakeContext records what engine sends, methods which are not used by engine are not implemented
+type fakeContext struct {
+	tele.Context
+	text      string
+	callback  *tele.Callback
+	sent      []string
+	responses []string
+}
+
+func (c *fakeContext) Sender() *tele.User       { return &tele.User{ID: userID} }
+func (c *fakeContext) Text() string             { return c.text }
+func (c *fakeContext) Callback() *tele.Callback { return c.callback }
+func (c *fakeContext) Delete() error            { return nil }
+
+func (c *fakeContext) Send(what any, opts ...any) error {
+	c.sent = append(c.sent, fmt.Sprint(what))
+	return nil
+}
+
+func (c *fakeContext) Edit(what any, opts ...any) error {
+	c.sent = append(c.sent, fmt.Sprint(what))
+	return nil
+}
+
+func (c *fakeContext) Respond(resp ...*tele.CallbackResponse) error {
+	for _, r := range resp {
+		c.responses = append(c.responses, r.Text)
+	}
+	return nil
+}
+
+func (c *fakeContext) last() string {
+	if len(c.sent) == 0 {
+		return ""
+	}
+	return c.sent[len(c.sent)-1]
+}
+
+var opts = flow.Options{
+	BackText:   "Назад",
+	CancelText: "Отмена",
+	Cancelled:  "cancelled",
+	Expired:    "expired",
+	Stale:      "stale",
+}
+
+func textStep(name string) flow.Step {
+	return flow.Step{
+		Name:   name,
+		Prompt: func(s *flow.Session) flow.Prompt { return flow.Prompt{Text: "ask " + name} },
+		Validate: func(s *flow.Session, answer string) (string, bool) {
+			return answer, answer != "bad"
+		},
+		Invalid: "invalid " + name,
+	}
+}
+
+// survey asks goal, experience for "strength" goal only, then days
+func survey(finished *map[string]string) flow.Flow {
+	goal := textStep("goal")
+	goal.Next = func(s *flow.Session) string {
+		if s.Data["goal"] == "strength" {
+			return "experience"
+		}
+		return "days"
+	}
+	return flow.Flow{
+		Name:  "survey",
+		Steps: []flow.Step{goal, textStep("experience"), textStep("days")},
+		OnFinish: func(c tele.Context, s *flow.Session) error {
+			*finished = s.Data
+			return c.Send("done")
+		},
+	}
+}
+
+func newEngine(t *testing.T, flows ...flow.Flow) (*flow.Engine, state.State) {
+	t.Helper()
+	st := state.NewMemory(0)
+	engine := flow.NewEngine(testutils.NewTestLogger(), st, opts)
+	for _, f := range flows {
+		engine.Register(f)
+	}
+	return engine, st
+}
+
+func session(t *testing.T, st state.State) *flow.Session {
+	t.Helper()
+	value, err := st.Get(context.Background(), userID)
+	require.NoError(t, err)
+	if value == nil {
+		return nil
+	}
+	return value.(*flow.Session)
+}
+
+func TestEngine_Handle(t *testing.T) {
+	testCases := []struct {
+		name         string
+		answers      []string
+		wantLast     string
+		wantStep     string
+		wantFinished map[string]string
+	}{
+		{
+			name:         "branch with extra step",
+			answers:      []string{"strength", "2 years", "3"},
+			wantLast:     "done",
+			wantFinished: map[string]string{"goal": "strength", "experience": "2 years", "days": "3"},
+		},
+		{
+			name:         "branch skips step",
+			answers:      []string{"health", "5"},
+			wantLast:     "done",
+			wantFinished: map[string]string{"goal": "health", "days": "5"},
+		},
+		{
+			name:     "next step",
+			answers:  []string{"strength"},
+			wantLast: "ask experience",
+			wantStep: "experience",
+		},
+		{
+			name:     "back returns to branching step",
+			answers:  []string{"health", "Назад"},
+			wantLast: "ask goal",
+			wantStep: "goal",
+		},
+		{
+			name:     "back on first step",
+			answers:  []string{"Назад"},
+			wantLast: "ask goal",
+			wantStep: "goal",
+		},
+		{
+			name:     "invalid answer",
+			answers:  []string{"bad"},
+			wantLast: "invalid goal",
+			wantStep: "goal",
+		},
+		{
+			name:     "cancel",
+			answers:  []string{"strength", "Отмена"},
+			wantLast: "cancelled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var finished map[string]string
+			engine, st := newEngine(t, survey(&finished))
+
+			c := &fakeContext{}
+			require.NoError(t, engine.Start(c, "survey"))
+			for _, answer := range tc.answers {
+				c.text = answer
+				require.NoError(t, engine.Handle(c, session(t, st)))
+			}
+
+			assert.Equal(t, tc.wantLast, c.last())
+			assert.Equal(t, tc.wantFinished, finished)
+			s := session(t, st)
+			if tc.wantStep == "" {
+				assert.Nil(t, s)
+				return
+			}
+			require.NotNil(t, s)
+			assert.Equal(t, tc.wantStep, s.Step)
+		})
+	}
+}
+
+func TestEngine_BackForgetsAnswer(t *testing.T) {
+	var finished map[string]string
+	engine, st := newEngine(t, survey(&finished))
+
+	c := &fakeContext{}
+	require.NoError(t, engine.Start(c, "survey"))
+	for _, answer := range []string{"strength", "Назад", "health", "4"} {
+		c.text = answer
+		require.NoError(t, engine.Handle(c, session(t, st)))
+	}
+	assert.Equal(t, map[string]string{"goal": "health", "days": "4"}, finished)
+}
+
+func TestEngine_Timeout(t *testing.T) {
+	f := survey(new(map[string]string))
+	f.Timeout = time.Minute
+	engine, st := newEngine(t, f)
+
+	c := &fakeContext{}
+	require.NoError(t, engine.Start(c, "survey"))
+	s := session(t, st)
+	s.UpdatedAt = time.Now().Add(-2 * time.Minute)
+
+	c.text = "strength"
+	require.NoError(t, engine.Handle(c, s))
+	assert.Equal(t, "expired", c.last())
+	assert.Nil(t, session(t, st))
+}
+
+func TestEngine_UnknownNextStep(t *testing.T) {
+	f := survey(new(map[string]string))
+	f.Steps[0].Next = func(s *flow.Session) string { return "missing" }
+	engine, st := newEngine(t, f)
+
+	c := &fakeContext{}
+	require.NoError(t, engine.Start(c, "survey"))
+	c.text = "strength"
+	assert.Error(t, engine.Handle(c, session(t, st)))
+	assert.Nil(t, session(t, st))
+}
+
+func TestEngine_HandleCallback(t *testing.T) {
+	choice := func(name string, options ...string) flow.Step {
+		step := textStep(name)
+		step.Prompt = func(s *flow.Session) flow.Prompt {
+			return flow.Prompt{Text: "ask " + name, Options: [][]string{options}}
+		}
+		return step
+	}
+	var finished map[string]string
+	quiz := flow.Flow{
+		Name:   "quiz",
+		Inline: true,
+		Steps:  []flow.Step{choice("first", "a", "b"), choice("second", "c", "d")},
+		OnFinish: func(c tele.Context, s *flow.Session) error {
+			finished = s.Data
+			return c.Send("done")
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		data          func(s *flow.Session) string
+		wantResponses []string
+		wantLast      string
+		wantStep      string
+	}{
+		{
+			name:     "answer",
+			data:     func(s *flow.Session) string { return s.ID + "|0|1" },
+			wantLast: "ask second",
+			wantStep: "second",
+		},
+		{
+			name:          "button of previous session",
+			data:          func(s *flow.Session) string { return "old|0|1" },
+			wantResponses: []string{"stale"},
+			wantStep:      "first",
+		},
+		{
+			name:          "button of previous question",
+			data:          func(s *flow.Session) string { return s.ID + "|1|0" },
+			wantResponses: []string{"stale"},
+			wantStep:      "first",
+		},
+		{
+			name:          "malformed data",
+			data:          func(s *flow.Session) string { return "broken" },
+			wantResponses: []string{"stale"},
+			wantStep:      "first",
+		},
+		{
+			name:     "unknown option",
+			data:     func(s *flow.Session) string { return s.ID + "|0|7" },
+			wantLast: "invalid first",
+			wantStep: "first",
+		},
+		{
+			name:     "cancel",
+			data:     func(s *flow.Session) string { return s.ID + "|0|cancel" },
+			wantLast: "cancelled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			engine, st := newEngine(t, quiz)
+
+			c := &fakeContext{}
+			require.NoError(t, engine.Start(c, "quiz"))
+			c.sent = nil
+			c.callback = &tele.Callback{Data: tc.data(session(t, st))}
+			require.NoError(t, engine.HandleCallback(c))
+
+			assert.Equal(t, tc.wantResponses, c.responses)
+			assert.Equal(t, tc.wantLast, c.last())
+			s := session(t, st)
+			if tc.wantStep == "" {
+				assert.Nil(t, s)
+				return
+			}
+			require.NotNil(t, s)
+			assert.Equal(t, tc.wantStep, s.Step)
+		})
+	}
+
+	t.Run("finish", func(t *testing.T) {
+		engine, st := newEngine(t, quiz)
+
+		c := &fakeContext{}
+		require.NoError(t, engine.Start(c, "quiz"))
+		c.callback = &tele.Callback{Data: session(t, st).ID + "|0|0"}
+		require.NoError(t, engine.HandleCallback(c))
+		c.callback = &tele.Callback{Data: session(t, st).ID + "|1|1"}
+		require.NoError(t, engine.HandleCallback(c))
+
+		assert.Equal(t, "done", c.last())
+		assert.Equal(t, map[string]string{"first": "a", "second": "d"}, finished)
+		assert.Nil(t, session(t, st))
+	})
+}
+
+func TestEngine_Register(t *testing.T) {
+	valid := func() flow.Flow {
+		return flow.Flow{
+			Name:     "valid",
+			Steps:    []flow.Step{textStep("first"), textStep("second")},
+			OnFinish: func(c tele.Context, s *flow.Session) error { return nil },
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		modify    func(f *flow.Flow)
+		wantPanic bool
+	}{
+		{
+			name:   "valid",
+			modify: func(f *flow.Flow) {},
+		},
+		{
+			name:      "no steps",
+			modify:    func(f *flow.Flow) { f.Steps = nil },
+			wantPanic: true,
+		},
+		{
+			name:      "no OnFinish",
+			modify:    func(f *flow.Flow) { f.OnFinish = nil },
+			wantPanic: true,
+		},
+		{
+			name:      "no Validate",
+			modify:    func(f *flow.Flow) { f.Steps[1].Validate = nil },
+			wantPanic: true,
+		},
+		{
+			name:      "no Prompt",
+			modify:    func(f *flow.Flow) { f.Steps[0].Prompt = nil },
+			wantPanic: true,
+		},
+		{
+			name:      "step without name",
+			modify:    func(f *flow.Flow) { f.Steps[0].Name = "" },
+			wantPanic: true,
+		},
+		{
+			name:      "duplicate step",
+			modify:    func(f *flow.Flow) { f.Steps[1].Name = "first" },
+			wantPanic: true,
+		},
+		{
+			name: "duplicate option",
+			modify: func(f *flow.Flow) {
+				f.Steps[0].Prompt = func(s *flow.Session) flow.Prompt {
+					return flow.Prompt{Text: "ask", Options: [][]string{{"yes", "no"}, {"yes"}}}
+				}
+			},
+			wantPanic: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := valid()
+			tc.modify(&f)
+			engine := flow.NewEngine(testutils.NewTestLogger(), state.NewMemory(0), opts)
+			register := func() { engine.Register(f) }
+			if tc.wantPanic {
+				assert.Panics(t, register)
+				return
+			}
+			assert.NotPanics(t, register)
+		})
+	}
+}
+
+func TestEngine_LoadInvalidFlow(t *testing.T) {
+	loadOpts := opts
+	loadOpts.Load = func(name string) (flow.Flow, error) {
+		return flow.Flow{Name: name, Steps: []flow.Step{textStep("first")}}, nil
+	}
+	engine := flow.NewEngine(testutils.NewTestLogger(), state.NewMemory(0), loadOpts)
+
+	assert.Error(t, engine.Start(&fakeContext{}, "loaded"))
+}
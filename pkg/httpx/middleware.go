@@ -17,6 +17,11 @@ func (rec *statusRecorder) WriteHeader(code int) {
 	rec.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap allows http.ResponseController to flush streamed responses through the recorder
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
 func NewLoggerMiddleware(logger *slog.Logger) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -64,7 +69,7 @@ func NewAuthMiddleware(validate AuthFunc) Middleware {
 				WriteError(w, "invalid authorization format", http.StatusUnauthorized)
 				return
 			}
-			ctx, err := validate(parts[1])
+			ctx, err := validate(r.Context(), parts[1])
 			if err != nil {
 				WriteError(w, "invalid or expired token", http.StatusUnauthorized)
 				return
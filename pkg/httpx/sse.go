@@ -0,0 +1,44 @@
+package httpx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// EventStream writes server-sent events, headers are sent with the first event,
+// so handler can still respond with regular error before anything is streamed.
+type EventStream struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	started bool
+}
+
+func NewEventStream(w http.ResponseWriter) *EventStream {
+	return &EventStream{w: w, rc: http.NewResponseController(w)}
+}
+
+// Started reports whether any event was written
+func (s *EventStream) Started() bool {
+	return s.started
+}
+
+// Send writes event with json encoded payload and flushes it to the client
+func (s *EventStream) Send(event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.Header().Set("Connection", "keep-alive")
+		s.w.Header().Set("X-Accel-Buffering", "no")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
@@ -20,4 +20,5 @@ type Response struct {
 
 type Middleware func(http.Handler) http.Handler
 
-type AuthFunc func(token string) (context.Context, error)
+// AuthFunc validates token and returns ctx derived from request context with auth info
+type AuthFunc func(ctx context.Context, token string) (context.Context, error)
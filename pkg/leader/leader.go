@@ -0,0 +1,155 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Status is leadership state of this instance
+type Status struct {
+	Instance string     `json:"instance" example:"bot-7d9f-1"`
+	Leader   bool       `json:"leader" example:"true"`
+	Since    *time.Time `json:"since,omitempty"`
+}
+
+// Elector elects one leader among instances with postgres session advisory lock.
+// Lock is held by a dedicated connection, when leader dies postgres releases the lock
+// with its session and another instance takes it on the next attempt.
+type Elector struct {
+	logger   *slog.Logger
+	db       *sqlx.DB
+	key      int64
+	interval time.Duration
+	instance string
+
+	mu     sync.RWMutex
+	leader bool
+	since  time.Time
+}
+
+// New creates elector, instances compete for the same key, interval is period of lock attempts and health checks
+func New(logger *slog.Logger, db *sqlx.DB, key int64, interval time.Duration) *Elector {
+	host, _ := os.Hostname()
+	instance := fmt.Sprintf("%s-%d", host, os.Getpid())
+	return &Elector{
+		logger:   logger.With(slog.String("instance", instance), slog.Int64("lock_key", key)),
+		db:       db,
+		key:      key,
+		interval: interval,
+		instance: instance,
+	}
+}
+
+func (e *Elector) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	status := Status{Instance: e.instance, Leader: e.leader}
+	if e.leader {
+		since := e.since
+		status.Since = &since
+	}
+	return status
+}
+
+func (e *Elector) IsLeader() bool {
+	return e.Status().Leader
+}
+
+// Run calls work while this instance is leader, until ctx is done.
+// Context of work is cancelled when leadership is lost, work must return after that.
+func (e *Elector) Run(ctx context.Context, work func(ctx context.Context)) {
+	e.logger.Info("joining leader election")
+	for {
+		if conn := e.acquire(ctx); conn != nil {
+			e.lead(ctx, conn, work)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// acquire returns connection holding the lock, or nil when lock is taken by another instance
+func (e *Elector) acquire(ctx context.Context) *sql.Conn {
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		e.logger.Error("failed to get connection for leader lock", "error", err)
+		return nil
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", e.key).Scan(&locked); err != nil {
+		e.logger.Error("failed to try leader lock", "error", err)
+		discard(conn)
+		return nil
+	}
+	if !locked {
+		e.logger.Debug("leader lock is held by another instance")
+		conn.Close()
+		return nil
+	}
+	return conn
+}
+
+func (e *Elector) lead(ctx context.Context, conn *sql.Conn, work func(ctx context.Context)) {
+	e.setLeader(true)
+	e.logger.Info("leadership acquired")
+
+	workCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		work(workCtx)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			e.logger.Info("leadership released on shutdown")
+			break loop
+		case <-done:
+			e.logger.Warn("leader work returned, releasing leadership")
+			break loop
+		case <-ticker.C:
+			// lock lives as long as the session, so healthy connection means lock is still held
+			checkCtx, checkCancel := context.WithTimeout(ctx, e.interval)
+			err := conn.PingContext(checkCtx)
+			checkCancel()
+			if err != nil && ctx.Err() == nil {
+				e.logger.Error("leadership lost, lock connection is broken", "error", err)
+				break loop
+			}
+		}
+	}
+
+	cancel()
+	<-done
+	e.setLeader(false)
+	// connection is closed instead of unlocking, so the lock can not stay in a pooled session
+	discard(conn)
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = leader
+	e.since = time.Now()
+}
+
+// discard closes connection for good, returning it to pool would keep session locks
+func discard(conn *sql.Conn) {
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Message is notification for admins, chats get Text and webhooks get whole message as JSON
+type Message struct {
+	Event string    `json:"event"`
+	Text  string    `json:"text"`
+	Data  any       `json:"data,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Multi sends message with every notifier, it fails if any of them failed
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type Options struct {
+	// Token and ChatID of bot which sends messages to admin chat, zero ChatID disables chat
+	Token  string
+	ChatID int64
+	// WebhookURL receives messages as JSON, empty url disables webhook
+	WebhookURL string
+	Timeout    time.Duration
+}
+
+// New returns notifier of configured channels, without channels messages are dropped
+func New(opts Options) Multi {
+	client := &http.Client{Timeout: opts.Timeout}
+	var m Multi
+	if opts.ChatID != 0 {
+		m = append(m, NewTelegram(client, opts.Token, opts.ChatID))
+	}
+	if opts.WebhookURL != "" {
+		m = append(m, NewWebhook(client, opts.WebhookURL))
+	}
+	return m
+}
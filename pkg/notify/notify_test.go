@@ -0,0 +1,75 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelegramNotifier_Notify(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "bad chat", status: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/botsecret/sendMessage", r.URL.Path)
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]any{"chat_id": float64(-100), "text": "queue is empty"}, body)
+				w.WriteHeader(tc.status)
+				w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+			}))
+			defer server.Close()
+
+			n := notify.NewTelegramWithURL(server.Client(), server.URL, "secret", -100)
+			err := n.Notify(context.Background(), notify.Message{Event: "queue_depth", Text: "queue is empty"})
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "chat not found")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTelegramNotifier_HidesToken(t *testing.T) {
+	n := notify.NewTelegramWithURL(http.DefaultClient, "http://127.0.0.1:0", "secret", 1)
+	err := n.Notify(context.Background(), notify.Message{Text: "text"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestMulti_Notify(t *testing.T) {
+	sentAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	var got notify.Message
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer webhook.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	n := notify.Multi{notify.NewWebhook(webhook.Client(), failing.URL), notify.NewWebhook(webhook.Client(), webhook.URL)}
+	err := n.Notify(context.Background(), notify.Message{Event: "queue_depth", Text: "text", Data: map[string]int{"posts": 1}, Time: sentAt})
+	assert.ErrorContains(t, err, "unexpected status 500")
+	assert.Equal(t, "queue_depth", got.Event)
+	assert.Equal(t, "text", got.Text)
+	assert.Equal(t, map[string]any{"posts": float64(1)}, got.Data)
+	assert.Equal(t, sentAt, got.Time)
+}
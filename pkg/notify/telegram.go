@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const telegramURL = "https://api.telegram.org"
+
+// telegramNotifier sends messages with bot api directly, so it works without running bot poller
+type telegramNotifier struct {
+	client *http.Client
+	url    string
+	chatID int64
+}
+
+func NewTelegram(client *http.Client, token string, chatID int64) Notifier {
+	return NewTelegramWithURL(client, telegramURL, token, chatID)
+}
+
+func NewTelegramWithURL(client *http.Client, baseURL, token string, chatID int64) Notifier {
+	return &telegramNotifier{
+		client: client,
+		url:    fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(baseURL, "/"), token),
+		chatID: chatID,
+	}
+}
+
+type sendMessageRequest struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func (n *telegramNotifier) Notify(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(sendMessageRequest{ChatID: n.chatID, Text: msg.Text})
+	if err != nil {
+		return fmt.Errorf("telegram: failed to marshal message: %w", err)
+	}
+	return post(ctx, n.client, n.url, body)
+}
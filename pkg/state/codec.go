@@ -0,0 +1,64 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var ErrUnknownType = errors.New("unknown state type")
+
+var registry = struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}{types: make(map[string]reflect.Type), names: make(map[reflect.Type]string)}
+
+// Register makes type of value storable under name. Value must be a pointer to struct,
+// name is persisted and must not change while states of the type may be stored.
+func Register(name string, value any) {
+	t := reflect.TypeOf(value)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("state: %s must be a pointer to struct, got %T", name, value))
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, ok := registry.types[name]; ok {
+		panic(fmt.Sprintf("state: %s is already registered", name))
+	}
+	registry.types[name] = t
+	registry.names[t] = name
+}
+
+func encode(value any) (string, []byte, error) {
+	registry.mu.RLock()
+	name, ok := registry.names[reflect.TypeOf(value)]
+	registry.mu.RUnlock()
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %T", ErrUnknownType, value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return name, data, nil
+}
+
+func decode(name string, data []byte) (any, error) {
+	registry.mu.RLock()
+	t, ok := registry.types[name]
+	registry.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, name)
+	}
+
+	value := reflect.New(t.Elem()).Interface()
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return value, nil
+}
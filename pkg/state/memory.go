@@ -0,0 +1,81 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	name      string
+	data      []byte
+	expiresAt time.Time
+}
+
+// memoryState is process local, states are lost on restart
+type memoryState struct {
+	mu   sync.RWMutex
+	data map[int64]entry
+	ttl  time.Duration
+}
+
+// NewMemory creates in-memory state, zero ttl means states never expire
+func NewMemory(ttl time.Duration) State {
+	return &memoryState{data: make(map[int64]entry), ttl: ttl}
+}
+
+// Set stores encoded copy of value, so it behaves the same as durable implementations
+func (s *memoryState) Set(ctx context.Context, key int64, value any) error {
+	name, data, err := encode(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = entry{name: name, data: data, expiresAt: expiresAt(s.ttl)}
+	return nil
+}
+
+func (s *memoryState) Get(ctx context.Context, key int64) (any, error) {
+	s.mu.RLock()
+	e, ok := s.data[key]
+	s.mu.RUnlock()
+	if !ok || expired(e.expiresAt) {
+		return nil, nil
+	}
+	return decode(e.name, e.data)
+}
+
+func (s *memoryState) Delete(ctx context.Context, key int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func (s *memoryState) Cleanup(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed int64
+	for key, e := range s.data {
+		if expired(e.expiresAt) {
+			delete(s.data, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// expiresAt returns zero time for states without ttl
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(at time.Time) bool {
+	return !at.IsZero() && time.Now().After(at)
+}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// postgresState stores states in bot_states table, it survives restarts and is shared by replicas
+type postgresState struct {
+	db  *sqlx.DB
+	ttl time.Duration
+}
+
+// NewPostgres creates durable state, zero ttl means states never expire
+func NewPostgres(db *sqlx.DB, ttl time.Duration) State {
+	return &postgresState{db: db, ttl: ttl}
+}
+
+func (s *postgresState) Set(ctx context.Context, key int64, value any) error {
+	name, data, err := encode(value)
+	if err != nil {
+		return err
+	}
+
+	var exp sql.NullTime
+	if at := expiresAt(s.ttl); !at.IsZero() {
+		exp = sql.NullTime{Time: at, Valid: true}
+	}
+
+	query := `INSERT INTO bot_states (user_id, kind, data, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET kind = EXCLUDED.kind, data = EXCLUDED.data, expires_at = EXCLUDED.expires_at`
+	if _, err := s.db.ExecContext(ctx, query, key, name, data, exp); err != nil {
+		return fmt.Errorf("failed to set state: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresState) Get(ctx context.Context, key int64) (any, error) {
+	query := `SELECT kind, data FROM bot_states WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW())`
+
+	var row struct {
+		Kind string `db:"kind"`
+		Data []byte `db:"data"`
+	}
+	if err := s.db.GetContext(ctx, &row, query, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get state: %w", err)
+	}
+	return decode(row.Kind, row.Data)
+}
+
+func (s *postgresState) Delete(ctx context.Context, key int64) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM bot_states WHERE user_id = $1`, key); err != nil {
+		return fmt.Errorf("failed to delete state: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresState) Cleanup(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM bot_states WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup states: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,104 @@
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPostgres needs a database, tests are skipped when TEST_POSTGRES_URL is not set.
+// Every test gets its own schema with bot_states table created by migration.
+func newPostgres(t *testing.T, ttl time.Duration) state.State {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_URL")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	admin, err := sqlx.Connect("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("state_test_%d", time.Now().UnixNano())
+	_, err = admin.Exec("CREATE SCHEMA " + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	// unknown url parameters are sent to postgres as session settings
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	db, err := sqlx.Connect("postgres", u.String())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	migration, err := os.ReadFile("../../migrations/000012_add_bot_states.up.sql")
+	require.NoError(t, err)
+	_, err = db.Exec(string(migration))
+	require.NoError(t, err)
+
+	return state.NewPostgres(db, ttl)
+}
+
+func TestPostgres_SetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newPostgres(t, 0)
+
+	got, err := s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 1, Tags: []string{"a"}}))
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 2}))
+	got, err = s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &counterState{Count: 2}, got)
+
+	require.NoError(t, s.Delete(ctx, 1))
+	got, err = s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestPostgres_UnknownType(t *testing.T) {
+	s := newPostgres(t, 0)
+	err := s.Set(context.Background(), 1, &unregisteredState{})
+	assert.ErrorIs(t, err, state.ErrUnknownType)
+}
+
+func TestPostgres_TTL(t *testing.T) {
+	ctx := context.Background()
+	s := newPostgres(t, 200*time.Millisecond)
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 1}))
+
+	got, err := s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, s.Set(ctx, 2, &counterState{Count: 2}))
+
+	got, err = s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	removed, err := s.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	got, err = s.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &counterState{Count: 2}, got)
+}
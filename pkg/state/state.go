@@ -1,38 +1,40 @@
 package state
 
 import (
-	"sync"
+	"context"
+	"log/slog"
+	"time"
 )
 
-type state struct {
-	mu   sync.RWMutex
-	data map[int64]any
-}
-
+// State keeps per user conversation state. Values are pointers to types added with Register,
+// so any implementation can serialize them. Changed values must be Set again.
 type State interface {
-	Set(key int64, value any)
-	Get(key int64) any
-	Delete(key int64)
-}
-
-func NewState() State {
-	return &state{data: make(map[int64]any)}
+	Set(ctx context.Context, key int64, value any) error
+	// Get returns nil value when there is no state or it is expired
+	Get(ctx context.Context, key int64) (any, error)
+	Delete(ctx context.Context, key int64) error
+	// Cleanup removes expired states and returns their number
+	Cleanup(ctx context.Context) (int64, error)
 }
 
-func (s *state) Set(key int64, value any) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.data[key] = value
-}
-
-func (s *state) Get(key int64) any {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.data[key]
-}
+// RunCleanup removes expired states every interval until ctx is done
+func RunCleanup(ctx context.Context, logger *slog.Logger, s State, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-func (s *state) Delete(key int64) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	delete(s.data, key)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Cleanup(ctx)
+			if err != nil {
+				logger.Error("failed to cleanup state", "error", err)
+				continue
+			}
+			if removed > 0 {
+				logger.Debug("expired state removed", "count", removed)
+			}
+		}
+	}
 }
@@ -0,0 +1,133 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SergeyBogomolovv/fitflow/pkg/flow"
+	"github.com/SergeyBogomolovv/fitflow/pkg/state"
+	testutils "github.com/SergeyBogomolovv/fitflow/pkg/test_utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counterState struct {
+	Count int      `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func init() {
+	state.Register("test_counter", &counterState{})
+}
+
+type unregisteredState struct{}
+
+func TestMemory_RoundTrip(t *testing.T) {
+	updated := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		value any
+	}{
+		{
+			name:  "local type",
+			value: &counterState{Count: 3, Tags: []string{"a", "b"}},
+		},
+		{
+			name: "flow session",
+			value: &flow.Session{
+				ID:        "abc",
+				Flow:      "survey",
+				Step:      "days",
+				History:   []string{"goal"},
+				Data:      map[string]string{"goal": "strength"},
+				UpdatedAt: updated,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := state.NewMemory(0)
+			require.NoError(t, s.Set(context.Background(), 1, tc.value))
+
+			got, err := s.Get(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, got)
+			// stored value is a copy, changes are not visible until Set
+			assert.NotSame(t, tc.value, got)
+		})
+	}
+}
+
+func TestMemory_UnknownType(t *testing.T) {
+	s := state.NewMemory(0)
+	err := s.Set(context.Background(), 1, &unregisteredState{})
+	assert.ErrorIs(t, err, state.ErrUnknownType)
+}
+
+func TestRegister(t *testing.T) {
+	assert.Panics(t, func() { state.Register("test_value", counterState{}) })
+	assert.Panics(t, func() { state.Register("test_counter", &unregisteredState{}) })
+}
+
+func TestMemory_TTL(t *testing.T) {
+	ctx := context.Background()
+	s := state.NewMemory(20 * time.Millisecond)
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 1}))
+
+	got, err := s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, s.Set(ctx, 2, &counterState{Count: 2}))
+
+	got, err = s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	removed, err := s.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	got, err = s.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &counterState{Count: 2}, got)
+}
+
+func TestMemory_WithoutTTL(t *testing.T) {
+	ctx := context.Background()
+	s := state.NewMemory(0)
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 1}))
+
+	removed, err := s.Cleanup(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	require.NoError(t, s.Delete(ctx, 1))
+	got, err := s.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRunCleanup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := state.NewMemory(10 * time.Millisecond)
+	require.NoError(t, s.Set(ctx, 1, &counterState{Count: 1}))
+
+	done := make(chan struct{})
+	go func() {
+		state.RunCleanup(ctx, testutils.NewTestLogger(), s, 5*time.Millisecond)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	// expired state was removed by the loop already
+	removed, err := s.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}